@@ -0,0 +1,120 @@
+package mongodb
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/portofolio-mager/goravel-mongodb/contracts"
+)
+
+var _ contracts.Aggregation = &Aggregation{}
+
+// The actions of Aggregation.Merge for the documents already in the target
+const (
+	MergeWhenMatchedReplace      = "replace"
+	MergeWhenMatchedKeepExisting = "keepExisting"
+	MergeWhenMatchedMerge        = "merge"
+	MergeWhenMatchedFail         = "fail"
+)
+
+type Aggregation struct {
+	collection *mongo.Collection
+	pipeline   interface{}
+	options    []*options.AggregateOptions
+	// ctx is the parent of the contexts of the operations, the one of the collection
+	ctx context.Context
+}
+
+func newAggregation(collection *mongo.Collection, pipeline interface{}, opts ...interface{}) *Aggregation {
+	aggregation := &Aggregation{
+		collection: collection,
+		pipeline:   pipeline,
+	}
+	for _, opt := range opts {
+		if opt, ok := opt.(*options.AggregateOptions); ok {
+			aggregation.options = append(aggregation.options, opt)
+		}
+	}
+
+	return aggregation
+}
+
+func (a *Aggregation) All(results interface{}) error {
+	ctx, cancel := operationContext(a.ctx, 30*time.Second)
+	defer cancel()
+
+	pipeline, err := stages(a.pipeline)
+	if err != nil {
+		return err
+	}
+
+	cursor, err := a.collection.Aggregate(ctx, pipeline, a.options...)
+	if err != nil {
+		return err
+	}
+
+	return cursor.All(ctx, results)
+}
+
+// Merge refreshes a materialised view: the results are written into the target
+// collection of the same database, which is created when missing
+func (a *Aggregation) Merge(target string, on []string, whenMatched string) error {
+	ctx, cancel := operationContext(a.ctx, 30*time.Second)
+	defer cancel()
+
+	pipeline, err := stages(a.pipeline)
+	if err != nil {
+		return err
+	}
+
+	merge := bson.D{{Key: "into", Value: target}}
+	if len(on) > 0 {
+		merge = append(merge, bson.E{Key: "on", Value: on})
+	}
+	if whenMatched != "" {
+		merge = append(merge, bson.E{Key: "whenMatched", Value: whenMatched})
+	}
+	pipeline = append(pipeline, bson.D{{Key: "$merge", Value: merge}})
+
+	cursor, err := a.collection.Aggregate(ctx, pipeline, a.options...)
+	if err != nil {
+		return fmt.Errorf("failed to merge %s into %s: %w", a.collection.Name(), target, err)
+	}
+
+	return cursor.Close(ctx)
+}
+
+// stages returns the stages of a pipeline given as mongo.Pipeline, []bson.D,
+// []bson.M or []interface{}, nil is an empty pipeline. A single document like
+// bson.D is rejected rather than split into a stage per field.
+func stages(pipeline interface{}) (bson.A, error) {
+	result := bson.A{}
+	switch pipeline := pipeline.(type) {
+	case nil:
+	case mongo.Pipeline:
+		for _, stage := range pipeline {
+			result = append(result, stage)
+		}
+	case []bson.D:
+		for _, stage := range pipeline {
+			result = append(result, stage)
+		}
+	case []bson.M:
+		for _, stage := range pipeline {
+			result = append(result, stage)
+		}
+	case bson.A:
+		result = append(result, pipeline...)
+	case []interface{}:
+		result = append(result, pipeline...)
+	default:
+		return nil, fmt.Errorf("pipeline must be a slice of stages, got %T", pipeline)
+	}
+
+	return result, nil
+}
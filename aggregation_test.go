@@ -0,0 +1,43 @@
+package mongodb
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type AggregationSuite struct {
+	suite.Suite
+}
+
+func TestAggregationSuite(t *testing.T) {
+	suite.Run(t, &AggregationSuite{})
+}
+
+func (s *AggregationSuite) TestStages() {
+	pipeline, err := stages(nil)
+	s.NoError(err)
+	s.Equal(bson.A{}, pipeline)
+
+	match := bson.D{{Key: "$match", Value: bson.D{{Key: "age", Value: 18}}}}
+	pipeline, err = stages(mongo.Pipeline{match})
+	s.NoError(err)
+	s.Equal(bson.A{match}, pipeline)
+
+	pipeline, err = stages([]bson.M{{"$limit": 1}})
+	s.NoError(err)
+	s.Equal(bson.A{bson.M{"$limit": 1}}, pipeline)
+
+	pipeline, err = stages(bson.A{match})
+	s.NoError(err)
+	s.Equal(bson.A{match}, pipeline)
+
+	_, err = stages(bson.M{"$limit": 1})
+	s.EqualError(err, "pipeline must be a slice of stages, got primitive.M")
+
+	// A single stage is not split into a stage per field
+	_, err = stages(match)
+	s.EqualError(err, "pipeline must be a slice of stages, got primitive.D")
+}
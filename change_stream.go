@@ -0,0 +1,231 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/portofolio-mager/goravel-mongodb/contracts"
+)
+
+var _ contracts.ChangeStream = &ChangeStream{}
+var _ contracts.ResumeTokenStore = &MemoryResumeTokenStore{}
+var _ contracts.ResumeTokenStore = &CollectionResumeTokenStore{}
+
+// ResumeOptions can be passed to Watch to resume the stream from the token
+// stored under Key and to persist the tokens of the processed events
+type ResumeOptions struct {
+	Store contracts.ResumeTokenStore
+	Key   string
+}
+
+type watcher interface {
+	Watch(ctx context.Context, pipeline interface{}, opts ...*options.ChangeStreamOptions) (*mongo.ChangeStream, error)
+}
+
+type ChangeStream struct {
+	stream *mongo.ChangeStream
+	resume *ResumeOptions
+	// pending is true when the current event has not been persisted yet
+	pending bool
+	err     error
+}
+
+// watch opens a change stream with parent as the parent of its context, the
+// opts can be *options.ChangeStreamOptions and *ResumeOptions
+func watch(parent context.Context, target watcher, pipeline interface{}, opts ...interface{}) (*ChangeStream, error) {
+	ctx, cancel := operationContext(parent, 30*time.Second)
+	defer cancel()
+
+	if pipeline == nil {
+		pipeline = mongo.Pipeline{}
+	}
+
+	var streamOpts []*options.ChangeStreamOptions
+	var resume *ResumeOptions
+	for _, opt := range opts {
+		switch opt := opt.(type) {
+		case *options.ChangeStreamOptions:
+			streamOpts = append(streamOpts, opt)
+		case *ResumeOptions:
+			resume = opt
+		}
+	}
+	mergedOpts := options.MergeChangeStreamOptions(streamOpts...)
+
+	if resume != nil && resume.Store != nil {
+		token, err := resume.Store.Get(resume.Key)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load resume token: %w", err)
+		}
+		if token != nil {
+			// StartAfter also resumes after an invalidate event
+			mergedOpts.ResumeAfter = nil
+			mergedOpts.StartAtOperationTime = nil
+			mergedOpts.SetStartAfter(token)
+		}
+	}
+
+	stream, err := target.Watch(ctx, pipeline, mergedOpts)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ChangeStream{
+		stream: stream,
+		resume: resume,
+	}, nil
+}
+
+func (r *ChangeStream) Native() *mongo.ChangeStream {
+	return r.stream
+}
+
+// Next persists the resume token of the previous event, as the consumer has
+// processed it, then waits for the next event
+func (r *ChangeStream) Next(ctx context.Context) bool {
+	if !r.persist() {
+		return false
+	}
+
+	r.pending = r.stream.Next(ctx)
+	return r.pending
+}
+
+func (r *ChangeStream) TryNext(ctx context.Context) bool {
+	if !r.persist() {
+		return false
+	}
+
+	r.pending = r.stream.TryNext(ctx)
+	return r.pending
+}
+
+func (r *ChangeStream) Event() (*contracts.ChangeEvent, error) {
+	var event contracts.ChangeEvent
+	if err := r.stream.Decode(&event); err != nil {
+		return nil, err
+	}
+
+	return &event, nil
+}
+
+func (r *ChangeStream) Decode(val interface{}) error {
+	return r.stream.Decode(val)
+}
+
+func (r *ChangeStream) ResumeToken() bson.Raw {
+	return r.stream.ResumeToken()
+}
+
+func (r *ChangeStream) SaveResumeToken() error {
+	if r.resume == nil || r.resume.Store == nil {
+		return nil
+	}
+
+	token := r.stream.ResumeToken()
+	if token == nil {
+		return nil
+	}
+
+	if err := r.resume.Store.Put(r.resume.Key, token); err != nil {
+		return fmt.Errorf("failed to save resume token: %w", err)
+	}
+	r.pending = false
+
+	return nil
+}
+
+func (r *ChangeStream) Err() error {
+	if r.err != nil {
+		return r.err
+	}
+
+	return r.stream.Err()
+}
+
+func (r *ChangeStream) Close() error {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	return r.stream.Close(ctx)
+}
+
+func (r *ChangeStream) persist() bool {
+	if !r.pending {
+		return true
+	}
+
+	if err := r.SaveResumeToken(); err != nil {
+		r.err = err
+		return false
+	}
+
+	return true
+}
+
+// MemoryResumeTokenStore keeps the resume tokens in memory, it is mostly
+// useful for tests and short lived consumers
+type MemoryResumeTokenStore struct {
+	mu     sync.RWMutex
+	tokens map[string]bson.Raw
+}
+
+func NewMemoryResumeTokenStore() *MemoryResumeTokenStore {
+	return &MemoryResumeTokenStore{
+		tokens: make(map[string]bson.Raw),
+	}
+}
+
+func (r *MemoryResumeTokenStore) Get(key string) (bson.Raw, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.tokens[key], nil
+}
+
+func (r *MemoryResumeTokenStore) Put(key string, token bson.Raw) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.tokens[key] = token
+	return nil
+}
+
+// CollectionResumeTokenStore persists the resume tokens in a collection,
+// one document per key
+type CollectionResumeTokenStore struct {
+	collection contracts.Collection
+}
+
+func NewCollectionResumeTokenStore(collection contracts.Collection) *CollectionResumeTokenStore {
+	return &CollectionResumeTokenStore{
+		collection: collection,
+	}
+}
+
+func (r *CollectionResumeTokenStore) Get(key string) (bson.Raw, error) {
+	var document struct {
+		Token bson.Raw `bson:"token"`
+	}
+	if err := r.collection.FindOne(bson.M{"_id": key}, &document); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return document.Token, nil
+}
+
+func (r *CollectionResumeTokenStore) Put(key string, token bson.Raw) error {
+	update := bson.M{"$set": bson.M{"token": token, "updated_at": time.Now()}}
+	_, err := r.collection.UpdateOne(bson.M{"_id": key}, update, options.Update().SetUpsert(true))
+	return err
+}
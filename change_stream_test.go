@@ -0,0 +1,113 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	mockscontracts "github.com/portofolio-mager/goravel-mongodb/mocks"
+)
+
+// testWatcher records the options of Watch and fails it
+type testWatcher struct {
+	opts []*options.ChangeStreamOptions
+}
+
+func (r *testWatcher) Watch(_ context.Context, _ interface{}, opts ...*options.ChangeStreamOptions) (*mongo.ChangeStream, error) {
+	r.opts = opts
+	return nil, errors.New("watch failed")
+}
+
+type ChangeStreamTestSuite struct {
+	suite.Suite
+	token bson.Raw
+}
+
+func TestChangeStreamTestSuite(t *testing.T) {
+	suite.Run(t, new(ChangeStreamTestSuite))
+}
+
+func (s *ChangeStreamTestSuite) SetupTest() {
+	token, err := bson.Marshal(bson.M{"_data": "8263"})
+	s.Require().NoError(err)
+	s.token = token
+}
+
+func (s *ChangeStreamTestSuite) TestMemoryResumeTokenStore() {
+	store := NewMemoryResumeTokenStore()
+
+	token, err := store.Get("orders")
+	s.NoError(err)
+	s.Nil(token)
+
+	s.NoError(store.Put("orders", s.token))
+	token, err = store.Get("orders")
+	s.NoError(err)
+	s.Equal(s.token, token)
+}
+
+func (s *ChangeStreamTestSuite) TestCollectionResumeTokenStore() {
+	collection := mockscontracts.NewCollection(s.T())
+	store := NewCollectionResumeTokenStore(collection)
+
+	collection.EXPECT().FindOne(bson.M{"_id": "orders"}, mock.Anything).Return(mongo.ErrNoDocuments).Once()
+	token, err := store.Get("orders")
+	s.NoError(err)
+	s.Nil(token)
+
+	collection.EXPECT().FindOne(bson.M{"_id": "orders"}, mock.Anything).RunAndReturn(func(filter interface{}, result interface{}, opts ...interface{}) error {
+		document, err := bson.Marshal(bson.M{"token": s.token})
+		s.Require().NoError(err)
+		return bson.Unmarshal(document, result)
+	}).Once()
+	token, err = store.Get("orders")
+	s.NoError(err)
+	s.Equal(s.token, token)
+
+	collection.EXPECT().UpdateOne(bson.M{"_id": "orders"}, mock.Anything, mock.Anything).Return(&mongo.UpdateResult{UpsertedCount: 1}, nil).Once()
+	s.NoError(store.Put("orders", s.token))
+}
+
+func (s *ChangeStreamTestSuite) TestWatch_StoreError() {
+	store := mockscontracts.NewResumeTokenStore(s.T())
+	store.EXPECT().Get("orders").Return(nil, errors.New("unavailable")).Once()
+
+	stream, err := watch(nil, nil, nil, &ResumeOptions{Store: store, Key: "orders"})
+	s.EqualError(err, "failed to load resume token: unavailable")
+	s.Nil(stream)
+}
+
+func (s *ChangeStreamTestSuite) TestWatch_MergesOptions() {
+	target := &testWatcher{}
+	_, err := watch(nil, target, nil,
+		options.ChangeStream().SetFullDocument(options.UpdateLookup),
+		options.ChangeStream().SetBatchSize(10),
+	)
+	s.EqualError(err, "watch failed")
+
+	s.Require().Len(target.opts, 1)
+	s.Equal(options.UpdateLookup, *target.opts[0].FullDocument)
+	s.Equal(int32(10), *target.opts[0].BatchSize)
+}
+
+func (s *ChangeStreamTestSuite) TestWatch_ErrorReturnsNilStream() {
+	client, err := mongo.Connect(context.Background(), options.Client().ApplyURI("mongodb://localhost:27017").SetServerSelectionTimeout(time.Second))
+	s.Require().NoError(err)
+	s.Require().NoError(client.Disconnect(context.Background()))
+
+	// A nil *ChangeStream in the interface would make the caller's Close panic
+	stream, err := NewCollection(client, nil, "orders", "shop").Watch(nil)
+	s.Error(err)
+	s.True(stream == nil)
+
+	stream, err = NewDatabase(client, nil, "shop").Watch(nil)
+	s.Error(err)
+	s.True(stream == nil)
+}
@@ -2,6 +2,7 @@ package mongodb
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	"go.mongodb.org/mongo-driver/bson"
@@ -17,6 +18,10 @@ type Collection struct {
 	client     *mongo.Client
 	collection *mongo.Collection
 	config     contracts.ConfigBuilder
+	scopes     *scopeRegistry
+	observers  *observerRegistry
+	// ctx is the parent of the contexts of the operations, set by WithContext
+	ctx context.Context
 }
 
 func NewCollection(client *mongo.Client, config contracts.ConfigBuilder, name string, database string) *Collection {
@@ -24,6 +29,8 @@ func NewCollection(client *mongo.Client, config contracts.ConfigBuilder, name st
 		client:     client,
 		collection: client.Database(database).Collection(name),
 		config:     config,
+		scopes:     newScopeRegistry(),
+		observers:  newObserverRegistry(),
 	}
 }
 
@@ -33,7 +40,7 @@ func (c *Collection) Native() *mongo.Collection {
 
 // Basic CRUD operations
 func (c *Collection) FindOne(filter interface{}, result interface{}, opts ...interface{}) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	ctx, cancel := operationContext(c.ctx, 30*time.Second)
 	defer cancel()
 
 	var findOpts *options.FindOneOptions
@@ -43,11 +50,16 @@ func (c *Collection) FindOne(filter interface{}, result interface{}, opts ...int
 		}
 	}
 
-	return c.collection.FindOne(ctx, filter, findOpts).Decode(result)
+	filter = c.scoped(filter)
+	if err := c.collection.FindOne(ctx, filter, findOpts).Decode(result); err != nil {
+		return err
+	}
+
+	return c.dispatch(ctx, contracts.EventRetrieved, result, filter, nil)
 }
 
 func (c *Collection) Find(filter interface{}, opts ...interface{}) (*mongo.Cursor, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	ctx, cancel := operationContext(c.ctx, 30*time.Second)
 	defer cancel()
 
 	var findOpts *options.FindOptions
@@ -57,11 +69,11 @@ func (c *Collection) Find(filter interface{}, opts ...interface{}) (*mongo.Curso
 		}
 	}
 
-	return c.collection.Find(ctx, filter, findOpts)
+	return c.collection.Find(ctx, c.scoped(filter), findOpts)
 }
 
 func (c *Collection) InsertOne(document interface{}, opts ...interface{}) (*mongo.InsertOneResult, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	ctx, cancel := operationContext(c.ctx, 30*time.Second)
 	defer cancel()
 
 	var insertOpts *options.InsertOneOptions
@@ -71,11 +83,20 @@ func (c *Collection) InsertOne(document interface{}, opts ...interface{}) (*mong
 		}
 	}
 
-	return c.collection.InsertOne(ctx, document, insertOpts)
+	if err := c.dispatch(ctx, contracts.EventCreating, document, nil, nil); err != nil {
+		return nil, err
+	}
+
+	result, err := c.collection.InsertOne(ctx, document, insertOpts)
+	if err != nil {
+		return nil, err
+	}
+
+	return result, c.dispatch(ctx, contracts.EventCreated, document, nil, nil)
 }
 
 func (c *Collection) InsertMany(documents []interface{}, opts ...interface{}) (*mongo.InsertManyResult, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	ctx, cancel := operationContext(c.ctx, 30*time.Second)
 	defer cancel()
 
 	var insertOpts *options.InsertManyOptions
@@ -85,11 +106,28 @@ func (c *Collection) InsertMany(documents []interface{}, opts ...interface{}) (*
 		}
 	}
 
-	return c.collection.InsertMany(ctx, documents, insertOpts)
+	for _, document := range documents {
+		if err := c.dispatch(ctx, contracts.EventCreating, document, nil, nil); err != nil {
+			return nil, err
+		}
+	}
+
+	result, err := c.collection.InsertMany(ctx, documents, insertOpts)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, document := range documents {
+		if err := c.dispatch(ctx, contracts.EventCreated, document, nil, nil); err != nil {
+			return result, err
+		}
+	}
+
+	return result, nil
 }
 
 func (c *Collection) UpdateOne(filter interface{}, update interface{}, opts ...interface{}) (*mongo.UpdateResult, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	ctx, cancel := operationContext(c.ctx, 30*time.Second)
 	defer cancel()
 
 	var updateOpts *options.UpdateOptions
@@ -99,11 +137,21 @@ func (c *Collection) UpdateOne(filter interface{}, update interface{}, opts ...i
 		}
 	}
 
-	return c.collection.UpdateOne(ctx, filter, update, updateOpts)
+	filter = c.scoped(filter)
+	if err := c.dispatch(ctx, contracts.EventUpdating, nil, filter, update); err != nil {
+		return nil, err
+	}
+
+	result, err := c.collection.UpdateOne(ctx, filter, update, updateOpts)
+	if err != nil {
+		return nil, err
+	}
+
+	return result, c.dispatch(ctx, contracts.EventUpdated, nil, filter, update)
 }
 
 func (c *Collection) UpdateMany(filter interface{}, update interface{}, opts ...interface{}) (*mongo.UpdateResult, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	ctx, cancel := operationContext(c.ctx, 30*time.Second)
 	defer cancel()
 
 	var updateOpts *options.UpdateOptions
@@ -113,11 +161,21 @@ func (c *Collection) UpdateMany(filter interface{}, update interface{}, opts ...
 		}
 	}
 
-	return c.collection.UpdateMany(ctx, filter, update, updateOpts)
+	filter = c.scoped(filter)
+	if err := c.dispatch(ctx, contracts.EventUpdating, nil, filter, update); err != nil {
+		return nil, err
+	}
+
+	result, err := c.collection.UpdateMany(ctx, filter, update, updateOpts)
+	if err != nil {
+		return nil, err
+	}
+
+	return result, c.dispatch(ctx, contracts.EventUpdated, nil, filter, update)
 }
 
 func (c *Collection) DeleteOne(filter interface{}, opts ...interface{}) (*mongo.DeleteResult, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	ctx, cancel := operationContext(c.ctx, 30*time.Second)
 	defer cancel()
 
 	var deleteOpts *options.DeleteOptions
@@ -127,11 +185,27 @@ func (c *Collection) DeleteOne(filter interface{}, opts ...interface{}) (*mongo.
 		}
 	}
 
-	return c.collection.DeleteOne(ctx, filter, deleteOpts)
+	filter = c.scoped(filter)
+	if err := c.dispatch(ctx, contracts.EventDeleting, nil, filter, nil); err != nil {
+		return nil, err
+	}
+
+	var result *mongo.DeleteResult
+	var err error
+	if c.usesSoftDeletes() {
+		result, err = c.softDelete(ctx, filter, deleteOpts, false)
+	} else {
+		result, err = c.collection.DeleteOne(ctx, filter, deleteOpts)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return result, c.dispatch(ctx, contracts.EventDeleted, nil, filter, nil)
 }
 
 func (c *Collection) DeleteMany(filter interface{}, opts ...interface{}) (*mongo.DeleteResult, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	ctx, cancel := operationContext(c.ctx, 30*time.Second)
 	defer cancel()
 
 	var deleteOpts *options.DeleteOptions
@@ -141,7 +215,23 @@ func (c *Collection) DeleteMany(filter interface{}, opts ...interface{}) (*mongo
 		}
 	}
 
-	return c.collection.DeleteMany(ctx, filter, deleteOpts)
+	filter = c.scoped(filter)
+	if err := c.dispatch(ctx, contracts.EventDeleting, nil, filter, nil); err != nil {
+		return nil, err
+	}
+
+	var result *mongo.DeleteResult
+	var err error
+	if c.usesSoftDeletes() {
+		result, err = c.softDelete(ctx, filter, deleteOpts, true)
+	} else {
+		result, err = c.collection.DeleteMany(ctx, filter, deleteOpts)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return result, c.dispatch(ctx, contracts.EventDeleted, nil, filter, nil)
 }
 
 // ORM-like convenience methods
@@ -158,23 +248,119 @@ func (c *Collection) First(result interface{}, filter ...interface{}) error {
 	return c.FindOne(f, result)
 }
 
+func (c *Collection) Query() contracts.QueryBuilder {
+	return NewQueryBuilder(c)
+}
+
 func (c *Collection) Where(field string, value interface{}) contracts.QueryBuilder {
 	return NewQueryBuilder(c).Where(field, value)
 }
 
+// Observe registers an observer for the lifecycle events of this collection,
+// it is shared by every Collection instance of the same client, database and
+// name
+func (c *Collection) Observe(observer contracts.Observer) {
+	c.observers.observe(c.namespace(), observer)
+}
+
+// WithScope adds a global scope applied to every query builder and method of
+// this collection, a scope with the same name is replaced. It is shared by
+// every Collection instance of the same client, database and name. Only the
+// conditions of a scope are applied, its modifiers like Limit, Select or
+// WithTrashed are ignored.
+func (c *Collection) WithScope(name string, scope func(contracts.QueryBuilder)) contracts.Collection {
+	c.scopes.withScope(c.namespace(), name, scope)
+	return c
+}
+
+// WithSoftDeletes makes this collection and its query builders soft delete
+// documents and exclude soft deleted documents from their results, it is
+// shared by every Collection instance of the same client, database and name
+func (c *Collection) WithSoftDeletes() contracts.Collection {
+	c.scopes.withSoftDeletes(c.namespace())
+	return c
+}
+
+// Watch opens a change stream on the collection, opts can contain a
+// *options.ChangeStreamOptions and a *ResumeOptions to resume from a stored token
+func (c *Collection) Watch(pipeline interface{}, opts ...interface{}) (contracts.ChangeStream, error) {
+	stream, err := watch(c.ctx, c.collection, pipeline, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return stream, nil
+}
+
 // Collection management
 func (c *Collection) Drop() error {
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	ctx, cancel := operationContext(c.ctx, 30*time.Second)
 	defer cancel()
 	return c.collection.Drop(ctx)
 }
 
+// Aggregate returns the aggregation running the pipeline as is, the soft
+// deletes and global scopes are not applied. The options are
+// *options.AggregateOptions.
+func (c *Collection) Aggregate(pipeline interface{}, opts ...interface{}) contracts.Aggregation {
+	aggregation := newAggregation(c.collection, pipeline, opts...)
+	aggregation.ctx = c.ctx
+
+	return aggregation
+}
+
+// SetValidator replaces the validator of the collection with collMod. The
+// schema is a validator document, or a struct its validator is generated from
+// by Validator, nil removes the validation. An empty level or action keeps the
+// current one.
+func (c *Collection) SetValidator(schema interface{}, level, action string) error {
+	ctx, cancel := operationContext(c.ctx, 30*time.Second)
+	defer cancel()
+
+	validator := schema
+	if _, ok := structType(schema); ok {
+		var err error
+		if validator, err = Validator(schema); err != nil {
+			return err
+		}
+	}
+	if validator == nil {
+		validator = bson.D{}
+	}
+
+	command := bson.D{
+		{Key: "collMod", Value: c.collection.Name()},
+		{Key: "validator", Value: validator},
+	}
+	if level != "" {
+		command = append(command, bson.E{Key: "validationLevel", Value: level})
+	}
+	if action != "" {
+		command = append(command, bson.E{Key: "validationAction", Value: action})
+	}
+
+	if err := c.collection.Database().RunCommand(ctx, command).Err(); err != nil {
+		return fmt.Errorf("failed to set the validator of %s: %w", c.collection.Name(), err)
+	}
+
+	return nil
+}
+
+// WithContext returns the collection running its operations with ctx, the
+// spans of its commands are children of the span of ctx
+func (c *Collection) WithContext(ctx context.Context) contracts.Collection {
+	collection := *c
+	collection.ctx = ctx
+
+	return &collection
+}
+
 func (c *Collection) Name() string {
 	return c.collection.Name()
 }
 
 func (c *Collection) CountDocuments(filter interface{}, opts ...interface{}) (int64, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	ctx, cancel := operationContext(c.ctx, 30*time.Second)
 	defer cancel()
 
 	var countOpts *options.CountOptions
@@ -184,5 +370,47 @@ func (c *Collection) CountDocuments(filter interface{}, opts ...interface{}) (in
 		}
 	}
 
-	return c.collection.CountDocuments(ctx, filter, countOpts)
+	return c.collection.CountDocuments(ctx, c.scoped(filter), countOpts)
+}
+
+func (c *Collection) usesSoftDeletes() bool {
+	return c.scopes.usesSoftDeletes(c.namespace())
+}
+
+func (c *Collection) namespace() string {
+	return c.collection.Database().Name() + "." + c.collection.Name()
+}
+
+// scoped adds the global scopes and the soft delete condition to the filter of
+// the collection methods
+func (c *Collection) scoped(filter interface{}) interface{} {
+	return scopeFilter(filter, NewQueryBuilder(c).buildFilter())
+}
+
+// softDelete sets the soft delete timestamp of the matching documents instead
+// of removing them
+func (c *Collection) softDelete(ctx context.Context, filter interface{}, deleteOpts *options.DeleteOptions, many bool) (*mongo.DeleteResult, error) {
+	updateOpts := options.Update()
+	if deleteOpts != nil {
+		updateOpts.Collation = deleteOpts.Collation
+		updateOpts.Hint = deleteOpts.Hint
+	}
+
+	update := bson.M{"$set": bson.M{DeletedAtField: time.Now()}}
+	var result *mongo.UpdateResult
+	var err error
+	if many {
+		result, err = c.collection.UpdateMany(ctx, filter, update, updateOpts)
+	} else {
+		result, err = c.collection.UpdateOne(ctx, filter, update, updateOpts)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &mongo.DeleteResult{DeletedCount: result.ModifiedCount}, nil
+}
+
+func (c *Collection) dispatch(ctx context.Context, eventType contracts.EventType, model, filter, update any) error {
+	return dispatchEvent(NewEvent(ctx, eventType, c, model, filter, update), c.observers.of(c.namespace()))
 }
@@ -0,0 +1,200 @@
+package mongodb
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/goravel/framework/contracts/console"
+	"github.com/goravel/framework/contracts/console/command"
+	"go.mongodb.org/mongo-driver/bson"
+
+	"github.com/portofolio-mager/goravel-mongodb/contracts"
+)
+
+type CollectionCommand struct {
+	client func(connection string) (contracts.Client, error)
+}
+
+// collectionStats are the storage statistics $collStats reports, summed over
+// the shards
+type collectionStats struct {
+	Count          int64 `bson:"count"`
+	Size           int64 `bson:"size"`
+	StorageSize    int64 `bson:"storageSize"`
+	TotalIndexSize int64 `bson:"totalIndexSize"`
+}
+
+// indexStats is an index $indexStats reports
+type indexStats struct {
+	Name     string `bson:"name"`
+	Key      bson.D `bson:"key"`
+	Spec     bson.M `bson:"spec"`
+	Accesses struct {
+		Ops int64 `bson:"ops"`
+	} `bson:"accesses"`
+}
+
+func NewCollectionCommand(client func(connection string) (contracts.Client, error)) *CollectionCommand {
+	return &CollectionCommand{
+		client: client,
+	}
+}
+
+// Signature The name and signature of the console command.
+func (r *CollectionCommand) Signature() string {
+	return "mongodb:collection"
+}
+
+// Description The console command description.
+func (r *CollectionCommand) Description() string {
+	return "Display information about the given MongoDB collection"
+}
+
+// Extend The console command extend.
+func (r *CollectionCommand) Extend() command.Extend {
+	return command.Extend{
+		ArgsUsage: " <name>",
+		Category:  "mongodb",
+		Flags: []command.Flag{
+			&command.StringFlag{
+				Name:  "connection",
+				Value: Name,
+				Usage: "the MongoDB connection",
+			},
+			&command.StringFlag{
+				Name:    "database",
+				Aliases: []string{"d"},
+				Usage:   "the database of the collection, the database of the connection by default",
+			},
+		},
+	}
+}
+
+// Handle Execute the console command.
+func (r *CollectionCommand) Handle(ctx console.Context) error {
+	name := ctx.Argument(0)
+	if name == "" {
+		ctx.Error(fmt.Sprintf("The collection name is required, usage: %s <name>", r.Signature()))
+		return nil
+	}
+
+	client, err := r.client(ctx.Option("connection"))
+	if err != nil {
+		ctx.Error(err.Error())
+		return nil
+	}
+
+	database := client.Database(ctx.Option("database"))
+	if database == nil {
+		ctx.Error(ConnectionFailed.Error())
+		return nil
+	}
+
+	info, err := database.CollectionInfo(name)
+	if err != nil {
+		ctx.Error(err.Error())
+		return nil
+	}
+
+	ctx.NewLine()
+	ctx.TwoColumnDetail(fmt.Sprintf("<fg=green;op=bold>%s</>", info.Name), "")
+	ctx.TwoColumnDetail("Database", database.Name())
+	ctx.TwoColumnDetail("Type", info.Type)
+	ctx.TwoColumnDetail("Read Only", fmt.Sprintf("%t", info.ReadOnly))
+	if info.UUID != "" {
+		ctx.TwoColumnDetail("UUID", info.UUID)
+	}
+
+	// Views have no storage and indexes of their own
+	if info.Type == "view" {
+		ctx.TwoColumnDetail("View On", fmt.Sprint(info.Options["viewOn"]))
+		ctx.NewLine()
+		return nil
+	}
+
+	collection := database.Collection(name)
+	stats, err := statsOf(collection)
+	if err != nil {
+		ctx.Error(err.Error())
+		return nil
+	}
+	indexes, err := indexesOf(collection)
+	if err != nil {
+		ctx.Error(err.Error())
+		return nil
+	}
+
+	ctx.TwoColumnDetail("Documents", fmt.Sprintf("%d", stats.Count))
+	ctx.TwoColumnDetail("Size", fmt.Sprintf("%s MB", megabytes(stats.Size)))
+	ctx.TwoColumnDetail("Storage Size", fmt.Sprintf("%s MB", megabytes(stats.StorageSize)))
+	ctx.TwoColumnDetail("Index Size", fmt.Sprintf("%s MB", megabytes(stats.TotalIndexSize)))
+	ctx.NewLine()
+
+	if len(indexes) > 0 {
+		ctx.TwoColumnDetail("<fg=green;op=bold>Indexes</>", "<fg=yellow;op=bold>Keys / Operations</>")
+		for _, index := range indexes {
+			var keys []string
+			for _, key := range index.Key {
+				keys = append(keys, fmt.Sprintf("%s: %v", key.Key, key.Value))
+			}
+
+			name := index.Name
+			if unique, _ := index.Spec["unique"].(bool); unique || index.Name == "_id_" {
+				name += " <fg=gray>unique</>"
+			}
+			ctx.TwoColumnDetail(name, fmt.Sprintf("%s / %d", strings.Join(keys, ", "), index.Accesses.Ops))
+		}
+		ctx.NewLine()
+	}
+
+	ctx.TwoColumnDetail("<fg=green;op=bold>Validator</>", "")
+	if len(info.Validator) == 0 {
+		ctx.Line("none")
+	} else {
+		validator, err := bson.MarshalExtJSONIndent(info.Validator, false, false, "", "  ")
+		if err != nil {
+			ctx.Error(err.Error())
+			return nil
+		}
+		ctx.Line(string(validator))
+	}
+	ctx.NewLine()
+
+	return nil
+}
+
+// statsOf returns the storage statistics of a collection with $collStats
+func statsOf(collection contracts.Collection) (*collectionStats, error) {
+	var results []struct {
+		StorageStats collectionStats `bson:"storageStats"`
+	}
+	if err := collection.Aggregate(bson.A{
+		bson.D{{Key: "$collStats", Value: bson.D{{Key: "storageStats", Value: bson.D{}}}}},
+	}).All(&results); err != nil {
+		return nil, fmt.Errorf("failed to get the statistics of %s: %w", collection.Name(), err)
+	}
+
+	stats := &collectionStats{}
+	for _, result := range results {
+		stats.Count += result.StorageStats.Count
+		stats.Size += result.StorageStats.Size
+		stats.StorageSize += result.StorageStats.StorageSize
+		stats.TotalIndexSize += result.StorageStats.TotalIndexSize
+	}
+
+	return stats, nil
+}
+
+// indexesOf returns the indexes of a collection with $indexStats, sorted by
+// name
+func indexesOf(collection contracts.Collection) ([]indexStats, error) {
+	var indexes []indexStats
+	if err := collection.Aggregate(bson.A{
+		bson.D{{Key: "$indexStats", Value: bson.D{}}},
+		bson.D{{Key: "$sort", Value: bson.D{{Key: "name", Value: 1}}}},
+	}).All(&indexes); err != nil {
+		return nil, fmt.Errorf("failed to get the indexes of %s: %w", collection.Name(), err)
+	}
+
+	return indexes, nil
+}
@@ -0,0 +1,150 @@
+package mongodb
+
+import (
+	"errors"
+	"testing"
+
+	mocksconsole "github.com/goravel/framework/mocks/console"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/bson"
+
+	"github.com/portofolio-mager/goravel-mongodb/contracts"
+	mockscontracts "github.com/portofolio-mager/goravel-mongodb/mocks"
+)
+
+type CollectionCommandTestSuite struct {
+	suite.Suite
+	mockContext       *mocksconsole.Context
+	mockClient        *mockscontracts.Client
+	mockDatabase      *mockscontracts.Database
+	mockCollection    *mockscontracts.Collection
+	collectionCommand *CollectionCommand
+}
+
+func TestCollectionCommandTestSuite(t *testing.T) {
+	suite.Run(t, new(CollectionCommandTestSuite))
+}
+
+func (s *CollectionCommandTestSuite) SetupTest() {
+	s.mockContext = mocksconsole.NewContext(s.T())
+	s.mockClient = mockscontracts.NewClient(s.T())
+	s.mockDatabase = mockscontracts.NewDatabase(s.T())
+	s.mockCollection = mockscontracts.NewCollection(s.T())
+	s.collectionCommand = NewCollectionCommand(func(connection string) (contracts.Client, error) {
+		s.Equal("mongodb", connection)
+		return s.mockClient, nil
+	})
+}
+
+// expectDatabase resolves the database of the connection
+func (s *CollectionCommandTestSuite) expectDatabase() {
+	s.mockContext.EXPECT().Argument(0).Return("users").Once()
+	s.mockContext.EXPECT().Option("connection").Return("mongodb").Once()
+	s.mockContext.EXPECT().Option("database").Return("goravel").Once()
+	s.mockClient.EXPECT().Database("goravel").Return(s.mockDatabase).Once()
+}
+
+func (s *CollectionCommandTestSuite) TestHandle() {
+	tests := []struct {
+		name  string
+		setup func()
+	}{
+		{
+			name: "Success",
+			setup: func() {
+				s.expectDatabase()
+				s.mockDatabase.EXPECT().CollectionInfo("users").Return(&contracts.CollectionInfo{
+					Name:      "users",
+					Type:      "collection",
+					UUID:      "0b3bd2a4-6a43-4a43-a3a4-5b4f2a6c7d8e",
+					Validator: bson.M{"name": bson.M{"$type": "string"}},
+				}, nil).Once()
+				s.mockDatabase.EXPECT().Name().Return("goravel").Once()
+				s.mockDatabase.EXPECT().Collection("users").Return(s.mockCollection).Once()
+				expectStats(s.T(), s.mockCollection,
+					bson.M{"storageStats": bson.M{"count": 2, "size": 1024 * 1024, "storageSize": 512 * 1024, "totalIndexSize": 256 * 1024}},
+					bson.M{"storageStats": bson.M{"count": 1, "size": 1024 * 1024, "storageSize": 512 * 1024, "totalIndexSize": 256 * 1024}},
+				)
+				aggregation := mockscontracts.NewAggregation(s.T())
+				s.mockCollection.EXPECT().Aggregate(bson.A{
+					bson.D{{Key: "$indexStats", Value: bson.D{}}},
+					bson.D{{Key: "$sort", Value: bson.D{{Key: "name", Value: 1}}}},
+				}).Return(aggregation).Once()
+				aggregation.EXPECT().All(mock.Anything).RunAndReturn(aggregationResults(
+					bson.M{"name": "_id_", "key": bson.D{{Key: "_id", Value: 1}}, "accesses": bson.M{"ops": int64(7)}},
+					bson.M{"name": "email_1_name_-1", "key": bson.D{{Key: "email", Value: 1}, {Key: "name", Value: -1}}, "spec": bson.M{"unique": true}},
+				)).Once()
+
+				s.mockContext.EXPECT().NewLine().Times(4)
+				s.mockContext.EXPECT().TwoColumnDetail("<fg=green;op=bold>users</>", "").Once()
+				s.mockContext.EXPECT().TwoColumnDetail("Database", "goravel").Once()
+				s.mockContext.EXPECT().TwoColumnDetail("Type", "collection").Once()
+				s.mockContext.EXPECT().TwoColumnDetail("Read Only", "false").Once()
+				s.mockContext.EXPECT().TwoColumnDetail("UUID", "0b3bd2a4-6a43-4a43-a3a4-5b4f2a6c7d8e").Once()
+				s.mockContext.EXPECT().TwoColumnDetail("Documents", "3").Once()
+				s.mockContext.EXPECT().TwoColumnDetail("Size", "2.000 MB").Once()
+				s.mockContext.EXPECT().TwoColumnDetail("Storage Size", "1.000 MB").Once()
+				s.mockContext.EXPECT().TwoColumnDetail("Index Size", "0.500 MB").Once()
+				s.mockContext.EXPECT().TwoColumnDetail("<fg=green;op=bold>Indexes</>", "<fg=yellow;op=bold>Keys / Operations</>").Once()
+				s.mockContext.EXPECT().TwoColumnDetail("_id_ <fg=gray>unique</>", "_id: 1 / 7").Once()
+				s.mockContext.EXPECT().TwoColumnDetail("email_1_name_-1 <fg=gray>unique</>", "email: 1, name: -1 / 0").Once()
+				s.mockContext.EXPECT().TwoColumnDetail("<fg=green;op=bold>Validator</>", "").Once()
+				s.mockContext.EXPECT().Line("{\n  \"name\": {\n    \"$type\": \"string\"\n  }\n}").Once()
+			},
+		},
+		{
+			name: "View",
+			setup: func() {
+				s.expectDatabase()
+				s.mockDatabase.EXPECT().CollectionInfo("users").Return(&contracts.CollectionInfo{
+					Name:     "users",
+					Type:     "view",
+					ReadOnly: true,
+					Options:  bson.M{"viewOn": "accounts"},
+				}, nil).Once()
+				s.mockDatabase.EXPECT().Name().Return("goravel").Once()
+
+				s.mockContext.EXPECT().NewLine().Twice()
+				s.mockContext.EXPECT().TwoColumnDetail("<fg=green;op=bold>users</>", "").Once()
+				s.mockContext.EXPECT().TwoColumnDetail("Database", "goravel").Once()
+				s.mockContext.EXPECT().TwoColumnDetail("Type", "view").Once()
+				s.mockContext.EXPECT().TwoColumnDetail("Read Only", "true").Once()
+				s.mockContext.EXPECT().TwoColumnDetail("View On", "accounts").Once()
+			},
+		},
+		{
+			name: "Missing name",
+			setup: func() {
+				s.mockContext.EXPECT().Argument(0).Return("").Once()
+				s.mockContext.EXPECT().Error("The collection name is required, usage: mongodb:collection <name>").Once()
+			},
+		},
+		{
+			name: "Failed to connect",
+			setup: func() {
+				s.mockContext.EXPECT().Argument(0).Return("users").Once()
+				s.mockContext.EXPECT().Option("connection").Return("mongodb").Once()
+				s.mockContext.EXPECT().Option("database").Return("").Once()
+				s.mockClient.EXPECT().Database("").Return(nil).Once()
+				s.mockContext.EXPECT().Error(ConnectionFailed.Error()).Once()
+			},
+		},
+		{
+			name: "Collection not found",
+			setup: func() {
+				s.expectDatabase()
+				s.mockDatabase.EXPECT().CollectionInfo("users").Return(nil, errors.New("collection not found: goravel.users")).Once()
+				s.mockContext.EXPECT().Error("collection not found: goravel.users").Once()
+			},
+		},
+	}
+
+	for _, test := range tests {
+		s.Run(test.name, func() {
+			s.SetupTest()
+			test.setup()
+			s.NoError(s.collectionCommand.Handle(s.mockContext))
+		})
+	}
+}
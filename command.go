@@ -0,0 +1,217 @@
+package mongodb
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// cursorCommands return their documents through a cursor
+var cursorCommands = map[string]bool{
+	"listCollections": true,
+	"listIndexes":     true,
+}
+
+// schemaCommands are the commands the Grammar compiles, the only ones the
+// dummy driver runs
+var schemaCommands = map[string]bool{
+	"create":          true,
+	"createIndexes":   true,
+	"drop":            true,
+	"dropIndexes":     true,
+	"listCollections": true,
+	"listIndexes":     true,
+}
+
+// parseCommand reads the MongoDB command the Grammar compiles as Extended
+// JSON, like {"listIndexes": "users"}. Any other command, like
+// {"dropDatabase": 1}, is handled like an SQL statement and never runs.
+func parseCommand(query string) (bson.D, bool) {
+	query = strings.TrimSpace(query)
+	if !strings.HasPrefix(query, "{") {
+		return nil, false
+	}
+
+	var command bson.D
+	if err := bson.UnmarshalExtJSON([]byte(query), false, &command); err != nil || len(command) == 0 {
+		return nil, false
+	}
+	if !schemaCommands[command[0].Key] {
+		return nil, false
+	}
+
+	return command, true
+}
+
+// runCommand runs a command of the Grammar and returns the documents of its
+// cursor, or its reply
+func (m *mongoConnPool) runCommand(ctx context.Context, command bson.D) ([]bson.D, error) {
+	database, err := m.mongoDatabase()
+	if err != nil {
+		return nil, err
+	}
+
+	name := command[0].Key
+	if !cursorCommands[name] {
+		var reply bson.D
+		err := database.RunCommand(ctx, command).Decode(&reply)
+
+		// Dropping a missing collection succeeds like Collection.Drop
+		var commandError mongo.CommandError
+		if name == "drop" && errors.As(err, &commandError) && commandError.Code == 26 {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to run MongoDB command %s: %w", name, err)
+		}
+
+		return []bson.D{reply}, nil
+	}
+
+	cursor, err := database.RunCommandCursor(ctx, command)
+	if err != nil {
+		// Listing the indexes of a missing collection returns none
+		var commandError mongo.CommandError
+		if name == "listIndexes" && errors.As(err, &commandError) && commandError.Code == 26 {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to run MongoDB command %s: %w", name, err)
+	}
+
+	var documents []bson.D
+	if err := cursor.All(ctx, &documents); err != nil {
+		return nil, fmt.Errorf("failed to run MongoDB command %s: %w", name, err)
+	}
+
+	if name == "listIndexes" {
+		for i, document := range documents {
+			documents[i] = indexRow(document)
+		}
+	}
+
+	return documents, nil
+}
+
+// indexRow returns the columns of driver.DBIndex for an index specification,
+// the Processor turns them into a driver.Index
+func indexRow(specification bson.D) bson.D {
+	var name string
+	var columns, weights []string
+	var unique bool
+	indexType := "btree"
+	for _, element := range specification {
+		switch element.Key {
+		case "name":
+			name, _ = element.Value.(string)
+		case "unique":
+			unique, _ = element.Value.(bool)
+		case "key":
+			key, _ := element.Value.(bson.D)
+			for _, field := range key {
+				columns = append(columns, field.Key)
+				if kind, ok := field.Value.(string); ok {
+					indexType = kind
+				}
+			}
+		case "weights":
+			// The key of a text index is _fts and _ftsx, the columns are weighted
+			fields, _ := element.Value.(bson.D)
+			for _, field := range fields {
+				weights = append(weights, field.Key)
+			}
+		}
+	}
+	if len(weights) > 0 {
+		columns = weights
+	}
+
+	return bson.D{
+		{Key: "name", Value: name},
+		{Key: "columns", Value: strings.Join(columns, ",")},
+		{Key: "type", Value: indexType},
+		{Key: "primary", Value: name == "_id_"},
+		{Key: "unique", Value: unique || name == "_id_"},
+	}
+}
+
+// newCommandRows returns the documents as rows, a column per top level field
+func newCommandRows(documents []bson.D) *mongoRows {
+	rows := &mongoRows{}
+	indexes := make(map[string]int)
+	for _, document := range documents {
+		for _, element := range document {
+			if _, ok := indexes[element.Key]; !ok {
+				indexes[element.Key] = len(rows.columns)
+				rows.columns = append(rows.columns, element.Key)
+			}
+		}
+	}
+
+	for _, document := range documents {
+		row := make([]driver.Value, len(rows.columns))
+		for _, element := range document {
+			row[indexes[element.Key]] = toDriverValue(element.Value)
+		}
+		rows.values = append(rows.values, row)
+	}
+
+	return rows
+}
+
+// toDriverValue converts a field to a value database/sql can scan, embedded
+// documents and arrays are returned as relaxed Extended JSON
+func toDriverValue(value interface{}) driver.Value {
+	switch v := value.(type) {
+	case nil, int64, float64, bool, string, []byte, time.Time:
+		return v
+	case int32:
+		return int64(v)
+	case primitive.DateTime:
+		return v.Time()
+	case primitive.ObjectID:
+		return v.Hex()
+	case primitive.Decimal128:
+		return v.String()
+	}
+
+	data, err := bson.MarshalExtJSON(bson.D{{Key: "v", Value: value}}, false, false)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+
+	// Strip the {"v": ...} wrapper
+	return strings.TrimSuffix(strings.TrimPrefix(string(data), `{"v":`), "}")
+}
+
+// mongoRows is a minimal sql/driver.Rows implementation
+type mongoRows struct {
+	columns []string
+	values  [][]driver.Value
+}
+
+func (r *mongoRows) Columns() []string {
+	return r.columns
+}
+
+func (r *mongoRows) Close() error {
+	return nil
+}
+
+func (r *mongoRows) Next(dest []driver.Value) error {
+	if len(r.values) == 0 {
+		return io.EOF
+	}
+
+	copy(dest, r.values[0])
+	r.values = r.values[1:]
+
+	return nil
+}
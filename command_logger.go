@@ -0,0 +1,199 @@
+package mongodb
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/goravel/framework/contracts/log"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/event"
+)
+
+// DefaultRedactedFields are the fields whose values are hidden from the
+// logged filters, unless database.connections.<connection>.redact is set
+var DefaultRedactedFields = []string{"password", "secret", "token"}
+
+// The value logged in place of a redacted field
+const redacted = "[REDACTED]"
+
+// The formats of the logged commands, like the SQL logger of Goravel
+const (
+	commandTraceStr     = "[%.3fms] %s"
+	commandTraceWarnStr = "[%.3fms] [SLOW] %s"
+	commandTraceErrStr  = "[%.3fms] %s\t%s"
+)
+
+// commandLogger logs the commands through the log facade: all of them at
+// debug level when app.debug is on, the slow ones as warnings and the failed
+// ones as errors
+type commandLogger struct {
+	log           log.Log
+	debug         bool
+	slowThreshold time.Duration
+	redact        []string
+	// commands are the running commands, by request
+	commands sync.Map
+}
+
+// commandLogger configures the logger of the commands of the connection. The
+// slow threshold is database.connections.<connection>.slow_threshold or
+// database.slow_threshold in milliseconds, 200 by default.
+func (m *MongoDB) commandLogger() *commandLogger {
+	logger := &commandLogger{
+		log:           m.log,
+		slowThreshold: 200 * time.Millisecond,
+		redact:        DefaultRedactedFields,
+	}
+	if m.config == nil || m.config.Config() == nil {
+		return logger
+	}
+
+	config := m.config.Config()
+	prefix := fmt.Sprintf("database.connections.%s", m.config.Connection())
+	logger.debug = config.GetBool("app.debug")
+	if threshold := config.GetInt(prefix+".slow_threshold", config.GetInt("database.slow_threshold", 200)); threshold > 0 {
+		logger.slowThreshold = time.Duration(threshold) * time.Millisecond
+	}
+	if redact, ok := config.Get(prefix + ".redact").([]string); ok {
+		logger.redact = redact
+	}
+
+	return logger
+}
+
+// monitor returns the driver monitor logging the commands
+func (c *commandLogger) monitor() *event.CommandMonitor {
+	return &event.CommandMonitor{
+		Started: func(_ context.Context, started *event.CommandStartedEvent) {
+			// The driver may reuse the buffer of the command
+			c.commands.Store(commandKey(started.ConnectionID, started.RequestID), append(bson.Raw(nil), started.Command...))
+		},
+		Succeeded: func(_ context.Context, succeeded *event.CommandSucceededEvent) {
+			c.finished(succeeded.CommandFinishedEvent, "")
+		},
+		Failed: func(_ context.Context, failed *event.CommandFailedEvent) {
+			c.finished(failed.CommandFinishedEvent, failed.Failure)
+		},
+	}
+}
+
+func (c *commandLogger) finished(finished event.CommandFinishedEvent, failure string) {
+	command, _ := c.commands.LoadAndDelete(commandKey(finished.ConnectionID, finished.RequestID))
+	raw, _ := command.(bson.Raw)
+
+	slow := finished.Duration > c.slowThreshold
+	if failure == "" && !slow && !c.debug {
+		return
+	}
+
+	elapsed := float64(finished.Duration.Nanoseconds()) / 1e6
+	statement := c.statement(finished.DatabaseName, finished.CommandName, raw)
+	switch {
+	case failure != "":
+		c.log.Errorf(commandTraceErrStr, elapsed, statement, failure)
+	case slow:
+		c.log.Warningf(commandTraceWarnStr, elapsed, statement)
+	default:
+		c.log.Debugf(commandTraceStr, elapsed, statement)
+	}
+}
+
+// statement describes a command as its namespace, name and redacted filter
+func (c *commandLogger) statement(database, name string, command bson.Raw) string {
+	namespace := database
+	if collection := commandCollection(command); collection != "" {
+		namespace += "." + collection
+	}
+
+	statement := namespace + " " + name
+	if filter := commandFilter(command); filter != nil {
+		statement += " " + extJSON(redactFields(filter, c.redact))
+	}
+
+	return statement
+}
+
+// commandFilter returns the filter of a command, the pipeline of an
+// aggregation and the filters of the statements of a bulk update or delete.
+// The other commands have none.
+func commandFilter(command bson.Raw) interface{} {
+	var document bson.D
+	if len(command) == 0 || bson.Unmarshal(command, &document) != nil {
+		return nil
+	}
+
+	for _, element := range document {
+		switch element.Key {
+		case "filter", "query", "pipeline":
+			return element.Value
+		case "updates", "deletes":
+			statements, _ := element.Value.(bson.A)
+			var filters bson.A
+			for _, statement := range statements {
+				if statement, ok := statement.(bson.D); ok {
+					for _, field := range statement {
+						if field.Key == "q" {
+							filters = append(filters, field.Value)
+						}
+					}
+				}
+			}
+			if len(filters) == 1 {
+				return filters[0]
+			}
+			return filters
+		}
+	}
+
+	return nil
+}
+
+// redactFields hides the values of the fields whose names contain one of the
+// redacted names, at any depth
+func redactFields(value interface{}, fields []string) interface{} {
+	switch value := value.(type) {
+	case bson.D:
+		document := make(bson.D, len(value))
+		for i, element := range value {
+			if isRedacted(element.Key, fields) {
+				document[i] = bson.E{Key: element.Key, Value: redacted}
+			} else {
+				document[i] = bson.E{Key: element.Key, Value: redactFields(element.Value, fields)}
+			}
+		}
+		return document
+	case bson.A:
+		array := make(bson.A, len(value))
+		for i, element := range value {
+			array[i] = redactFields(element, fields)
+		}
+		return array
+	}
+
+	return value
+}
+
+func isRedacted(key string, fields []string) bool {
+	key = strings.ToLower(key)
+	for _, field := range fields {
+		if field != "" && strings.Contains(key, strings.ToLower(field)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// extJSON formats a value as relaxed extended JSON, which only documents can
+// be marshalled to
+func extJSON(value interface{}) string {
+	data, err := bson.MarshalExtJSON(bson.D{{Key: "v", Value: value}}, false, false)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+
+	return string(data[len(`{"v":`) : len(data)-1])
+}
@@ -0,0 +1,163 @@
+package mongodb
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	mocksconfig "github.com/goravel/framework/mocks/config"
+	mockslog "github.com/goravel/framework/mocks/log"
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/event"
+)
+
+type CommandLoggerTestSuite struct {
+	suite.Suite
+	mockConfig *mocksconfig.Config
+	mockLog    *mockslog.Log
+}
+
+func TestCommandLoggerTestSuite(t *testing.T) {
+	suite.Run(t, new(CommandLoggerTestSuite))
+}
+
+func (s *CommandLoggerTestSuite) SetupTest() {
+	s.mockConfig = mocksconfig.NewConfig(s.T())
+	s.mockLog = mockslog.NewLog(s.T())
+}
+
+// run logs a find on goravel.users lasting the duration, failed with the
+// failure when it is set
+func (s *CommandLoggerTestSuite) run(logger *commandLogger, duration time.Duration, failure string) {
+	monitor := logger.monitor()
+	monitor.Started(context.Background(), &event.CommandStartedEvent{
+		Command: mustMarshal(bson.D{
+			{Key: "find", Value: "users"},
+			{Key: "filter", Value: bson.D{{Key: "email", Value: "goravel@example.com"}, {Key: "password", Value: "secret"}}},
+			{Key: "$db", Value: "goravel"},
+		}),
+		DatabaseName: "goravel",
+		CommandName:  "find",
+		RequestID:    1,
+		ConnectionID: "localhost:27017[-1]",
+	})
+
+	finished := event.CommandFinishedEvent{
+		Duration:     duration,
+		CommandName:  "find",
+		DatabaseName: "goravel",
+		RequestID:    1,
+		ConnectionID: "localhost:27017[-1]",
+	}
+	if failure != "" {
+		monitor.Failed(context.Background(), &event.CommandFailedEvent{CommandFinishedEvent: finished, Failure: failure})
+	} else {
+		monitor.Succeeded(context.Background(), &event.CommandSucceededEvent{CommandFinishedEvent: finished})
+	}
+}
+
+func (s *CommandLoggerTestSuite) TestCommandLogger() {
+	s.mockConfig.EXPECT().GetBool("app.debug").Return(true).Once()
+	s.mockConfig.EXPECT().GetInt("database.slow_threshold", 200).Return(100).Once()
+	s.mockConfig.EXPECT().GetInt("database.connections.mongodb.slow_threshold", 100).Return(50).Once()
+	s.mockConfig.EXPECT().Get("database.connections.mongodb.redact").Return([]string{"email"}).Once()
+
+	logger := (&MongoDB{config: NewConfig(s.mockConfig, "mongodb"), log: s.mockLog}).commandLogger()
+	s.True(logger.debug)
+	s.Equal(50*time.Millisecond, logger.slowThreshold)
+	s.Equal([]string{"email"}, logger.redact)
+
+	s.mockConfig.EXPECT().GetBool("app.debug").Return(false).Once()
+	s.mockConfig.EXPECT().GetInt("database.slow_threshold", 200).Return(200).Once()
+	s.mockConfig.EXPECT().GetInt("database.connections.mongodb.slow_threshold", 200).Return(0).Once()
+	s.mockConfig.EXPECT().Get("database.connections.mongodb.redact").Return(nil).Once()
+
+	logger = (&MongoDB{config: NewConfig(s.mockConfig, "mongodb"), log: s.mockLog}).commandLogger()
+	s.False(logger.debug)
+	s.Equal(200*time.Millisecond, logger.slowThreshold)
+	s.Equal(DefaultRedactedFields, logger.redact)
+}
+
+func (s *CommandLoggerTestSuite) TestLog() {
+	statement := `goravel.users find {"email":"goravel@example.com","password":"[REDACTED]"}`
+	logger := func(debug bool) *commandLogger {
+		return &commandLogger{log: s.mockLog, debug: debug, slowThreshold: 100 * time.Millisecond, redact: DefaultRedactedFields}
+	}
+
+	s.Run("debug", func() {
+		s.mockLog.EXPECT().Debugf(commandTraceStr, 1.5, statement).Once()
+		s.run(logger(true), 1500*time.Microsecond, "")
+	})
+
+	s.Run("not logged without debug", func() {
+		s.run(logger(false), time.Millisecond, "")
+	})
+
+	s.Run("slow", func() {
+		s.mockLog.EXPECT().Warningf(commandTraceWarnStr, 150.0, statement).Once()
+		s.run(logger(false), 150*time.Millisecond, "")
+	})
+
+	s.Run("failed", func() {
+		s.mockLog.EXPECT().Errorf(commandTraceErrStr, 1.0, statement, "(Unauthorized) not authorized").Once()
+		s.run(logger(false), time.Millisecond, "(Unauthorized) not authorized")
+	})
+}
+
+func (s *CommandLoggerTestSuite) TestStatement() {
+	logger := &commandLogger{redact: DefaultRedactedFields}
+
+	tests := []struct {
+		name      string
+		command   bson.D
+		statement string
+	}{
+		{
+			name:      "without filter",
+			command:   bson.D{{Key: "insert", Value: "users"}, {Key: "documents", Value: bson.A{bson.D{{Key: "password", Value: "secret"}}}}},
+			statement: "goravel.users insert",
+		},
+		{
+			name:      "on the database",
+			command:   bson.D{{Key: "ping", Value: 1}},
+			statement: "goravel ping",
+		},
+		{
+			name:      "count",
+			command:   bson.D{{Key: "count", Value: "users"}, {Key: "query", Value: bson.D{{Key: "age", Value: bson.D{{Key: "$gt", Value: 18}}}}}},
+			statement: `goravel.users count {"age":{"$gt":18}}`,
+		},
+		{
+			name: "aggregate",
+			command: bson.D{{Key: "aggregate", Value: "users"}, {Key: "pipeline", Value: bson.A{
+				bson.D{{Key: "$match", Value: bson.D{{Key: "api_token", Value: "abc"}}}},
+			}}},
+			statement: `goravel.users aggregate [{"$match":{"api_token":"[REDACTED]"}}]`,
+		},
+		{
+			name: "delete",
+			command: bson.D{{Key: "delete", Value: "users"}, {Key: "deletes", Value: bson.A{
+				bson.D{{Key: "q", Value: bson.D{{Key: "name", Value: "Goravel"}}}, {Key: "limit", Value: 1}},
+			}}},
+			statement: `goravel.users delete {"name":"Goravel"}`,
+		},
+		{
+			name: "bulk update",
+			command: bson.D{{Key: "update", Value: "users"}, {Key: "updates", Value: bson.A{
+				bson.D{{Key: "q", Value: bson.D{{Key: "name", Value: "Goravel"}}}, {Key: "u", Value: bson.D{}}},
+				bson.D{{Key: "q", Value: bson.D{{Key: "Profile", Value: bson.D{{Key: "Secret_Answer", Value: "blue"}}}}}, {Key: "u", Value: bson.D{}}},
+			}}},
+			statement: `goravel.users update [{"name":"Goravel"},{"Profile":{"Secret_Answer":"[REDACTED]"}}]`,
+		},
+	}
+
+	for _, test := range tests {
+		s.Run(test.name, func() {
+			s.Equal(test.statement, logger.statement("goravel", test.command[0].Key, mustMarshal(test.command)))
+		})
+	}
+
+	// The commands the driver redacts have no body
+	s.Equal("admin saslStart", logger.statement("admin", "saslStart", nil))
+}
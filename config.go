@@ -70,6 +70,8 @@ func (r *Config) fillDefault(configs []contracts.Config) []contracts.FullConfig
 				ConnectTimeout: config.ConnectTimeout,
 				ServerTimeout:  config.ServerTimeout,
 				Options:        config.Options,
+				Tracing:        config.Tracing,
+				TracerProvider: config.TracerProvider,
 			},
 			Connection: r.connection,
 			Driver:     Name,
@@ -82,6 +84,9 @@ func (r *Config) fillDefault(configs []contracts.Config) []contracts.FullConfig
 		if fullConfig.Database == "" {
 			fullConfig.Database = r.config.GetString(fmt.Sprintf("database.connections.%s.database", r.connection))
 		}
+		if !fullConfig.Tracing {
+			fullConfig.Tracing = r.config.GetBool(fmt.Sprintf("database.connections.%s.tracing", r.connection), false)
+		}
 
 		fullConfigs = append(fullConfigs, fullConfig)
 	}
@@ -6,6 +6,7 @@ import (
 
 	mocksconfig "github.com/goravel/framework/mocks/config"
 	"github.com/stretchr/testify/suite"
+	"go.opentelemetry.io/otel/trace/noop"
 
 	"github.com/portofolio-mager/goravel-mongodb/contracts"
 )
@@ -40,6 +41,7 @@ func (s *ConfigTestSuite) TestReads() {
 			Database: "forge",
 		},
 	}).Once()
+	s.mockConfig.EXPECT().GetBool(fmt.Sprintf("database.connections.%s.tracing", s.connection), false).Return(false).Once()
 	s.Equal([]contracts.FullConfig{
 		{
 			Connection: s.connection,
@@ -57,6 +59,7 @@ func (s *ConfigTestSuite) TestWrites() {
 		s.mockConfig.EXPECT().Get(fmt.Sprintf("database.connections.%s.write", s.connection)).Return(nil).Once()
 		s.mockConfig.EXPECT().GetString(fmt.Sprintf("database.connections.%s.uri", s.connection)).Return("mongodb://localhost:27017").Once()
 		s.mockConfig.EXPECT().GetString(fmt.Sprintf("database.connections.%s.database", s.connection)).Return("forge").Once()
+		s.mockConfig.EXPECT().GetBool(fmt.Sprintf("database.connections.%s.tracing", s.connection), false).Return(false).Once()
 
 		s.Equal([]contracts.FullConfig{
 			{
@@ -77,6 +80,7 @@ func (s *ConfigTestSuite) TestWrites() {
 			},
 		}).Once()
 		s.mockConfig.EXPECT().GetString(fmt.Sprintf("database.connections.%s.uri", s.connection)).Return("mongodb://localhost:27017").Once()
+		s.mockConfig.EXPECT().GetBool(fmt.Sprintf("database.connections.%s.tracing", s.connection), false).Return(false).Once()
 
 		s.Equal([]contracts.FullConfig{
 			{
@@ -112,6 +116,7 @@ func (s *ConfigTestSuite) TestFillDefault() {
 			setup: func() {
 				s.mockConfig.EXPECT().GetString(fmt.Sprintf("database.connections.%s.uri", s.connection)).Return(uri).Once()
 				s.mockConfig.EXPECT().GetString(fmt.Sprintf("database.connections.%s.database", s.connection)).Return(database).Once()
+				s.mockConfig.EXPECT().GetBool(fmt.Sprintf("database.connections.%s.tracing", s.connection), false).Return(false).Once()
 			},
 			expectConfigs: []contracts.FullConfig{
 				{
@@ -132,7 +137,55 @@ func (s *ConfigTestSuite) TestFillDefault() {
 					Database: database,
 				},
 			},
+			setup: func() {
+				s.mockConfig.EXPECT().GetBool(fmt.Sprintf("database.connections.%s.tracing", s.connection), false).Return(false).Once()
+			},
+			expectConfigs: []contracts.FullConfig{
+				{
+					Connection: s.connection,
+					Driver:     Name,
+					Config: contracts.Config{
+						URI:      uri,
+						Database: database,
+					},
+				},
+			},
+		},
+		{
+			name: "success when configs enable tracing",
+			configs: []contracts.Config{
+				{
+					URI:            uri,
+					Database:       database,
+					Tracing:        true,
+					TracerProvider: noop.NewTracerProvider(),
+				},
+			},
 			setup: func() {},
+			expectConfigs: []contracts.FullConfig{
+				{
+					Connection: s.connection,
+					Driver:     Name,
+					Config: contracts.Config{
+						URI:            uri,
+						Database:       database,
+						Tracing:        true,
+						TracerProvider: noop.NewTracerProvider(),
+					},
+				},
+			},
+		},
+		{
+			name: "success when the connection enables tracing",
+			configs: []contracts.Config{
+				{
+					URI:      uri,
+					Database: database,
+				},
+			},
+			setup: func() {
+				s.mockConfig.EXPECT().GetBool(fmt.Sprintf("database.connections.%s.tracing", s.connection), false).Return(true).Once()
+			},
 			expectConfigs: []contracts.FullConfig{
 				{
 					Connection: s.connection,
@@ -140,6 +193,7 @@ func (s *ConfigTestSuite) TestFillDefault() {
 					Config: contracts.Config{
 						URI:      uri,
 						Database: database,
+						Tracing:  true,
 					},
 				},
 			},
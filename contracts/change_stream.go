@@ -0,0 +1,64 @@
+package contracts
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// ChangeStream represents a change stream opened by Watch
+type ChangeStream interface {
+	// Native change stream access
+	Native() *mongo.ChangeStream
+
+	// Next blocks until the next event is available, it returns false when the
+	// stream is closed or fails, check Err in that case
+	Next(ctx context.Context) bool
+	// TryNext is like Next but returns false immediately when no event is available
+	TryNext(ctx context.Context) bool
+	// Event decodes the current event
+	Event() (*ChangeEvent, error)
+	// Decode decodes the current event into val
+	Decode(val interface{}) error
+
+	// ResumeToken returns the token of the last event returned by the stream
+	ResumeToken() bson.Raw
+	// SaveResumeToken stores the current resume token in the resume token store
+	SaveResumeToken() error
+
+	Err() error
+	Close() error
+}
+
+// ResumeTokenStore persists resume tokens so a consumer restarts where it left off
+type ResumeTokenStore interface {
+	// Get returns the stored token, nil when there is none
+	Get(key string) (bson.Raw, error)
+	// Put stores the token
+	Put(key string, token bson.Raw) error
+}
+
+// ChangeEvent is a change stream event
+type ChangeEvent struct {
+	ID                       bson.Raw            `bson:"_id"`
+	OperationType            string              `bson:"operationType"`
+	ClusterTime              primitive.Timestamp `bson:"clusterTime"`
+	Namespace                ChangeNamespace     `bson:"ns"`
+	DocumentKey              bson.Raw            `bson:"documentKey,omitempty"`
+	FullDocument             bson.Raw            `bson:"fullDocument,omitempty"`
+	FullDocumentBeforeChange bson.Raw            `bson:"fullDocumentBeforeChange,omitempty"`
+	UpdateDescription        *UpdateDescription  `bson:"updateDescription,omitempty"`
+}
+
+type ChangeNamespace struct {
+	Database   string `bson:"db"`
+	Collection string `bson:"coll"`
+}
+
+type UpdateDescription struct {
+	UpdatedFields   bson.Raw `bson:"updatedFields"`
+	RemovedFields   []string `bson:"removedFields"`
+	TruncatedArrays bson.Raw `bson:"truncatedArrays,omitempty"`
+}
@@ -1,8 +1,13 @@
 package contracts
 
 import (
+	"context"
+	"time"
+
 	contractsconfig "github.com/goravel/framework/contracts/config"
+	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.opentelemetry.io/otel/trace"
 )
 
 type ConfigBuilder interface {
@@ -28,6 +33,11 @@ type Config struct {
 	ConnectTimeout *int                   `json:"connect_timeout"`
 	ServerTimeout  *int                   `json:"server_timeout"`
 	Options        map[string]interface{} `json:"options"`
+	// Tracing starts an OpenTelemetry span for each command, with the global
+	// tracer provider unless TracerProvider is set. database.connections.<connection>.tracing
+	// enables it too
+	Tracing        bool                 `json:"tracing"`
+	TracerProvider trace.TracerProvider `json:"-"`
 }
 
 // FullConfig Fill the default value for Config
@@ -44,13 +54,33 @@ type Client interface {
 
 	// Database operations
 	Database(name ...string) Database
+	ListDatabases() ([]DatabaseInfo, error)
 
 	// Collection operations
 	Collection(collection string, database ...string) Collection
 
+	// Change streams
+	Watch(pipeline interface{}, opts ...interface{}) (ChangeStream, error)
+
 	// Connection management
 	Ping() error
+	// HealthCheck pings the deployment and describes its topology, version and
+	// connection pool, a failed check is reported as down
+	HealthCheck() Health
 	Close() error
+
+	// WithContext returns the client running its operations with ctx, the
+	// databases and collections it returns use ctx too
+	WithContext(ctx context.Context) Client
+
+	// Seeders
+	// RegisterSeeders registers seeders for the mongodb:seed command, a seeder
+	// replaces the registered one with the same signature
+	RegisterSeeders(seeders ...Seeder)
+	// Seeder returns the registered seeder with the signature, nil when there is none
+	Seeder(signature string) Seeder
+	// Seeders returns the registered seeders in registration order
+	Seeders() []Seeder
 }
 
 // Database represents a MongoDB database interface
@@ -61,11 +91,63 @@ type Database interface {
 	// Collection operations
 	Collection(name string) Collection
 
+	// Change streams
+	Watch(pipeline interface{}, opts ...interface{}) (ChangeStream, error)
+
+	// GridFS operations
+	GridFS(bucket ...string) (GridFS, error)
+
+	// Fixtures
+	LoadFixtures(dir string) error
+	DumpFixtures(dir string, collections ...string) error
+
 	// Database operations
 	CreateCollection(name string, opts ...interface{}) error
+	// CreateTimeSeries creates a time-series collection, the empty metaField and
+	// granularity and a zero expireAfter are left to the server
+	CreateTimeSeries(name, timeField, metaField, granularity string, expireAfter time.Duration) error
+	// CreateCapped creates a capped collection of size bytes, a zero max does
+	// not limit the number of documents
+	CreateCapped(name string, size, max int64) error
+	// CreateClustered creates a collection clustered by _id
+	CreateClustered(name string) error
+	// CreateView creates a read-only view running the pipeline on source
+	CreateView(name, source string, pipeline interface{}) error
+	CollectionInfo(name string) (*CollectionInfo, error)
+	// ListCollectionSpecs describes the collections and views matching a
+	// listCollections filter document or a name pattern, nil matches all
+	ListCollectionSpecs(filter interface{}) ([]CollectionInfo, error)
+	// ListCollections returns the names of the collections and the views,
+	// ListViews the names of the views only
 	ListCollections() ([]string, error)
+	ListViews() ([]string, error)
 	Drop() error
 	Name() string
+
+	// WithContext returns the database running its operations with ctx, the
+	// collections and buckets it returns use ctx too
+	WithContext(ctx context.Context) Database
+}
+
+// DatabaseInfo describes a database as listDatabases reports it
+type DatabaseInfo struct {
+	Name       string
+	SizeOnDisk int64
+	Empty      bool
+}
+
+// CollectionInfo describes a collection as listCollections reports it
+type CollectionInfo struct {
+	Name string
+	// Type is collection, timeseries or view
+	Type     string
+	ReadOnly bool
+	// UUID is empty for views
+	UUID string
+	// Options are the options the collection was created with
+	Options bson.M
+	// Validator is the validator of the options, nil without one
+	Validator bson.M
 }
 
 // Collection represents a MongoDB collection interface
@@ -86,12 +168,45 @@ type Collection interface {
 	// ORM-like convenience methods
 	Create(document interface{}) error
 	First(result interface{}, filter ...interface{}) error
+	Query() QueryBuilder
 	Where(field string, value interface{}) QueryBuilder
 
+	// Scopes
+	WithScope(name string, scope func(QueryBuilder)) Collection
+
+	// Soft deletes
+	WithSoftDeletes() Collection
+
+	// Events
+	Observe(observer Observer)
+
+	// Change streams
+	Watch(pipeline interface{}, opts ...interface{}) (ChangeStream, error)
+
+	// Aggregation
+	Aggregate(pipeline interface{}, opts ...interface{}) Aggregation
+
 	// Collection management
 	Drop() error
 	Name() string
+	// SetValidator replaces the validator with a validator document or the one
+	// generated from a struct, level and action are left as is when empty
+	SetValidator(schema interface{}, level, action string) error
 	CountDocuments(filter interface{}, opts ...interface{}) (int64, error)
+
+	// WithContext returns the collection running its operations with ctx, the
+	// query builders it returns use ctx too
+	WithContext(ctx context.Context) Collection
+}
+
+// Aggregation runs an aggregation pipeline on a collection
+type Aggregation interface {
+	// All decodes every result into results, a pointer to a slice
+	All(results interface{}) error
+	// Merge writes the results into the target collection with $merge, on are
+	// the fields matching the documents, _id when empty, and whenMatched is
+	// replace, keepExisting, merge or fail, merge when empty
+	Merge(target string, on []string, whenMatched string) error
 }
 
 // QueryBuilder represents a query builder interface for MongoDB
@@ -113,9 +228,29 @@ type QueryBuilder interface {
 	First(result interface{}) error
 	Count() (int64, error)
 
+	// Write methods
+	Update(update interface{}) (int64, error)
+	Delete() (int64, error)
+	ForceDelete() (int64, error)
+	Restore() (int64, error)
+
 	// Query modifiers
 	Limit(limit int64) QueryBuilder
 	Skip(skip int64) QueryBuilder
 	Sort(field string, order int) QueryBuilder
 	Select(fields ...string) QueryBuilder
+
+	// Relations
+	With(relations ...string) QueryBuilder
+
+	// Scopes
+	Scopes(scopes ...func(QueryBuilder)) QueryBuilder
+	WithoutGlobalScope(names ...string) QueryBuilder
+
+	// Soft delete modifiers
+	WithTrashed() QueryBuilder
+	OnlyTrashed() QueryBuilder
+
+	// WithContext runs the operations of the query with ctx
+	WithContext(ctx context.Context) QueryBuilder
 }
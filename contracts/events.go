@@ -0,0 +1,73 @@
+package contracts
+
+import "context"
+
+type EventType string
+
+const (
+	// Create events
+	EventCreating EventType = "creating"
+	EventCreated  EventType = "created"
+
+	// Update events
+	EventUpdating EventType = "updating"
+	EventUpdated  EventType = "updated"
+
+	// Delete events
+	EventDeleting EventType = "deleting"
+	EventDeleted  EventType = "deleted"
+
+	// Retrieve events
+	EventRetrieved EventType = "retrieved"
+)
+
+// Event is passed to model event handlers and observers
+type Event interface {
+	// Context returns the context of the operation
+	Context() context.Context
+	// Type returns the event type
+	Type() EventType
+	// Collection returns the collection the operation runs against
+	Collection() Collection
+	// Model returns the document being written or retrieved, nil for filter based operations
+	Model() any
+	// Filter returns the filter of update and delete operations
+	Filter() any
+	// Update returns the update document of update operations
+	Update() any
+}
+
+// DispatchesEvents can be implemented by models to handle their own events
+type DispatchesEvents interface {
+	// DispatchesEvents returns the event handlers
+	DispatchesEvents() map[EventType]func(Event) error
+}
+
+type Observer interface {
+	// Created called when the model has been created
+	Created(Event) error
+	// Updated called when the model has been updated
+	Updated(Event) error
+	// Deleted called when the model has been deleted
+	Deleted(Event) error
+}
+
+type ObserverWithCreating interface {
+	// Creating called when the model is being created
+	Creating(Event) error
+}
+
+type ObserverWithUpdating interface {
+	// Updating called when the model is being updated
+	Updating(Event) error
+}
+
+type ObserverWithDeleting interface {
+	// Deleting called when the model is being deleted
+	Deleting(Event) error
+}
+
+type ObserverWithRetrieved interface {
+	// Retrieved called when the model is retrieved from the database
+	Retrieved(Event) error
+}
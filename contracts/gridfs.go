@@ -0,0 +1,33 @@
+package contracts
+
+import (
+	"context"
+	"io"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/gridfs"
+)
+
+// GridFS represents a GridFS bucket interface
+type GridFS interface {
+	// Native bucket access
+	Native() *gridfs.Bucket
+
+	// File operations
+	Upload(name string, source io.Reader, metadata interface{}) (primitive.ObjectID, error)
+	Download(id interface{}, destination io.Writer) (int64, error)
+	DownloadByName(name string, destination io.Writer) (int64, error)
+	OpenDownloadStream(id interface{}) (*gridfs.DownloadStream, error)
+	OpenDownloadStreamByName(name string) (*gridfs.DownloadStream, error)
+	Delete(id interface{}) error
+	Find(filter interface{}, opts ...interface{}) ([]gridfs.File, error)
+	Rename(id interface{}, name string) error
+
+	// Bucket management
+	Drop() error
+	Name() string
+
+	// WithContext returns the bucket running its operations with ctx, the
+	// deadline of ctx bounds the uploads and downloads
+	WithContext(ctx context.Context) GridFS
+}
@@ -0,0 +1,52 @@
+package contracts
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Health is the status of a connection as Client.HealthCheck reports it
+type Health struct {
+	Connection string `json:"connection"`
+	// Status is up when the deployment answered the ping, down otherwise
+	Status string `json:"status"`
+	// Latency is the round trip of the ping
+	Latency time.Duration `json:"latency"`
+	// Topology is standalone, replica set, sharded or unknown
+	Topology      string    `json:"topology"`
+	Primary       string    `json:"primary,omitempty"`
+	ServerVersion string    `json:"server_version,omitempty"`
+	Pool          PoolStats `json:"pool"`
+	// Error is why the connection is down
+	Error string `json:"error,omitempty"`
+}
+
+// MarshalJSON writes the latency as a duration like 1.5ms
+func (h Health) MarshalJSON() ([]byte, error) {
+	type health Health
+	return json.Marshal(struct {
+		health
+		Latency string `json:"latency"`
+	}{
+		health:  health(h),
+		Latency: h.Latency.String(),
+	})
+}
+
+// PoolStats are the connections of the pool of a client
+type PoolStats struct {
+	// Open are the connections established, InUse the checked out ones and
+	// Idle the ones waiting in the pool
+	Open  int64 `json:"open"`
+	InUse int64 `json:"in_use"`
+	Idle  int64 `json:"idle"`
+	// Max is the maximum size of the pool
+	Max uint64 `json:"max"`
+}
+
+// HealthReport aggregates the health of the connections, its status is up
+// when every connection is up
+type HealthReport struct {
+	Status      string   `json:"status"`
+	Connections []Health `json:"connections"`
+}
@@ -0,0 +1,12 @@
+package contracts
+
+// MetricsSink receives the metrics of the connection pool and the commands of
+// a client. It is called concurrently, the labels are never nil.
+type MetricsSink interface {
+	// AddCounter adds delta to a counter
+	AddCounter(name string, labels map[string]string, delta float64)
+	// AddGauge adds delta to a gauge, a negative delta decreases it
+	AddGauge(name string, labels map[string]string, delta float64)
+	// ObserveHistogram records a value of a histogram
+	ObserveHistogram(name string, labels map[string]string, value float64)
+}
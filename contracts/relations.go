@@ -0,0 +1,28 @@
+package contracts
+
+type RelationType string
+
+const (
+	RelationHasOne     RelationType = "has_one"
+	RelationHasMany    RelationType = "has_many"
+	RelationBelongsTo  RelationType = "belongs_to"
+	RelationManyToMany RelationType = "many_to_many"
+)
+
+// Relation describes how the documents of a related collection are matched
+type Relation struct {
+	Type RelationType
+	// Collection is the related collection
+	Collection string
+	// LocalKey is the field of the parent document, an array of ids for many to many relations
+	LocalKey string
+	// ForeignKey is the field of the related documents matched against LocalKey
+	ForeignKey string
+}
+
+// ModelWithRelations can be implemented by models to define the relations
+// that QueryBuilder.With eager loads
+type ModelWithRelations interface {
+	// Relations returns the relations keyed by the name passed to With
+	Relations() map[string]Relation
+}
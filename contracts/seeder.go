@@ -0,0 +1,10 @@
+package contracts
+
+// Seeder populates a MongoDB database, register it with Client.RegisterSeeders
+// to run it from the mongodb:seed command
+type Seeder interface {
+	// Signature the unique signature of the seeder.
+	Signature() string
+	// Run executes the seeder logic.
+	Run(database Database) error
+}
@@ -2,9 +2,13 @@ package mongodb
 
 import (
 	"context"
+	"fmt"
+	"regexp"
+	"strings"
 	"time"
 
 	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 
@@ -13,17 +17,30 @@ import (
 
 var _ contracts.Database = &Database{}
 
+// The granularities of Database.CreateTimeSeries
+const (
+	TimeSeriesGranularitySeconds = "seconds"
+	TimeSeriesGranularityMinutes = "minutes"
+	TimeSeriesGranularityHours   = "hours"
+)
+
 type Database struct {
-	client   *mongo.Client
-	database *mongo.Database
-	config   contracts.ConfigBuilder
+	client    *mongo.Client
+	database  *mongo.Database
+	config    contracts.ConfigBuilder
+	scopes    *scopeRegistry
+	observers *observerRegistry
+	// ctx is the parent of the contexts of the operations, set by WithContext
+	ctx context.Context
 }
 
 func NewDatabase(client *mongo.Client, config contracts.ConfigBuilder, name string) *Database {
 	return &Database{
-		client:   client,
-		database: client.Database(name),
-		config:   config,
+		client:    client,
+		database:  client.Database(name),
+		config:    config,
+		scopes:    newScopeRegistry(),
+		observers: newObserverRegistry(),
 	}
 }
 
@@ -32,28 +49,179 @@ func (d *Database) Native() *mongo.Database {
 }
 
 func (d *Database) Collection(name string) contracts.Collection {
-	return NewCollection(d.client, d.config, name, d.database.Name())
+	collection := NewCollection(d.client, d.config, name, d.database.Name())
+	collection.scopes = d.scopes
+	collection.observers = d.observers
+	collection.ctx = d.ctx
+
+	return collection
 }
 
+// GridFS returns the GridFS bucket with the given name, "fs" by default
+func (d *Database) GridFS(bucket ...string) (contracts.GridFS, error) {
+	var name string
+	if len(bucket) > 0 {
+		name = bucket[0]
+	}
+
+	gridFS, err := NewGridFS(d.database, name)
+	if err != nil {
+		return nil, err
+	}
+	if d.ctx != nil {
+		return gridFS.WithContext(d.ctx), nil
+	}
+
+	return gridFS, nil
+}
+
+// CreateCollection creates a collection with *options.CreateCollectionOptions,
+// a struct or a pointer to one adds the validator generated by Validator. The
+// validator of the options takes precedence.
 func (d *Database) CreateCollection(name string, opts ...interface{}) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	ctx, cancel := operationContext(d.ctx, 30*time.Second)
+	defer cancel()
+
+	var createOpts []*options.CreateCollectionOptions
+	for _, opt := range opts {
+		if opt, ok := opt.(*options.CreateCollectionOptions); ok {
+			createOpts = append(createOpts, opt)
+			continue
+		}
+
+		if _, ok := structType(opt); ok {
+			validator, err := Validator(opt)
+			if err != nil {
+				return err
+			}
+			createOpts = append([]*options.CreateCollectionOptions{options.CreateCollection().SetValidator(validator)}, createOpts...)
+		}
+	}
+
+	return d.database.CreateCollection(ctx, name, createOpts...)
+}
+
+// CreateTimeSeries creates a time-series collection, granularity is one of the
+// TimeSeriesGranularity constants
+func (d *Database) CreateTimeSeries(name, timeField, metaField, granularity string, expireAfter time.Duration) error {
+	timeSeries := options.TimeSeries().SetTimeField(timeField)
+	if metaField != "" {
+		timeSeries.SetMetaField(metaField)
+	}
+	if granularity != "" {
+		timeSeries.SetGranularity(granularity)
+	}
+
+	createOpts := options.CreateCollection().SetTimeSeriesOptions(timeSeries)
+	if expireAfter > 0 {
+		createOpts.SetExpireAfterSeconds(int64(expireAfter.Seconds()))
+	}
+
+	return d.CreateCollection(name, createOpts)
+}
+
+func (d *Database) CreateCapped(name string, size, max int64) error {
+	createOpts := options.CreateCollection().SetCapped(true).SetSizeInBytes(size)
+	if max > 0 {
+		createOpts.SetMaxDocuments(max)
+	}
+
+	return d.CreateCollection(name, createOpts)
+}
+
+func (d *Database) CreateClustered(name string) error {
+	return d.CreateCollection(name, options.CreateCollection().SetClusteredIndex(bson.D{
+		{Key: "key", Value: bson.D{{Key: "_id", Value: 1}}},
+		{Key: "unique", Value: true},
+	}))
+}
+
+// CollectionInfo returns the type and options of a collection, CollectionNotFound
+// when it does not exist
+func (d *Database) CollectionInfo(name string) (*contracts.CollectionInfo, error) {
+	infos, err := d.ListCollectionSpecs(bson.D{{Key: "name", Value: name}})
+	if err != nil {
+		return nil, err
+	}
+	if len(infos) == 0 {
+		return nil, fmt.Errorf("%w: %s.%s", CollectionNotFound, d.database.Name(), name)
+	}
+
+	return &infos[0], nil
+}
+
+// ListCollectionSpecs describes the collections and views matching the filter,
+// a listCollections filter document or a name pattern where * matches any
+// characters and ? a single one, like "user_*"
+func (d *Database) ListCollectionSpecs(filter interface{}) ([]contracts.CollectionInfo, error) {
+	ctx, cancel := operationContext(d.ctx, 30*time.Second)
 	defer cancel()
 
-	var createOpts *options.CreateCollectionOptions
-	if len(opts) > 0 {
-		if opt, ok := opts[0].(*options.CreateCollectionOptions); ok {
-			createOpts = opt
+	if pattern, ok := filter.(string); ok {
+		filter = bson.D{{Key: "name", Value: NamePattern(pattern)}}
+	}
+	if filter == nil {
+		filter = bson.D{}
+	}
+
+	specifications, err := d.database.ListCollectionSpecifications(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list the collections of %s: %w", d.database.Name(), err)
+	}
+
+	infos := make([]contracts.CollectionInfo, len(specifications))
+	for i, specification := range specifications {
+		infos[i] = contracts.CollectionInfo{
+			Name:     specification.Name,
+			Type:     specification.Type,
+			ReadOnly: specification.ReadOnly,
+			Options:  bson.M{},
+		}
+		if specification.UUID != nil {
+			infos[i].UUID = formatUUID(specification.UUID.Data)
+		}
+		if len(specification.Options) > 0 {
+			if err := bson.Unmarshal(specification.Options, &infos[i].Options); err != nil {
+				return nil, fmt.Errorf("failed to decode the options of %s: %w", specification.Name, err)
+			}
+		}
+		if validator, ok := infos[i].Options["validator"].(bson.M); ok {
+			infos[i].Validator = validator
 		}
 	}
 
-	return d.database.CreateCollection(ctx, name, createOpts)
+	return infos, nil
 }
 
+// CreateView creates a read-only view running the pipeline on the source
+// collection, a view without a pipeline shows every document
+func (d *Database) CreateView(name, source string, pipeline interface{}) error {
+	ctx, cancel := operationContext(d.ctx, 30*time.Second)
+	defer cancel()
+
+	if pipeline == nil {
+		pipeline = mongo.Pipeline{}
+	}
+
+	return d.database.CreateView(ctx, name, source, pipeline)
+}
+
+// ListCollections returns the names of the collections and the views
 func (d *Database) ListCollections() ([]string, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	return d.collectionNames(bson.M{})
+}
+
+// ListViews returns the names of the views, the materialised views are
+// collections listed by ListCollections
+func (d *Database) ListViews() ([]string, error) {
+	return d.collectionNames(bson.M{"type": "view"})
+}
+
+func (d *Database) collectionNames(filter bson.M) ([]string, error) {
+	ctx, cancel := operationContext(d.ctx, 30*time.Second)
 	defer cancel()
 
-	cursor, err := d.database.ListCollections(ctx, bson.M{})
+	cursor, err := d.database.ListCollections(ctx, filter)
 	if err != nil {
 		return nil, err
 	}
@@ -73,12 +241,50 @@ func (d *Database) ListCollections() ([]string, error) {
 	return collections, cursor.Err()
 }
 
+// Watch opens a change stream on the database, see Collection.Watch for the options
+func (d *Database) Watch(pipeline interface{}, opts ...interface{}) (contracts.ChangeStream, error) {
+	stream, err := watch(d.ctx, d.database, pipeline, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return stream, nil
+}
+
 func (d *Database) Drop() error {
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	ctx, cancel := operationContext(d.ctx, 30*time.Second)
 	defer cancel()
 	return d.database.Drop(ctx)
 }
 
+// WithContext returns the database running its operations with ctx, the
+// collections and the GridFS buckets it returns use ctx too
+func (d *Database) WithContext(ctx context.Context) contracts.Database {
+	database := *d
+	database.ctx = ctx
+
+	return &database
+}
+
 func (d *Database) Name() string {
 	return d.database.Name()
 }
+
+// NamePattern matches the names of a pattern where * matches any characters
+// and ? a single one
+func NamePattern(pattern string) primitive.Regex {
+	quoted := regexp.QuoteMeta(pattern)
+	quoted = strings.ReplaceAll(quoted, `\*`, ".*")
+	quoted = strings.ReplaceAll(quoted, `\?`, ".")
+
+	return primitive.Regex{Pattern: "^" + quoted + "$"}
+}
+
+// formatUUID formats the 16 bytes of a UUID in its canonical form
+func formatUUID(data []byte) string {
+	if len(data) != 16 {
+		return fmt.Sprintf("%x", data)
+	}
+
+	return fmt.Sprintf("%x-%x-%x-%x-%x", data[0:4], data[4:6], data[6:8], data[8:10], data[10:16])
+}
@@ -0,0 +1,46 @@
+package mongodb
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type DatabaseSuite struct {
+	suite.Suite
+}
+
+func TestDatabaseSuite(t *testing.T) {
+	suite.Run(t, &DatabaseSuite{})
+}
+
+func (s *DatabaseSuite) TestNamePattern() {
+	tests := []struct {
+		pattern string
+		matches []string
+		misses  []string
+	}{
+		{pattern: "users", matches: []string{"users"}, misses: []string{"users_archive", "old_users"}},
+		{pattern: "user_*", matches: []string{"user_", "user_logs"}, misses: []string{"users", "userXlogs"}},
+		{pattern: "log?", matches: []string{"logs", "log1"}, misses: []string{"log", "logs1"}},
+		{pattern: "a.b*", matches: []string{"a.b", "a.bc"}, misses: []string{"axb"}},
+	}
+
+	for _, test := range tests {
+		s.Run(test.pattern, func() {
+			pattern := regexp.MustCompile(NamePattern(test.pattern).Pattern)
+			for _, name := range test.matches {
+				s.True(pattern.MatchString(name), name)
+			}
+			for _, name := range test.misses {
+				s.False(pattern.MatchString(name), name)
+			}
+		})
+	}
+}
+
+func (s *DatabaseSuite) TestFormatUUID() {
+	s.Equal("00112233-4455-6677-8899-aabbccddeeff", formatUUID([]byte{0x00, 0x11, 0x22, 0x33, 0x44, 0x55, 0x66, 0x77, 0x88, 0x99, 0xaa, 0xbb, 0xcc, 0xdd, 0xee, 0xff}))
+	s.Equal("0102", formatUUID([]byte{0x01, 0x02}))
+}
@@ -7,9 +7,17 @@ import (
 	"database/sql"
 	"database/sql/driver"
 	"errors"
+	"fmt"
 	"strconv"
+	"strings"
 	"sync"
+	"time"
 
+	"github.com/goravel/framework/contracts/log"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/x/mongo/driver/connstring"
 	"gorm.io/gorm"
 	"gorm.io/gorm/callbacks"
 	"gorm.io/gorm/clause"
@@ -18,14 +26,35 @@ import (
 )
 
 type Dialector struct {
-	DSN  string
-	Conn gorm.ConnPool
+	DSN string
+	// Database is the database the statements run against, the database of
+	// the DSN is used when it is empty
+	Database string
+	Conn     gorm.ConnPool
+	// Strict makes the SQL statements reaching the dummy driver, raw SQL or
+	// facades.DB() queries, fail instead of logging a warning
+	Strict bool
+
+	// client returns the client of the MongoDB driver, the DSN is connected
+	// when it is nil
+	client func() (*mongo.Client, error)
+	// log receives the warnings of the ignored SQL statements, the GORM
+	// logger is used when it is nil
+	log log.Log
 }
 
 // mongoConnPool is a ConnPool implementation for MongoDB that satisfies GORM's interface
 // while redirecting operations to use the native MongoDB driver
 type mongoConnPool struct {
 	*sql.DB
+
+	connector *mongoConnector
+	dialector Dialector
+	mu        sync.Mutex
+	database  *mongo.Database
+	// client is the client connected from the DSN, disconnected on Close
+	client  *mongo.Client
+	schemas sync.Map
 }
 
 var (
@@ -33,6 +62,10 @@ var (
 	driverName = "mongodb-dummy"
 )
 
+// ignoredStatementWarning is logged for the SQL statements the dummy driver
+// ignores when the dialector is not strict
+const ignoredStatementWarning = "MongoDB ignored the SQL statement %q, use the native facade facades.MongoDB() instead"
+
 // newMongoConnPool creates a new MongoDB connection pool with a minimal SQL DB implementation
 func newMongoConnPool() (*mongoConnPool, error) {
 	// Register a custom driver that does nothing but satisfies the interface
@@ -41,26 +74,131 @@ func newMongoConnPool() (*mongoConnPool, error) {
 		sql.Register(driverName, &mongoDriver{})
 	})
 
-	db, err := sql.Open(driverName, "dummy")
+	connector := &mongoConnector{}
+
+	return &mongoConnPool{DB: sql.OpenDB(connector), connector: connector}, nil
+}
+
+// mongoDatabase connects on first use and returns the database the GORM
+// statements run against
+func (m *mongoConnPool) mongoDatabase() (*mongo.Database, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.database != nil {
+		return m.database, nil
+	}
+
+	name := m.dialector.Database
+	if name == "" && m.dialector.DSN != "" {
+		connString, err := connstring.ParseAndValidate(m.dialector.DSN)
+		if err != nil {
+			return nil, fmt.Errorf("invalid MongoDB DSN: %w", err)
+		}
+		name = connString.Database
+	}
+	if name == "" {
+		return nil, DatabaseNotFound
+	}
+
+	if m.dialector.client != nil {
+		client, err := m.dialector.client()
+		if err != nil {
+			return nil, err
+		}
+		m.database = client.Database(name)
+
+		return m.database, nil
+	}
+
+	if m.dialector.DSN == "" {
+		return nil, fmt.Errorf("MongoDB URI is required")
+	}
+	client, err := mongo.Connect(context.Background(), options.Client().ApplyURI(m.dialector.DSN))
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("failed to connect to MongoDB: %w", err)
+	}
+	m.client = client
+	m.database = client.Database(name)
+
+	return m.database, nil
+}
+
+// Close closes the SQL handle and disconnects the client connected from the DSN
+func (m *mongoConnPool) Close() error {
+	m.mu.Lock()
+	client := m.client
+	m.client = nil
+	m.database = nil
+	m.mu.Unlock()
+
+	if client != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		if err := client.Disconnect(ctx); err != nil {
+			return err
+		}
 	}
 
-	return &mongoConnPool{DB: db}, nil
+	return m.DB.Close()
 }
 
 // mongoDriver is a minimal sql/driver.Driver implementation for MongoDB compatibility
 type mongoDriver struct{}
 
 func (d *mongoDriver) Open(name string) (driver.Conn, error) {
-	return &mongoConn{}, nil
+	return &mongoConn{connector: &mongoConnector{}}, nil
+}
+
+// mongoConnector opens the connections of the dummy SQL handle and decides
+// what happens to the statements run on them
+type mongoConnector struct {
+	strict bool
+	warn   func(query string)
+	// run executes the MongoDB commands compiled by the Grammar
+	run func(ctx context.Context, command bson.D) ([]bson.D, error)
+}
+
+func (c *mongoConnector) Connect(context.Context) (driver.Conn, error) {
+	return &mongoConn{connector: c}, nil
+}
+
+func (c *mongoConnector) Driver() driver.Driver {
+	return &mongoDriver{}
+}
+
+// statement is called for every statement, the MongoDB commands of the
+// schema facade run on the database and the SQL statements never reach it
+func (c *mongoConnector) statement(ctx context.Context, query string) ([]bson.D, error) {
+	// The Grammar compiles nothing for the schema operations MongoDB has no use for
+	if strings.TrimSpace(query) == "" {
+		return nil, nil
+	}
+	if command, ok := parseCommand(query); ok && c.run != nil {
+		return c.run(ctx, command)
+	}
+
+	if c.strict {
+		// The commands the Grammar does not compile are rejected like SQL
+		if strings.HasPrefix(strings.TrimSpace(query), "{") {
+			return nil, fmt.Errorf("%w: MongoDB command %q is not a schema command, use the native facade facades.MongoDB() instead", UnsupportedStatement, query)
+		}
+		return nil, fmt.Errorf("%w: SQL statement %q cannot run on MongoDB, use the native facade facades.MongoDB() instead", UnsupportedStatement, query)
+	}
+	if c.warn != nil {
+		c.warn(query)
+	}
+
+	return nil, nil
 }
 
 // mongoConn is a minimal sql/driver.Conn implementation
-type mongoConn struct{}
+type mongoConn struct {
+	connector *mongoConnector
+}
 
 func (c *mongoConn) Prepare(query string) (driver.Stmt, error) {
-	return &mongoStmt{}, nil
+	return &mongoStmt{query: query, connector: c.connector}, nil
 }
 
 func (c *mongoConn) Close() error {
@@ -72,22 +210,44 @@ func (c *mongoConn) Begin() (driver.Tx, error) {
 }
 
 // mongoStmt is a minimal sql/driver.Stmt implementation
-type mongoStmt struct{}
+type mongoStmt struct {
+	query     string
+	connector *mongoConnector
+}
 
 func (s *mongoStmt) Close() error {
 	return nil
 }
 
+// NumInput returns -1, statements with any number of arguments reach Exec
+// and Query to be reported
 func (s *mongoStmt) NumInput() int {
-	return 0
+	return -1
 }
 
 func (s *mongoStmt) Exec(args []driver.Value) (driver.Result, error) {
+	return s.ExecContext(context.Background(), nil)
+}
+
+func (s *mongoStmt) ExecContext(ctx context.Context, args []driver.NamedValue) (driver.Result, error) {
+	if _, err := s.connector.statement(ctx, s.query); err != nil {
+		return nil, err
+	}
+
 	return &mongoResult{}, nil
 }
 
 func (s *mongoStmt) Query(args []driver.Value) (driver.Rows, error) {
-	return &mongoRows{}, nil
+	return s.QueryContext(context.Background(), nil)
+}
+
+func (s *mongoStmt) QueryContext(ctx context.Context, args []driver.NamedValue) (driver.Rows, error) {
+	documents, err := s.connector.statement(ctx, s.query)
+	if err != nil {
+		return nil, err
+	}
+
+	return newCommandRows(documents), nil
 }
 
 // mongoTx is a minimal sql/driver.Tx implementation
@@ -112,21 +272,6 @@ func (r *mongoResult) RowsAffected() (int64, error) {
 	return 0, nil
 }
 
-// mongoRows is a minimal sql/driver.Rows implementation
-type mongoRows struct{}
-
-func (r *mongoRows) Columns() []string {
-	return []string{}
-}
-
-func (r *mongoRows) Close() error {
-	return nil
-}
-
-func (r *mongoRows) Next(dest []driver.Value) error {
-	return nil
-}
-
 // gorm.ConnPool interface is automatically satisfied by embedding *sql.DB
 // No need to implement individual methods since *sql.DB already implements them
 
@@ -201,14 +346,25 @@ func (dialector Dialector) Name() string {
 }
 
 func (dialector Dialector) Initialize(db *gorm.DB) (err error) {
+	var connPool *mongoConnPool
 	if dialector.Conn != nil {
 		db.ConnPool = dialector.Conn
 	} else {
 		// For MongoDB, create a minimal SQL DB that satisfies GORM's requirements
-		connPool, err := newMongoConnPool()
+		connPool, err = newMongoConnPool()
 		if err != nil {
 			return err
 		}
+		connPool.dialector = dialector
+		connPool.connector.strict = dialector.Strict
+		connPool.connector.run = connPool.runCommand
+		connPool.connector.warn = func(query string) {
+			if dialector.log != nil {
+				dialector.log.Warningf(ignoredStatementWarning, query)
+				return
+			}
+			db.Logger.Warn(context.Background(), ignoredStatementWarning, query)
+		}
 
 		// Ensure the connection pool is not nil
 		if connPool == nil {
@@ -241,6 +397,13 @@ func (dialector Dialector) Initialize(db *gorm.DB) (err error) {
 		LastInsertIDReversed: true,
 	})
 
+	// Run the statements against MongoDB instead of the SQL handle
+	if connPool != nil {
+		if err := connPool.registerCallbacks(db); err != nil {
+			return err
+		}
+	}
+
 	for k, v := range dialector.ClauseBuilders() {
 		db.ClauseBuilders[k] = v
 	}
@@ -395,12 +558,13 @@ func (dialector Dialector) DataTypeOf(field *schema.Field) string {
 	return string(field.DataType)
 }
 
+// SavePoint is a no-op, the statements of a transaction are not run on the
+// dummy SQL connection
 func (dialectopr Dialector) SavePoint(tx *gorm.DB, name string) error {
-	tx.Exec("SAVEPOINT " + name)
 	return nil
 }
 
+// RollbackTo is a no-op like SavePoint
 func (dialectopr Dialector) RollbackTo(tx *gorm.DB, name string) error {
-	tx.Exec("ROLLBACK TO SAVEPOINT " + name)
 	return nil
 }
@@ -1,75 +1,315 @@
 package mongodb
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
 	"github.com/goravel/framework/contracts/testing/docker"
+	supportdocker "github.com/goravel/framework/support/docker"
+	"github.com/goravel/framework/support/process"
+	testingdocker "github.com/goravel/framework/testing/docker"
+	"github.com/spf13/cast"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 var _ docker.DatabaseDriver = &Docker{}
 
+// systemDatabases are kept by Fresh
+var systemDatabases = []string{"admin", "config", "local"}
+
 type Docker struct {
-	database string
+	databaseConfig docker.DatabaseConfig
+	image          *docker.Image
+	imageDriver    *testingdocker.ImageDriver
+	replicaSet     string
 }
 
-func NewDocker(database string) *Docker {
+func NewDocker(database, username, password string) *Docker {
+	var env []string
+	if username != "" && password != "" {
+		env = []string{
+			"MONGO_INITDB_ROOT_USERNAME=" + username,
+			"MONGO_INITDB_ROOT_PASSWORD=" + password,
+		}
+	}
+
+	image := &docker.Image{
+		Repository:   "mongo",
+		Tag:          "7.0",
+		Env:          env,
+		ExposedPorts: []string{"27017"},
+	}
+
 	return &Docker{
-		database: database,
+		databaseConfig: docker.DatabaseConfig{
+			Driver:   Name,
+			Host:     "127.0.0.1",
+			Port:     27017,
+			Database: database,
+			Username: username,
+			Password: password,
+		},
+		image:       image,
+		imageDriver: testingdocker.NewImageDriver(*image),
 	}
 }
 
+// Build starts the container on a random port, Ready needs to be called to
+// wait for MongoDB to accept connections
 func (r *Docker) Build() error {
+	if err := r.imageDriver.Build(); err != nil {
+		return err
+	}
+
+	config := r.imageDriver.Config()
+	r.databaseConfig.ContainerID = config.ContainerID
+	r.databaseConfig.Port = cast.ToInt(supportdocker.ExposedPort(config.ExposedPorts, r.containerPort()))
+
 	return nil
 }
 
 func (r *Docker) Config() docker.DatabaseConfig {
-	return docker.DatabaseConfig{
-		Driver:   Name,
-		Host:     r.Host(),
-		Port:     r.Port(),
-		Database: r.database,
-		Username: r.Username(),
-		Password: r.Password(),
-	}
+	return r.databaseConfig
 }
 
+// Database returns a driver for another database of the same container,
+// MongoDB creates databases on first write so nothing needs to be created
 func (r *Docker) Database(name string) (docker.DatabaseDriver, error) {
-	return NewDocker(name), nil
+	databaseDocker := NewDocker(name, r.databaseConfig.Username, r.databaseConfig.Password)
+	databaseDocker.databaseConfig.ContainerID = r.databaseConfig.ContainerID
+	databaseDocker.databaseConfig.Port = r.databaseConfig.Port
+	databaseDocker.replicaSet = r.replicaSet
+	databaseDocker.Image(*r.image)
+
+	return databaseDocker, nil
 }
 
 func (r *Docker) Driver() string {
 	return Name
 }
 
+// Fresh drops every database except the system ones
 func (r *Docker) Fresh() error {
+	client, err := r.connect()
+	if err != nil {
+		return fmt.Errorf("connect MongoDB error when refreshing: %w", err)
+	}
+	defer r.disconnect(client)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	databases, err := client.ListDatabaseNames(ctx, map[string]any{})
+	if err != nil {
+		return fmt.Errorf("list databases error when refreshing MongoDB: %w", err)
+	}
+
+	for _, database := range databases {
+		if slices.Contains(systemDatabases, database) {
+			continue
+		}
+		if err := client.Database(database).Drop(ctx); err != nil {
+			return fmt.Errorf("drop database %s error when refreshing MongoDB: %w", database, err)
+		}
+	}
+
 	return nil
 }
 
 func (r *Docker) Host() string {
-	return "localhost"
+	return r.databaseConfig.Host
 }
 
 func (r *Docker) Image(image docker.Image) {
+	r.image = &image
+	r.imageDriver = testingdocker.NewImageDriver(image)
+}
+
+func (r *Docker) Password() string {
+	return r.databaseConfig.Password
+}
+
+func (r *Docker) Port() int {
+	return r.databaseConfig.Port
 }
 
 func (r *Docker) Ready() error {
-	return nil
+	if r.replicaSet != "" {
+		if err := r.imageDriver.Ready(r.initiateReplicaSet); err != nil {
+			return err
+		}
+	}
+
+	return r.imageDriver.Ready(func() error {
+		client, err := r.connect()
+		if err != nil {
+			return err
+		}
+		r.disconnect(client)
+
+		return nil
+	})
+}
+
+// ReplicaSet runs the container as a single node replica set, which is
+// required by transactions and change streams. It replaces the image, so
+// Image should not be called afterwards.
+func (r *Docker) ReplicaSet(name string) *Docker {
+	// The node announces its own address to the clients, so the container
+	// listens on the same port as the one published on the host.
+	port := strconv.Itoa(process.ValidPort())
+	mongod := []string{"mongod", "--replSet", name, "--port", port, "--bind_ip_all"}
+
+	image := *r.image
+	image.ExposedPorts = []string{port + ":" + port}
+	if r.databaseConfig.Username != "" && r.databaseConfig.Password != "" {
+		// A replica set with authentication requires a key file
+		keyFile := "/tmp/mongodb-keyfile"
+		script := fmt.Sprintf("head -c 756 /dev/urandom | base64 > %[1]s && chmod 400 %[1]s && chown mongodb:mongodb %[1]s && exec docker-entrypoint.sh %[2]s --keyFile %[1]s",
+			keyFile, strings.Join(mongod, " "))
+		image.Args = []string{"bash", "-c", "'" + script + "'"}
+	} else {
+		image.Args = mongod[1:]
+	}
+
+	r.replicaSet = name
+	r.Image(image)
+
+	return r
 }
 
 func (r *Docker) Reuse(containerID string, port int) error {
+	r.databaseConfig.ContainerID = containerID
+	r.databaseConfig.Port = port
+
 	return nil
 }
 
+// Shutdown stops the container of the driver, including the one set by
+// Reuse or shared with Database
 func (r *Docker) Shutdown() error {
+	if r.databaseConfig.ContainerID == "" {
+		return nil
+	}
+
+	if _, err := process.Run(fmt.Sprintf("docker stop %s", r.databaseConfig.ContainerID)); err != nil {
+		return fmt.Errorf("stop MongoDB container %s error: %w", r.databaseConfig.ContainerID, err)
+	}
+
 	return nil
 }
 
-func (r *Docker) Password() string {
-	return "123123"
+func (r *Docker) Username() string {
+	return r.databaseConfig.Username
 }
 
-func (r *Docker) Port() int {
-	return 27017
+// URI returns the connection string of the container, it can be set as the
+// uri of the MongoDB connection in tests
+func (r *Docker) URI() string {
+	query := url.Values{}
+	if r.replicaSet != "" {
+		query.Set("replicaSet", r.replicaSet)
+	}
+
+	return r.uri(query)
 }
 
-func (r *Docker) Username() string {
-	return "root"
+func (r *Docker) uri(query url.Values) string {
+	uri := url.URL{
+		Scheme: "mongodb",
+		Host:   fmt.Sprintf("%s:%d", r.databaseConfig.Host, r.databaseConfig.Port),
+		Path:   "/" + r.databaseConfig.Database,
+	}
+
+	if r.databaseConfig.Username != "" {
+		uri.User = url.UserPassword(r.databaseConfig.Username, r.databaseConfig.Password)
+		query.Set("authSource", "admin")
+	}
+	uri.RawQuery = query.Encode()
+
+	return uri.String()
+}
+
+// containerPort returns the port MongoDB listens on inside the container
+func (r *Docker) containerPort() string {
+	if len(r.image.ExposedPorts) > 0 {
+		ports := strings.Split(r.image.ExposedPorts[0], ":")
+		return ports[len(ports)-1]
+	}
+
+	return "27017"
+}
+
+// initiateReplicaSet initiates the replica set if needed and fails until
+// the node is elected primary
+func (r *Docker) initiateReplicaSet() error {
+	// The replica set cannot be discovered before it is initiated
+	client, err := r.connectURI(r.uri(url.Values{"directConnection": []string{"true"}}))
+	if err != nil {
+		return err
+	}
+	defer r.disconnect(client)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	admin := client.Database("admin")
+	err = admin.RunCommand(ctx, bson.D{{Key: "replSetInitiate", Value: bson.M{
+		"_id": r.replicaSet,
+		"members": bson.A{
+			bson.M{"_id": 0, "host": fmt.Sprintf("%s:%d", r.databaseConfig.Host, r.databaseConfig.Port)},
+		},
+	}}}).Err()
+	var commandErr mongo.CommandError
+	if err != nil && !(errors.As(err, &commandErr) && commandErr.Name == "AlreadyInitialized") {
+		return fmt.Errorf("initiate MongoDB replica set error: %w", err)
+	}
+
+	var hello struct {
+		IsWritablePrimary bool `bson:"isWritablePrimary"`
+	}
+	if err := admin.RunCommand(ctx, bson.D{{Key: "hello", Value: 1}}).Decode(&hello); err != nil {
+		return err
+	}
+	if !hello.IsWritablePrimary {
+		return fmt.Errorf("MongoDB replica set %s has no primary yet", r.replicaSet)
+	}
+
+	return nil
+}
+
+func (r *Docker) connect() (*mongo.Client, error) {
+	return r.connectURI(r.URI())
+}
+
+func (r *Docker) connectURI(uri string) (*mongo.Client, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	client, err := mongo.Connect(ctx, options.Client().ApplyURI(uri).SetServerSelectionTimeout(5*time.Second))
+	if err != nil {
+		return nil, err
+	}
+
+	if err := client.Ping(ctx, nil); err != nil {
+		r.disconnect(client)
+		return nil, err
+	}
+
+	return client, nil
+}
+
+func (r *Docker) disconnect(client *mongo.Client) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_ = client.Disconnect(ctx)
 }
@@ -7,4 +7,11 @@ var (
 	ConfigNotFound      = errors.New("not found database configuration")
 	ConnectionFailed    = errors.New("failed to connect to MongoDB")
 	DatabaseNotFound    = errors.New("database name not specified")
+	CollectionNotFound  = errors.New("collection not found")
+	// RootDirectoryDeletion is returned by Filesystem.DeleteDirectory for the
+	// root, which would delete every file of the bucket
+	RootDirectoryDeletion = errors.New("the root directory of the GridFS bucket cannot be deleted")
+	// UnsupportedStatement is wrapped by the errors of the GORM statements the
+	// dialector cannot translate to MongoDB
+	UnsupportedStatement = errors.New("statement is not supported by the MongoDB dialector")
 )
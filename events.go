@@ -0,0 +1,152 @@
+package mongodb
+
+import (
+	"context"
+	"reflect"
+	"sync"
+
+	"github.com/portofolio-mager/goravel-mongodb/contracts"
+)
+
+var _ contracts.Event = &Event{}
+
+type Event struct {
+	ctx        context.Context
+	eventType  contracts.EventType
+	collection contracts.Collection
+	model      any
+	filter     any
+	update     any
+}
+
+func NewEvent(ctx context.Context, eventType contracts.EventType, collection contracts.Collection, model, filter, update any) *Event {
+	return &Event{
+		ctx:        ctx,
+		eventType:  eventType,
+		collection: collection,
+		model:      model,
+		filter:     filter,
+		update:     update,
+	}
+}
+
+func (e *Event) Context() context.Context {
+	return e.ctx
+}
+
+func (e *Event) Type() contracts.EventType {
+	return e.eventType
+}
+
+func (e *Event) Collection() contracts.Collection {
+	return e.collection
+}
+
+func (e *Event) Model() any {
+	return e.model
+}
+
+func (e *Event) Filter() any {
+	return e.filter
+}
+
+func (e *Event) Update() any {
+	return e.update
+}
+
+// observerRegistry holds the observers of a client by collection namespace, so
+// that every Collection instance of the same collection shares them
+type observerRegistry struct {
+	mu        sync.RWMutex
+	observers map[string][]contracts.Observer
+}
+
+func newObserverRegistry() *observerRegistry {
+	return &observerRegistry{
+		observers: make(map[string][]contracts.Observer),
+	}
+}
+
+func (r *observerRegistry) observe(namespace string, observer contracts.Observer) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.observers[namespace] = append(r.observers[namespace], observer)
+}
+
+func (r *observerRegistry) of(namespace string) []contracts.Observer {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.observers[namespace]
+}
+
+// dispatchEvent calls the handler of the model, then the observers of the collection
+func dispatchEvent(event *Event, observers []contracts.Observer) error {
+	if model, ok := event.model.(contracts.DispatchesEvents); ok {
+		if handler := model.DispatchesEvents()[event.eventType]; handler != nil {
+			if err := handler(event); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, observer := range observers {
+		if err := callObserver(observer, event); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func callObserver(observer contracts.Observer, event *Event) error {
+	switch event.eventType {
+	case contracts.EventCreating:
+		if o, ok := observer.(contracts.ObserverWithCreating); ok {
+			return o.Creating(event)
+		}
+	case contracts.EventCreated:
+		return observer.Created(event)
+	case contracts.EventUpdating:
+		if o, ok := observer.(contracts.ObserverWithUpdating); ok {
+			return o.Updating(event)
+		}
+	case contracts.EventUpdated:
+		return observer.Updated(event)
+	case contracts.EventDeleting:
+		if o, ok := observer.(contracts.ObserverWithDeleting); ok {
+			return o.Deleting(event)
+		}
+	case contracts.EventDeleted:
+		return observer.Deleted(event)
+	case contracts.EventRetrieved:
+		if o, ok := observer.(contracts.ObserverWithRetrieved); ok {
+			return o.Retrieved(event)
+		}
+	}
+
+	return nil
+}
+
+// eachModel calls fn with a pointer to every element when results is a
+// pointer to a slice, or with results itself otherwise
+func eachModel(results any, fn func(model any) error) error {
+	value := reflect.ValueOf(results)
+	if value.Kind() != reflect.Ptr || value.IsNil() || value.Elem().Kind() != reflect.Slice {
+		return fn(results)
+	}
+
+	slice := value.Elem()
+	for i := 0; i < slice.Len(); i++ {
+		item := slice.Index(i)
+		if item.Kind() != reflect.Ptr && item.CanAddr() {
+			item = item.Addr()
+		}
+		if err := fn(item.Interface()); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,109 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/portofolio-mager/goravel-mongodb/contracts"
+	mockscontracts "github.com/portofolio-mager/goravel-mongodb/mocks"
+)
+
+type eventUser struct {
+	Name   string
+	events []contracts.EventType
+}
+
+func (r *eventUser) DispatchesEvents() map[contracts.EventType]func(contracts.Event) error {
+	return map[contracts.EventType]func(contracts.Event) error{
+		contracts.EventCreating: func(event contracts.Event) error {
+			if r.Name == "" {
+				return errors.New("name is required")
+			}
+			r.events = append(r.events, event.Type())
+			return nil
+		},
+		contracts.EventRetrieved: func(event contracts.Event) error {
+			r.events = append(r.events, event.Type())
+			return nil
+		},
+	}
+}
+
+type retrievingObserver struct {
+	*mockscontracts.Observer
+	retrieved []any
+}
+
+func (r *retrievingObserver) Retrieved(event contracts.Event) error {
+	r.retrieved = append(r.retrieved, event.Model())
+	return nil
+}
+
+type EventsTestSuite struct {
+	suite.Suite
+	ctx context.Context
+}
+
+func TestEventsTestSuite(t *testing.T) {
+	suite.Run(t, new(EventsTestSuite))
+}
+
+func (s *EventsTestSuite) SetupTest() {
+	s.ctx = context.Background()
+}
+
+func (s *EventsTestSuite) TestDispatchEvent_Model() {
+	user := &eventUser{Name: "goravel"}
+	s.NoError(dispatchEvent(NewEvent(s.ctx, contracts.EventCreating, nil, user, nil, nil), nil))
+	s.Equal([]contracts.EventType{contracts.EventCreating}, user.events)
+
+	// Events without a handler are ignored
+	s.NoError(dispatchEvent(NewEvent(s.ctx, contracts.EventCreated, nil, user, nil, nil), nil))
+	s.Len(user.events, 1)
+
+	s.EqualError(dispatchEvent(NewEvent(s.ctx, contracts.EventCreating, nil, &eventUser{}, nil, nil), nil), "name is required")
+}
+
+func (s *EventsTestSuite) TestDispatchEvent_Observer() {
+	namespace := "test.observer"
+	observer := mockscontracts.NewObserver(s.T())
+	registry := newObserverRegistry()
+	registry.observe(namespace, observer)
+
+	filter := map[string]any{"name": "goravel"}
+	observer.EXPECT().Deleted(NewEvent(s.ctx, contracts.EventDeleted, nil, nil, filter, nil)).Return(nil).Once()
+	s.NoError(dispatchEvent(NewEvent(s.ctx, contracts.EventDeleted, nil, nil, filter, nil), registry.of(namespace)))
+
+	// Optional events are skipped when the observer does not implement them
+	s.NoError(dispatchEvent(NewEvent(s.ctx, contracts.EventDeleting, nil, nil, filter, nil), registry.of(namespace)))
+
+	observer.EXPECT().Updated(NewEvent(s.ctx, contracts.EventUpdated, nil, nil, filter, nil)).Return(errors.New("error")).Once()
+	s.EqualError(dispatchEvent(NewEvent(s.ctx, contracts.EventUpdated, nil, nil, filter, nil), registry.of(namespace)), "error")
+
+	s.Empty(registry.of("test.other"))
+	s.Empty(newObserverRegistry().of(namespace))
+}
+
+func (s *EventsTestSuite) TestEachModel() {
+	namespace := "test.retrieved"
+	observer := &retrievingObserver{Observer: mockscontracts.NewObserver(s.T())}
+	registry := newObserverRegistry()
+	registry.observe(namespace, observer)
+
+	users := []eventUser{{Name: "a"}, {Name: "b"}}
+	s.NoError(eachModel(&users, func(model any) error {
+		return dispatchEvent(NewEvent(s.ctx, contracts.EventRetrieved, nil, model, nil, nil), registry.of(namespace))
+	}))
+	s.Equal([]contracts.EventType{contracts.EventRetrieved}, users[0].events)
+	s.Equal([]contracts.EventType{contracts.EventRetrieved}, users[1].events)
+	s.Equal([]any{&users[0], &users[1]}, observer.retrieved)
+
+	user := &eventUser{Name: "c"}
+	s.NoError(eachModel(user, func(model any) error {
+		return dispatchEvent(NewEvent(s.ctx, contracts.EventRetrieved, nil, model, nil, nil), registry.of(namespace))
+	}))
+	s.Equal([]contracts.EventType{contracts.EventRetrieved}, user.events)
+}
@@ -2,8 +2,11 @@ package facades
 
 import (
 	"fmt"
+	nethttp "net/http"
 
 	"github.com/goravel/framework/contracts/database/driver"
+	"github.com/goravel/framework/contracts/filesystem"
+	"github.com/goravel/framework/contracts/http"
 
 	mongodb "github.com/portofolio-mager/goravel-mongodb"
 	"github.com/portofolio-mager/goravel-mongodb/contracts"
@@ -112,3 +115,69 @@ func NativeDatabase(name string, connection ...string) (*mongo.Database, error)
 	}
 	return native, nil
 }
+
+// HealthHandler responds with the health of every MongoDB connection, to be
+// registered as a readiness route:
+//
+//	facades.Route().Get("/health/mongodb", mongodbfacades.HealthHandler)
+func HealthHandler(ctx http.Context) http.Response {
+	if mongodb.App == nil {
+		return ctx.Response().Json(nethttp.StatusServiceUnavailable, map[string]any{
+			"status": mongodb.HealthDown,
+			"error":  "please register mongodb service provider",
+		})
+	}
+
+	return mongodb.NewHealthHandler(mongodb.App.MakeConfig(), MongoDB).Handle(ctx)
+}
+
+// GridFS returns a GridFS bucket of the default database of the given connection
+func GridFS(bucket string, connection ...string) (contracts.GridFS, error) {
+	conn := "mongodb"
+	if len(connection) > 0 && connection[0] != "" {
+		conn = connection[0]
+	}
+
+	client, err := MongoDB(conn)
+	if err != nil {
+		return nil, err
+	}
+	database := client.Database()
+	if database == nil {
+		return nil, fmt.Errorf("mongodb database is nil")
+	}
+
+	return database.GridFS(bucket)
+}
+
+// GridFSDriver returns a Goravel filesystem driver backed by GridFS, used as the
+// "via" of a custom disk in config/filesystems.go. The disk accepts the
+// "connection", "database", "bucket" and "url" options.
+func GridFSDriver(disk string) (filesystem.Driver, error) {
+	if mongodb.App == nil {
+		return nil, fmt.Errorf("please register mongodb service provider")
+	}
+
+	config := mongodb.App.MakeConfig()
+	if config == nil {
+		return nil, fmt.Errorf("config facade is not initialized")
+	}
+
+	prefix := fmt.Sprintf("filesystems.disks.%s", disk)
+	client, err := MongoDB(config.GetString(prefix+".connection", "mongodb"))
+	if err != nil {
+		return nil, err
+	}
+
+	database := client.Database(config.GetString(prefix + ".database"))
+	if database == nil {
+		return nil, fmt.Errorf("mongodb database is nil")
+	}
+
+	bucket, err := database.GridFS(config.GetString(prefix+".bucket", mongodb.DefaultBucket))
+	if err != nil {
+		return nil, err
+	}
+
+	return mongodb.NewFilesystem(bucket, config.GetString(prefix+".url")), nil
+}
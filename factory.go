@@ -0,0 +1,120 @@
+package mongodb
+
+import (
+	"fmt"
+	"reflect"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/portofolio-mager/goravel-mongodb/contracts"
+)
+
+// Factory builds models from a definition, optionally modified by states, and
+// inserts them into a collection:
+//
+//	users := mongodb.NewFactory(database.Collection("users"), func() User {
+//		return User{Name: "Goravel", Active: true}
+//	})
+//	inactive, err := users.Count(3).State(func(user *User) { user.Active = false }).Create()
+type Factory[T any] struct {
+	collection contracts.Collection
+	definition func() T
+	states     []func(*T)
+	count      int
+}
+
+func NewFactory[T any](collection contracts.Collection, definition func() T) *Factory[T] {
+	return &Factory[T]{
+		collection: collection,
+		definition: definition,
+		count:      1,
+	}
+}
+
+// Count returns a factory building count models
+func (r *Factory[T]) Count(count int) *Factory[T] {
+	factory := r.clone()
+	factory.count = count
+
+	return factory
+}
+
+// State returns a factory applying the states, in order, after the definition
+func (r *Factory[T]) State(states ...func(*T)) *Factory[T] {
+	factory := r.clone()
+	factory.states = append(factory.states, states...)
+
+	return factory
+}
+
+// Make builds the models without inserting them
+func (r *Factory[T]) Make() []T {
+	models := make([]T, r.count)
+	for i := range models {
+		models[i] = r.definition()
+		for _, state := range r.states {
+			state(&models[i])
+		}
+	}
+
+	return models
+}
+
+// Create builds the models and inserts them with InsertMany, the generated ids
+// are set on the models when they have an _id field
+func (r *Factory[T]) Create() ([]T, error) {
+	if r.collection == nil {
+		return nil, fmt.Errorf("factory has no collection to create models in")
+	}
+
+	models := r.Make()
+	if len(models) == 0 {
+		return models, nil
+	}
+
+	documents := make([]interface{}, len(models))
+	for i := range models {
+		documents[i] = &models[i]
+	}
+
+	result, err := r.collection.InsertMany(documents)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create models: %w", err)
+	}
+
+	for i, id := range result.InsertedIDs {
+		if i < len(models) {
+			setInsertedID(reflect.ValueOf(&models[i]), id)
+		}
+	}
+
+	return models, nil
+}
+
+func (r *Factory[T]) clone() *Factory[T] {
+	return &Factory[T]{
+		collection: r.collection,
+		definition: r.definition,
+		states:     append([]func(*T){}, r.states...),
+		count:      r.count,
+	}
+}
+
+// setInsertedID sets the id on the _id field of the model if it is empty and
+// the id can be assigned to it
+func setInsertedID(model reflect.Value, id interface{}) {
+	field, err := relationField(model, "_id")
+	if err != nil || !field.CanSet() || !field.IsZero() {
+		return
+	}
+
+	value := reflect.ValueOf(id)
+	switch {
+	case value.Type().AssignableTo(field.Type()):
+		field.Set(value)
+	case field.Kind() == reflect.String:
+		if objectID, ok := id.(primitive.ObjectID); ok {
+			field.SetString(objectID.Hex())
+		}
+	}
+}
@@ -0,0 +1,99 @@
+package mongodb
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	mockscontracts "github.com/portofolio-mager/goravel-mongodb/mocks"
+)
+
+type factoryUser struct {
+	ID     primitive.ObjectID `bson:"_id,omitempty"`
+	Name   string             `bson:"name"`
+	Active bool               `bson:"active"`
+}
+
+type factoryPost struct {
+	ID    string `bson:"_id,omitempty"`
+	Title string `bson:"title"`
+}
+
+type FactoryTestSuite struct {
+	suite.Suite
+	collection *mockscontracts.Collection
+	factory    *Factory[factoryUser]
+}
+
+func TestFactoryTestSuite(t *testing.T) {
+	suite.Run(t, new(FactoryTestSuite))
+}
+
+func (s *FactoryTestSuite) SetupTest() {
+	s.collection = mockscontracts.NewCollection(s.T())
+	s.factory = NewFactory(s.collection, func() factoryUser {
+		return factoryUser{Name: "Goravel", Active: true}
+	})
+}
+
+func (s *FactoryTestSuite) TestMake() {
+	s.Equal([]factoryUser{{Name: "Goravel", Active: true}}, s.factory.Make())
+
+	inactive := s.factory.State(func(user *factoryUser) {
+		user.Active = false
+	})
+	renamed := inactive.State(func(user *factoryUser) {
+		user.Name = "Framework"
+	}).Count(2)
+
+	s.Equal([]factoryUser{{Name: "Framework"}, {Name: "Framework"}}, renamed.Make())
+	s.Equal([]factoryUser{{Name: "Goravel"}}, inactive.Make())
+	s.Equal([]factoryUser{{Name: "Goravel", Active: true}}, s.factory.Make())
+	s.Empty(s.factory.Count(0).Make())
+}
+
+func (s *FactoryTestSuite) TestCreate() {
+	ids := []interface{}{primitive.NewObjectID(), primitive.NewObjectID()}
+	s.collection.EXPECT().InsertMany(mock.MatchedBy(func(documents []interface{}) bool {
+		return len(documents) == 2 && documents[0].(*factoryUser).Name == "Goravel"
+	})).Return(&mongo.InsertManyResult{InsertedIDs: ids}, nil).Once()
+
+	users, err := s.factory.Count(2).Create()
+	s.NoError(err)
+	s.Equal([]factoryUser{
+		{ID: ids[0].(primitive.ObjectID), Name: "Goravel", Active: true},
+		{ID: ids[1].(primitive.ObjectID), Name: "Goravel", Active: true},
+	}, users)
+
+	s.collection.EXPECT().InsertMany(mock.Anything).Return(nil, errors.New("duplicate key")).Once()
+	_, err = s.factory.Create()
+	s.EqualError(err, "failed to create models: duplicate key")
+
+	users, err = s.factory.Count(0).Create()
+	s.NoError(err)
+	s.Empty(users)
+
+	_, err = NewFactory(nil, func() factoryUser { return factoryUser{} }).Create()
+	s.EqualError(err, "factory has no collection to create models in")
+}
+
+func (s *FactoryTestSuite) TestCreateWithStringID() {
+	id := primitive.NewObjectID()
+	s.collection.EXPECT().InsertMany(mock.Anything).Return(&mongo.InsertManyResult{InsertedIDs: []interface{}{id, "custom"}}, nil).Once()
+
+	posts, err := NewFactory(s.collection, func() factoryPost {
+		return factoryPost{Title: "Goravel"}
+	}).Count(2).State(func(post *factoryPost) {
+		if post.Title == "Goravel" {
+			post.Title = "MongoDB"
+		}
+	}).Create()
+	s.NoError(err)
+	s.Equal(id.Hex(), posts[0].ID)
+	s.Equal("custom", posts[1].ID)
+	s.Equal("MongoDB", posts[1].Title)
+}
@@ -0,0 +1,383 @@
+package mongodb
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/goravel/framework/contracts/filesystem"
+	supportfile "github.com/goravel/framework/support/file"
+	"github.com/goravel/framework/support/str"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/gridfs"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/portofolio-mager/goravel-mongodb/contracts"
+)
+
+var _ filesystem.Driver = &Filesystem{}
+
+// Filesystem implements Goravel's filesystem driver on top of a GridFS bucket.
+// GridFS has no directories, they are derived from the "/" separated file names.
+type Filesystem struct {
+	gridfs contracts.GridFS
+	url    string
+}
+
+func NewFilesystem(gridfs contracts.GridFS, url string) *Filesystem {
+	return &Filesystem{
+		gridfs: gridfs,
+		url:    url,
+	}
+}
+
+func (r *Filesystem) AllDirectories(path string) ([]string, error) {
+	names, err := r.names(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var directories []string
+	seen := make(map[string]bool)
+	for _, name := range names {
+		segments := strings.Split(name, "/")
+		for i := 1; i < len(segments); i++ {
+			directory := strings.Join(segments[:i], "/") + "/"
+			if !seen[directory] {
+				seen[directory] = true
+				directories = append(directories, directory)
+			}
+		}
+	}
+	sort.Strings(directories)
+
+	return directories, nil
+}
+
+func (r *Filesystem) AllFiles(path string) ([]string, error) {
+	return r.names(path)
+}
+
+func (r *Filesystem) Copy(oldFile, newFile string) error {
+	content, err := r.GetBytes(oldFile)
+	if err != nil {
+		return err
+	}
+
+	return r.put(newFile, content)
+}
+
+func (r *Filesystem) Delete(files ...string) error {
+	for _, file := range files {
+		if !r.Exists(file) {
+			return fmt.Errorf("file %s does not exist", file)
+		}
+	}
+
+	for _, file := range files {
+		if err := r.deleteRevisions(file); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// DeleteDirectory deletes the files under the directory, the root cannot be
+// deleted
+func (r *Filesystem) DeleteDirectory(directory string) error {
+	if r.Path(directory) == "" {
+		return RootDirectoryDeletion
+	}
+
+	files, err := r.gridfs.Find(r.prefixFilter(directory))
+	if err != nil {
+		return err
+	}
+
+	for _, file := range files {
+		if err := r.gridfs.Delete(file.ID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *Filesystem) Directories(path string) ([]string, error) {
+	names, err := r.names(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var directories []string
+	seen := make(map[string]bool)
+	for _, name := range names {
+		if directory, _, found := strings.Cut(name, "/"); found && !seen[directory] {
+			seen[directory] = true
+			directories = append(directories, directory+"/")
+		}
+	}
+
+	return directories, nil
+}
+
+func (r *Filesystem) Exists(file string) bool {
+	revisions, err := r.revisions(file)
+
+	return err == nil && len(revisions) > 0
+}
+
+func (r *Filesystem) Files(path string) ([]string, error) {
+	names, err := r.names(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var files []string
+	for _, name := range names {
+		if !strings.Contains(name, "/") {
+			files = append(files, name)
+		}
+	}
+
+	return files, nil
+}
+
+func (r *Filesystem) Get(file string) (string, error) {
+	data, err := r.GetBytes(file)
+
+	return string(data), err
+}
+
+func (r *Filesystem) GetBytes(file string) ([]byte, error) {
+	var buffer bytes.Buffer
+	if _, err := r.gridfs.DownloadByName(r.Path(file), &buffer); err != nil {
+		return nil, err
+	}
+
+	return buffer.Bytes(), nil
+}
+
+func (r *Filesystem) LastModified(file string) (time.Time, error) {
+	latest, err := r.latest(file)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	return latest.UploadDate, nil
+}
+
+// MakeDirectory is a no-op, directories exist as long as they contain files
+func (r *Filesystem) MakeDirectory(directory string) error {
+	return nil
+}
+
+func (r *Filesystem) MimeType(file string) (string, error) {
+	latest, err := r.latest(file)
+	if err != nil {
+		return "", err
+	}
+
+	var metadata struct {
+		ContentType string `bson:"content_type"`
+	}
+	if latest.Metadata != nil {
+		if err := bson.Unmarshal(latest.Metadata, &metadata); err != nil {
+			return "", err
+		}
+	}
+	if metadata.ContentType != "" {
+		return metadata.ContentType, nil
+	}
+
+	content, err := r.GetBytes(file)
+	if err != nil {
+		return "", err
+	}
+
+	return http.DetectContentType(content), nil
+}
+
+func (r *Filesystem) Missing(file string) bool {
+	return !r.Exists(file)
+}
+
+func (r *Filesystem) Move(oldFile, newFile string) error {
+	revisions, err := r.revisions(oldFile)
+	if err != nil {
+		return err
+	}
+	if len(revisions) == 0 {
+		return fmt.Errorf("file %s does not exist", oldFile)
+	}
+
+	if err := r.deleteRevisions(newFile); err != nil {
+		return err
+	}
+	for _, revision := range revisions {
+		if err := r.gridfs.Rename(revision.ID, r.Path(newFile)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Path returns the GridFS file name of the file
+func (r *Filesystem) Path(file string) string {
+	name := path.Clean("/" + filepath.ToSlash(file))
+
+	return strings.TrimPrefix(name, "/")
+}
+
+func (r *Filesystem) Put(file, content string) error {
+	return r.put(file, []byte(content))
+}
+
+func (r *Filesystem) PutFile(filePath string, source filesystem.File) (string, error) {
+	return r.PutFileAs(filePath, source, str.Random(40))
+}
+
+func (r *Filesystem) PutFileAs(filePath string, source filesystem.File, name string) (string, error) {
+	data, err := os.ReadFile(source.File())
+	if err != nil {
+		return "", err
+	}
+
+	if filepath.Ext(name) == "" {
+		extension, err := supportfile.Extension(source.File(), true)
+		if err != nil {
+			return "", err
+		}
+		name = filepath.Base(name) + "." + extension
+	}
+
+	file := r.Path(path.Join(filePath, filepath.Base(name)))
+	if err := r.put(file, data); err != nil {
+		return "", err
+	}
+
+	return file, nil
+}
+
+func (r *Filesystem) Size(file string) (int64, error) {
+	latest, err := r.latest(file)
+	if err != nil {
+		return 0, err
+	}
+
+	return latest.Length, nil
+}
+
+func (r *Filesystem) TemporaryUrl(file string, time time.Time) (string, error) {
+	return r.Url(file), nil
+}
+
+// WithContext returns the filesystem running the GridFS operations with ctx
+func (r *Filesystem) WithContext(ctx context.Context) filesystem.Driver {
+	return &Filesystem{
+		gridfs: r.gridfs.WithContext(ctx),
+		url:    r.url,
+	}
+}
+
+func (r *Filesystem) Url(file string) string {
+	return strings.TrimSuffix(r.url, "/") + "/" + r.Path(file)
+}
+
+// put uploads a new revision of the file then removes the previous ones
+func (r *Filesystem) put(file string, content []byte) error {
+	previous, err := r.revisions(file)
+	if err != nil {
+		return err
+	}
+
+	metadata := bson.M{"content_type": http.DetectContentType(content)}
+	if _, err := r.gridfs.Upload(r.Path(file), bytes.NewReader(content), metadata); err != nil {
+		return err
+	}
+
+	for _, revision := range previous {
+		if err := r.gridfs.Delete(revision.ID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *Filesystem) deleteRevisions(file string) error {
+	revisions, err := r.revisions(file)
+	if err != nil {
+		return err
+	}
+
+	for _, revision := range revisions {
+		if err := r.gridfs.Delete(revision.ID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// revisions returns every revision of the file, the latest first
+func (r *Filesystem) revisions(file string) ([]gridfs.File, error) {
+	return r.gridfs.Find(bson.M{"filename": r.Path(file)}, options.GridFSFind().SetSort(bson.D{{Key: "uploadDate", Value: -1}}))
+}
+
+func (r *Filesystem) latest(file string) (*gridfs.File, error) {
+	revisions, err := r.revisions(file)
+	if err != nil {
+		return nil, err
+	}
+	if len(revisions) == 0 {
+		return nil, fmt.Errorf("file %s does not exist", file)
+	}
+
+	return &revisions[0], nil
+}
+
+// names returns the distinct file names under path, relative to it
+func (r *Filesystem) names(path string) ([]string, error) {
+	files, err := r.gridfs.Find(r.prefixFilter(path))
+	if err != nil {
+		return nil, err
+	}
+
+	prefix := r.prefix(path)
+	var names []string
+	seen := make(map[string]bool)
+	for _, file := range files {
+		name := strings.TrimPrefix(file.Name, prefix)
+		if name != "" && !seen[name] {
+			seen[name] = true
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	return names, nil
+}
+
+func (r *Filesystem) prefix(directory string) string {
+	prefix := r.Path(directory)
+	if prefix == "" {
+		return ""
+	}
+
+	return prefix + "/"
+}
+
+func (r *Filesystem) prefixFilter(directory string) bson.M {
+	return bson.M{"filename": bson.M{"$regex": "^" + regexp.QuoteMeta(r.prefix(directory))}}
+}
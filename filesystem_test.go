@@ -0,0 +1,162 @@
+package mongodb
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/gridfs"
+
+	mockscontracts "github.com/portofolio-mager/goravel-mongodb/mocks"
+)
+
+type FilesystemTestSuite struct {
+	suite.Suite
+	gridfs     *mockscontracts.GridFS
+	filesystem *Filesystem
+}
+
+func TestFilesystemTestSuite(t *testing.T) {
+	suite.Run(t, new(FilesystemTestSuite))
+}
+
+func (s *FilesystemTestSuite) SetupTest() {
+	s.gridfs = mockscontracts.NewGridFS(s.T())
+	s.filesystem = NewFilesystem(s.gridfs, "https://goravel.dev/storage/")
+}
+
+func (s *FilesystemTestSuite) TestPath() {
+	s.Equal("avatars/1.png", s.filesystem.Path("avatars/1.png"))
+	s.Equal("avatars/1.png", s.filesystem.Path("/avatars/1.png"))
+	s.Equal("avatars/1.png", s.filesystem.Path("./avatars/../avatars/1.png"))
+	s.Equal("", s.filesystem.Path("."))
+	s.Equal("https://goravel.dev/storage/avatars/1.png", s.filesystem.Url("/avatars/1.png"))
+}
+
+func (s *FilesystemTestSuite) TestListing() {
+	files := []gridfs.File{
+		{Name: "Files/1.txt"},
+		{Name: "Files/2.txt"},
+		{Name: "Files/2.txt"},
+		{Name: "Files/3/3.txt"},
+		{Name: "Files/3/4/4.txt"},
+	}
+	s.gridfs.EXPECT().Find(bson.M{"filename": bson.M{"$regex": "^Files/"}}).Return(files, nil).Times(4)
+
+	names, err := s.filesystem.Files("./Files/")
+	s.NoError(err)
+	s.Equal([]string{"1.txt", "2.txt"}, names)
+
+	names, err = s.filesystem.AllFiles("/Files")
+	s.NoError(err)
+	s.Equal([]string{"1.txt", "2.txt", "3/3.txt", "3/4/4.txt"}, names)
+
+	directories, err := s.filesystem.Directories("Files")
+	s.NoError(err)
+	s.Equal([]string{"3/"}, directories)
+
+	directories, err = s.filesystem.AllDirectories("Files")
+	s.NoError(err)
+	s.Equal([]string{"3/", "3/4/"}, directories)
+}
+
+func (s *FilesystemTestSuite) TestPut() {
+	oldID := primitive.NewObjectID()
+	s.gridfs.EXPECT().Find(bson.M{"filename": "Put/1.txt"}, mock.Anything).Return([]gridfs.File{{ID: oldID, Name: "Put/1.txt"}}, nil).Once()
+	s.gridfs.EXPECT().Upload("Put/1.txt", mock.Anything, bson.M{"content_type": "text/plain; charset=utf-8"}).
+		RunAndReturn(func(name string, source io.Reader, metadata interface{}) (primitive.ObjectID, error) {
+			content, err := io.ReadAll(source)
+			s.NoError(err)
+			s.Equal("Goravel", string(content))
+			return primitive.NewObjectID(), nil
+		}).Once()
+	s.gridfs.EXPECT().Delete(oldID).Return(nil).Once()
+
+	s.NoError(s.filesystem.Put("/Put/1.txt", "Goravel"))
+}
+
+func (s *FilesystemTestSuite) TestGet() {
+	s.gridfs.EXPECT().DownloadByName("Get/1.txt", mock.Anything).RunAndReturn(func(name string, destination io.Writer) (int64, error) {
+		n, err := destination.Write([]byte("Goravel"))
+		return int64(n), err
+	}).Once()
+
+	content, err := s.filesystem.Get("Get/1.txt")
+	s.NoError(err)
+	s.Equal("Goravel", content)
+}
+
+func (s *FilesystemTestSuite) TestMetadata() {
+	uploadDate := time.Now().UTC().Truncate(time.Millisecond)
+	metadata, err := bson.Marshal(bson.M{"content_type": "image/png"})
+	s.Require().NoError(err)
+
+	s.gridfs.EXPECT().Find(bson.M{"filename": "avatars/1.png"}, mock.Anything).Return([]gridfs.File{
+		{ID: primitive.NewObjectID(), Name: "avatars/1.png", Length: 7, UploadDate: uploadDate, Metadata: metadata},
+	}, nil).Times(4)
+
+	s.True(s.filesystem.Exists("avatars/1.png"))
+
+	size, err := s.filesystem.Size("avatars/1.png")
+	s.NoError(err)
+	s.Equal(int64(7), size)
+
+	lastModified, err := s.filesystem.LastModified("avatars/1.png")
+	s.NoError(err)
+	s.Equal(uploadDate, lastModified)
+
+	mimeType, err := s.filesystem.MimeType("avatars/1.png")
+	s.NoError(err)
+	s.Equal("image/png", mimeType)
+}
+
+func (s *FilesystemTestSuite) TestDelete() {
+	id := primitive.NewObjectID()
+	s.gridfs.EXPECT().Find(bson.M{"filename": "Delete/1.txt"}, mock.Anything).Return([]gridfs.File{{ID: id}}, nil).Twice()
+	s.gridfs.EXPECT().Delete(id).Return(nil).Once()
+	s.NoError(s.filesystem.Delete("Delete/1.txt"))
+
+	s.gridfs.EXPECT().Find(bson.M{"filename": "Delete/2.txt"}, mock.Anything).Return(nil, nil).Twice()
+	s.True(s.filesystem.Missing("Delete/2.txt"))
+	s.EqualError(s.filesystem.Delete("Delete/2.txt"), "file Delete/2.txt does not exist")
+}
+
+func (s *FilesystemTestSuite) TestCopy() {
+	s.gridfs.EXPECT().DownloadByName("Copy/1.txt", mock.Anything).RunAndReturn(func(name string, destination io.Writer) (int64, error) {
+		n, err := io.Copy(destination, bytes.NewBufferString("Goravel"))
+		return n, err
+	}).Once()
+	s.gridfs.EXPECT().Find(bson.M{"filename": "Copy1/1.txt"}, mock.Anything).Return(nil, nil).Once()
+	s.gridfs.EXPECT().Upload("Copy1/1.txt", mock.Anything, mock.Anything).Return(primitive.NewObjectID(), nil).Once()
+
+	s.NoError(s.filesystem.Copy("Copy/1.txt", "Copy1/1.txt"))
+}
+
+func (s *FilesystemTestSuite) TestDeleteDirectory() {
+	id := primitive.NewObjectID()
+	s.gridfs.EXPECT().Find(bson.M{"filename": bson.M{"$regex": "^Delete/"}}).Return([]gridfs.File{{ID: id}}, nil).Once()
+	s.gridfs.EXPECT().Delete(id).Return(nil).Once()
+	s.NoError(s.filesystem.DeleteDirectory("/Delete/"))
+
+	// The root would match every file
+	s.ErrorIs(s.filesystem.DeleteDirectory(""), RootDirectoryDeletion)
+	s.ErrorIs(s.filesystem.DeleteDirectory("/"), RootDirectoryDeletion)
+	s.ErrorIs(s.filesystem.DeleteDirectory("."), RootDirectoryDeletion)
+}
+
+func (s *FilesystemTestSuite) TestWithContext() {
+	ctx := context.WithValue(context.Background(), testContextKey{}, "request")
+	withContext := mockscontracts.NewGridFS(s.T())
+	s.gridfs.EXPECT().WithContext(ctx).Return(withContext).Once()
+	withContext.EXPECT().Find(bson.M{"filename": "avatars/1.png"}, mock.Anything).Return(nil, nil).Once()
+
+	s.True(s.filesystem.WithContext(ctx).Missing("avatars/1.png"))
+}
+
+type testContextKey struct{}
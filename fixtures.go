@@ -0,0 +1,188 @@
+package mongodb
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// FixtureExtension is the extension of the fixture files, the file name
+// without it is the collection name
+const FixtureExtension = ".json"
+
+// LoadFixtures truncates the collections of the <collection>.json files found
+// in dir and inserts their documents. The files hold MongoDB Extended JSON,
+// canonical or relaxed, either as an array of documents or one document per
+// line as written by mongoexport.
+func (d *Database) LoadFixtures(dir string) error {
+	files, err := filepath.Glob(filepath.Join(dir, "*"+FixtureExtension))
+	if err != nil {
+		return err
+	}
+	sort.Strings(files)
+
+	for _, file := range files {
+		if err := d.loadFixture(file); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// loadFixture truncates the collection of the file and inserts its documents
+func (d *Database) loadFixture(file string) error {
+	documents, err := ReadFixture(file)
+	if err != nil {
+		return fmt.Errorf("failed to read fixture %s: %w", file, err)
+	}
+
+	ctx, cancel := operationContext(d.ctx, 30*time.Second)
+	defer cancel()
+
+	name := strings.TrimSuffix(filepath.Base(file), FixtureExtension)
+	collection := d.database.Collection(name)
+	if _, err := collection.DeleteMany(ctx, bson.M{}); err != nil {
+		return fmt.Errorf("failed to truncate collection %s: %w", name, err)
+	}
+	if len(documents) == 0 {
+		return nil
+	}
+	if _, err := collection.InsertMany(ctx, documents); err != nil {
+		return fmt.Errorf("failed to load fixture %s: %w", file, err)
+	}
+
+	return nil
+}
+
+// DumpFixtures writes the documents of the collections to <collection>.json
+// files in dir as canonical Extended JSON, every collection is dumped when
+// none is given
+func (d *Database) DumpFixtures(dir string, collections ...string) error {
+	if len(collections) == 0 {
+		// The views have no documents of their own to dump
+		names, err := d.collectionNames(bson.M{"type": bson.M{"$ne": "view"}})
+		if err != nil {
+			return err
+		}
+		for _, name := range names {
+			if !strings.HasPrefix(name, "system.") {
+				collections = append(collections, name)
+			}
+		}
+	}
+
+	if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+		return err
+	}
+
+	for _, name := range collections {
+		if err := d.dumpCollection(dir, name); err != nil {
+			return fmt.Errorf("failed to dump collection %s: %w", name, err)
+		}
+	}
+
+	return nil
+}
+
+// dumpCollection writes the documents of the collection to its file in dir
+func (d *Database) dumpCollection(dir, name string) error {
+	ctx, cancel := operationContext(d.ctx, 30*time.Second)
+	defer cancel()
+
+	cursor, err := d.database.Collection(name).Find(ctx, bson.M{}, options.Find().SetSort(bson.D{{Key: "_id", Value: 1}}))
+	if err != nil {
+		return err
+	}
+
+	var documents []bson.Raw
+	if err := cursor.All(ctx, &documents); err != nil {
+		return err
+	}
+
+	return WriteFixture(filepath.Join(dir, name+FixtureExtension), documents)
+}
+
+// ReadFixture parses a fixture file into documents
+func ReadFixture(file string) ([]interface{}, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+
+	var raws []json.RawMessage
+	data = bytes.TrimSpace(data)
+	if bytes.HasPrefix(data, []byte("[")) {
+		if err := json.Unmarshal(data, &raws); err != nil {
+			return nil, err
+		}
+	} else {
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		for {
+			var raw json.RawMessage
+			if err := decoder.Decode(&raw); err != nil {
+				if errors.Is(err, io.EOF) {
+					break
+				}
+				return nil, err
+			}
+			raws = append(raws, raw)
+		}
+	}
+
+	documents := make([]interface{}, len(raws))
+	for i, raw := range raws {
+		var document bson.D
+		// Relaxed parsing accepts canonical Extended JSON as well
+		if err := bson.UnmarshalExtJSON(raw, false, &document); err != nil {
+			return nil, fmt.Errorf("document %d: %w", i, err)
+		}
+		documents[i] = document
+	}
+
+	return documents, nil
+}
+
+// WriteFixture writes the documents to a fixture file
+func WriteFixture(file string, documents []bson.Raw) error {
+	data, err := marshalFixture(documents)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(file, data, 0644)
+}
+
+// marshalFixture formats the documents as an array of canonical Extended
+// JSON, which keeps the types of the numbers
+func marshalFixture(documents []bson.Raw) ([]byte, error) {
+	var buffer bytes.Buffer
+	buffer.WriteString("[")
+	for i, document := range documents {
+		data, err := bson.MarshalExtJSONIndent(document, true, false, "  ", "  ")
+		if err != nil {
+			return nil, err
+		}
+		if i > 0 {
+			buffer.WriteString(",")
+		}
+		buffer.WriteString("\n  ")
+		buffer.Write(data)
+	}
+	if len(documents) > 0 {
+		buffer.WriteString("\n")
+	}
+	buffer.WriteString("]\n")
+
+	return buffer.Bytes(), nil
+}
@@ -0,0 +1,152 @@
+package mongodb
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type FixturesTestSuite struct {
+	suite.Suite
+	dir string
+}
+
+func TestFixturesTestSuite(t *testing.T) {
+	suite.Run(t, new(FixturesTestSuite))
+}
+
+func (s *FixturesTestSuite) SetupTest() {
+	s.dir = s.T().TempDir()
+}
+
+func (s *FixturesTestSuite) TestReadFixture() {
+	id, err := primitive.ObjectIDFromHex("65f1a2b3c4d5e6f708192a3b")
+	s.Require().NoError(err)
+	createdAt := primitive.NewDateTimeFromTime(time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC))
+
+	tests := []struct {
+		name     string
+		content  string
+		expected []interface{}
+	}{
+		{
+			name: "canonical array",
+			content: `[
+  {"_id": {"$oid": "65f1a2b3c4d5e6f708192a3b"}, "age": {"$numberInt": "18"}, "views": {"$numberLong": "1"}, "created_at": {"$date": {"$numberLong": "1709251200000"}}}
+]`,
+			expected: []interface{}{
+				bson.D{{Key: "_id", Value: id}, {Key: "age", Value: int32(18)}, {Key: "views", Value: int64(1)}, {Key: "created_at", Value: createdAt}},
+			},
+		},
+		{
+			name:    "relaxed array",
+			content: `[{"_id": {"$oid": "65f1a2b3c4d5e6f708192a3b"}, "name": "Goravel", "score": 1.5, "created_at": {"$date": "2024-03-01T00:00:00Z"}}, {"name": "MongoDB"}]`,
+			expected: []interface{}{
+				bson.D{{Key: "_id", Value: id}, {Key: "name", Value: "Goravel"}, {Key: "score", Value: 1.5}, {Key: "created_at", Value: createdAt}},
+				bson.D{{Key: "name", Value: "MongoDB"}},
+			},
+		},
+		{
+			name:    "one document per line",
+			content: "{\"name\": \"Goravel\"}\n{\"name\": \"MongoDB\"}\n",
+			expected: []interface{}{
+				bson.D{{Key: "name", Value: "Goravel"}},
+				bson.D{{Key: "name", Value: "MongoDB"}},
+			},
+		},
+		{
+			name:     "empty array",
+			content:  "[]",
+			expected: []interface{}{},
+		},
+		{
+			name:     "empty file",
+			content:  "",
+			expected: []interface{}{},
+		},
+	}
+
+	for _, test := range tests {
+		s.Run(test.name, func() {
+			file := filepath.Join(s.dir, "users.json")
+			s.Require().NoError(os.WriteFile(file, []byte(test.content), 0644))
+
+			documents, err := ReadFixture(file)
+			s.NoError(err)
+			s.Equal(test.expected, documents)
+		})
+	}
+}
+
+func (s *FixturesTestSuite) TestReadFixtureError() {
+	file := filepath.Join(s.dir, "users.json")
+	s.Require().NoError(os.WriteFile(file, []byte(`[{"_id": {"$oid": "invalid"}}]`), 0644))
+
+	_, err := ReadFixture(file)
+	s.ErrorContains(err, "document 0:")
+
+	_, err = ReadFixture(filepath.Join(s.dir, "posts.json"))
+	s.ErrorIs(err, os.ErrNotExist)
+}
+
+func (s *FixturesTestSuite) TestMarshalFixture() {
+	data, err := marshalFixture(nil)
+	s.NoError(err)
+	s.Equal("[]\n", string(data))
+
+	id := primitive.NewObjectID()
+	createdAt := primitive.NewDateTimeFromTime(time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC))
+	var documents []bson.Raw
+	for _, document := range []bson.D{
+		{{Key: "_id", Value: id}, {Key: "name", Value: "Goravel"}, {Key: "age", Value: int64(18)}, {Key: "created_at", Value: createdAt}},
+		{{Key: "_id", Value: id}, {Key: "tags", Value: bson.A{"go", "mongodb"}}},
+	} {
+		raw, err := bson.Marshal(document)
+		s.Require().NoError(err)
+		documents = append(documents, raw)
+	}
+
+	data, err = marshalFixture(documents)
+	s.NoError(err)
+	s.Equal(`[
+  {
+    "_id": {
+      "$oid": "`+id.Hex()+`"
+    },
+    "name": "Goravel",
+    "age": {
+      "$numberLong": "18"
+    },
+    "created_at": {
+      "$date": {
+        "$numberLong": "1709251200000"
+      }
+    }
+  },
+  {
+    "_id": {
+      "$oid": "`+id.Hex()+`"
+    },
+    "tags": [
+      "go",
+      "mongodb"
+    ]
+  }
+]
+`, string(data))
+
+	// The dumped fixtures can be loaded again with the types of their numbers
+	file := filepath.Join(s.dir, "users.json")
+	s.Require().NoError(WriteFixture(file, documents))
+	loaded, err := ReadFixture(file)
+	s.NoError(err)
+	s.Equal([]interface{}{
+		bson.D{{Key: "_id", Value: id}, {Key: "name", Value: "Goravel"}, {Key: "age", Value: int64(18)}, {Key: "created_at", Value: createdAt}},
+		bson.D{{Key: "_id", Value: id}, {Key: "tags", Value: bson.A{"go", "mongodb"}}},
+	}, loaded)
+}
@@ -4,13 +4,15 @@ import (
 	sq "github.com/Masterminds/squirrel"
 	"github.com/goravel/framework/contracts/database/driver"
 	"github.com/goravel/framework/contracts/log"
+	"go.mongodb.org/mongo-driver/bson"
 	"gorm.io/gorm/clause"
 )
 
 var _ driver.Grammar = &Grammar{}
 
-// Grammar is a minimal implementation to satisfy Goravel's driver interface
-// MongoDB operations bypass this grammar and use native MongoDB queries
+// Grammar compiles the schema operations of Goravel's schema facade to MongoDB
+// commands, written as Extended JSON the dialector's connection runs.
+// The SQL specific operations compile to nothing
 type Grammar struct {
 	log log.Log
 }
@@ -43,7 +45,12 @@ func (g *Grammar) CompileComment(blueprint driver.Blueprint, command *driver.Com
 }
 
 func (g *Grammar) CompileCreate(blueprint driver.Blueprint) string {
-	return ""
+	command := bson.D{{Key: "create", Value: blueprint.GetTableName()}}
+	if validator := columnsValidator(blueprint.GetAddedColumns()); validator != nil {
+		command = append(command, bson.E{Key: "validator", Value: validator})
+	}
+
+	return g.command(command)
 }
 
 func (g *Grammar) CompileDisableWriteableSchema() string {
@@ -51,7 +58,7 @@ func (g *Grammar) CompileDisableWriteableSchema() string {
 }
 
 func (g *Grammar) CompileDrop(blueprint driver.Blueprint) string {
-	return ""
+	return g.command(bson.D{{Key: "drop", Value: blueprint.GetTableName()}})
 }
 
 func (g *Grammar) CompileDropAllDomains(domains []string) string {
@@ -59,7 +66,12 @@ func (g *Grammar) CompileDropAllDomains(domains []string) string {
 }
 
 func (g *Grammar) CompileDropAllTables(database string, tables []driver.Table) []string {
-	return nil
+	var statements []string
+	for _, table := range tables {
+		statements = append(statements, g.command(bson.D{{Key: "drop", Value: table.Name}}))
+	}
+
+	return statements
 }
 
 func (g *Grammar) CompileDropAllTypes(database string, types []driver.Type) []string {
@@ -79,15 +91,19 @@ func (g *Grammar) CompileDropForeign(blueprint driver.Blueprint, command *driver
 }
 
 func (g *Grammar) CompileDropFullText(blueprint driver.Blueprint, command *driver.Command) string {
-	return ""
+	return g.CompileDropIndex(blueprint, command)
 }
 
 func (g *Grammar) CompileDropIfExists(blueprint driver.Blueprint) string {
-	return ""
+	// Dropping a missing collection succeeds, see mongoConnPool.runCommand
+	return g.CompileDrop(blueprint)
 }
 
 func (g *Grammar) CompileDropIndex(blueprint driver.Blueprint, command *driver.Command) string {
-	return ""
+	return g.command(bson.D{
+		{Key: "dropIndexes", Value: blueprint.GetTableName()},
+		{Key: "index", Value: command.Index},
+	})
 }
 
 func (g *Grammar) CompileDropPrimary(blueprint driver.Blueprint, command *driver.Command) string {
@@ -95,7 +111,7 @@ func (g *Grammar) CompileDropPrimary(blueprint driver.Blueprint, command *driver
 }
 
 func (g *Grammar) CompileDropUnique(blueprint driver.Blueprint, command *driver.Command) string {
-	return ""
+	return g.CompileDropIndex(blueprint, command)
 }
 
 func (g *Grammar) CompileEnableWriteableSchema() string {
@@ -111,15 +127,15 @@ func (g *Grammar) CompileForeignKeys(database, table string) string {
 }
 
 func (g *Grammar) CompileFullText(blueprint driver.Blueprint, command *driver.Command) string {
-	return ""
+	return g.createIndex(blueprint, command, "text", false)
 }
 
 func (g *Grammar) CompileIndex(blueprint driver.Blueprint, command *driver.Command) string {
-	return ""
+	return g.createIndex(blueprint, command, 1, false)
 }
 
 func (g *Grammar) CompileIndexes(database, table string) (string, error) {
-	return "", nil
+	return g.command(bson.D{{Key: "listIndexes", Value: table}}), nil
 }
 
 func (g *Grammar) CompileJsonColumnsUpdate(values map[string]any) (map[string]any, error) {
@@ -195,7 +211,11 @@ func (g *Grammar) CompileSharedLockForGorm() clause.Expression {
 }
 
 func (g *Grammar) CompileTables(database string) string {
-	return ""
+	return g.command(bson.D{
+		{Key: "listCollections", Value: 1},
+		{Key: "filter", Value: bson.D{{Key: "type", Value: "collection"}}},
+		{Key: "nameOnly", Value: true},
+	})
 }
 
 func (g *Grammar) CompileTableComment(blueprint driver.Blueprint, command *driver.Command) string {
@@ -207,7 +227,7 @@ func (g *Grammar) CompileTypes() string {
 }
 
 func (g *Grammar) CompileUnique(blueprint driver.Blueprint, command *driver.Command) string {
-	return ""
+	return g.createIndex(blueprint, command, 1, true)
 }
 
 func (g *Grammar) CompileVersion() string {
@@ -226,6 +246,113 @@ func (g *Grammar) GetModifiers() []func(driver.Blueprint, driver.ColumnDefinitio
 	return nil
 }
 
+// createIndex compiles a createIndexes command for the columns of the
+// command, in order
+func (g *Grammar) createIndex(blueprint driver.Blueprint, command *driver.Command, kind interface{}, unique bool) string {
+	key := bson.D{}
+	for _, column := range command.Columns {
+		key = append(key, bson.E{Key: column, Value: kind})
+	}
+
+	index := bson.D{
+		{Key: "key", Value: key},
+		{Key: "name", Value: command.Index},
+	}
+	if unique {
+		index = append(index, bson.E{Key: "unique", Value: true})
+	}
+	if command.Language != "" {
+		index = append(index, bson.E{Key: "default_language", Value: command.Language})
+	}
+
+	return g.command(bson.D{
+		{Key: "createIndexes", Value: blueprint.GetTableName()},
+		{Key: "indexes", Value: bson.A{index}},
+	})
+}
+
+// command returns the MongoDB command as relaxed Extended JSON, the dummy
+// SQL connection runs it instead of treating it as SQL
+func (g *Grammar) command(command bson.D) string {
+	statement, err := bson.MarshalExtJSON(command, false, false)
+	if err != nil {
+		if g.log != nil {
+			g.log.Errorf("failed to compile the MongoDB command %s: %v", command[0].Key, err)
+		}
+		return ""
+	}
+
+	return string(statement)
+}
+
+// columnsValidator returns the $jsonSchema validator of the columns of a
+// Create, nil without columns. The auto increment columns are the _id the
+// dialector generates and are left out
+func columnsValidator(columns []driver.ColumnDefinition) bson.D {
+	properties := bson.D{}
+	required := bson.A{}
+	for _, column := range columns {
+		if column.GetAutoIncrement() {
+			continue
+		}
+
+		property := bson.D{}
+		bsonTypes := columnBSONTypes(column.GetType())
+		if column.GetNullable() {
+			bsonTypes = append(bsonTypes, "null")
+		} else if column.GetDefault() == nil {
+			required = append(required, column.GetName())
+		}
+		if len(bsonTypes) == 1 {
+			property = append(property, bson.E{Key: "bsonType", Value: bsonTypes[0]})
+		} else {
+			property = append(property, bson.E{Key: "bsonType", Value: bsonTypes})
+		}
+		if allowed := column.GetAllowed(); len(allowed) > 0 {
+			property = append(property, bson.E{Key: "enum", Value: bson.A(allowed)})
+		}
+		if comment := column.GetComment(); comment != "" {
+			property = append(property, bson.E{Key: "description", Value: comment})
+		}
+
+		properties = append(properties, bson.E{Key: column.GetName(), Value: property})
+	}
+
+	if len(properties) == 0 {
+		return nil
+	}
+
+	schema := bson.D{
+		{Key: "bsonType", Value: "object"},
+		{Key: "properties", Value: properties},
+	}
+	if len(required) > 0 {
+		schema = append(schema, bson.E{Key: "required", Value: required})
+	}
+
+	return bson.D{{Key: "$jsonSchema", Value: schema}}
+}
+
+// columnBSONTypes maps the column types of the Blueprint to the BSON types
+// the driver stores their Go values as
+func columnBSONTypes(columnType string) bson.A {
+	switch columnType {
+	case "bigInteger", "integer", "mediumInteger", "smallInteger", "tinyInteger":
+		return bson.A{"int", "long"}
+	case "boolean":
+		return bson.A{"bool"}
+	case "date", "dateTime", "dateTimeTz", "timestamp", "timestampTz":
+		return bson.A{"date"}
+	case "decimal", "double", "float":
+		return bson.A{"double", "decimal", "int", "long"}
+	case "json", "jsonb":
+		return bson.A{"object", "array"}
+	default:
+		// char, string, text, enum, time, uuid, ...
+		return bson.A{"string"}
+	}
+}
+
 // Type methods - not used in MongoDB but required for interface
 func (g *Grammar) TypeBigInteger(column driver.ColumnDefinition) string    { return "" }
 func (g *Grammar) TypeBoolean(column driver.ColumnDefinition) string       { return "" }
@@ -45,12 +45,54 @@ func (s *GrammarSuite) TestCompileAdd() {
 }
 
 func (s *GrammarSuite) TestCompileCreate() {
-	// MongoDB doesn't use SQL CREATE TABLE commands
-	// Collections are created automatically when documents are inserted
-	mockBlueprint := mocksdriver.NewBlueprint(s.T())
+	s.Run("without columns", func() {
+		mockBlueprint := mocksdriver.NewBlueprint(s.T())
+		mockBlueprint.EXPECT().GetTableName().Return("users").Once()
+		mockBlueprint.EXPECT().GetAddedColumns().Return(nil).Once()
 
-	result := s.grammar.CompileCreate(mockBlueprint)
-	s.Equal("", result)
+		s.Equal(`{"create":"users"}`, s.grammar.CompileCreate(mockBlueprint))
+	})
+
+	s.Run("with a validator", func() {
+		mockID := mocksdriver.NewColumnDefinition(s.T())
+		mockID.EXPECT().GetAutoIncrement().Return(true).Once()
+
+		mockName := mocksdriver.NewColumnDefinition(s.T())
+		mockName.EXPECT().GetAutoIncrement().Return(false).Once()
+		mockName.EXPECT().GetType().Return("string").Once()
+		mockName.EXPECT().GetNullable().Return(false).Once()
+		mockName.EXPECT().GetDefault().Return(nil).Once()
+		mockName.EXPECT().GetName().Return("name").Twice()
+		mockName.EXPECT().GetAllowed().Return(nil).Once()
+		mockName.EXPECT().GetComment().Return("The full name").Once()
+
+		mockRole := mocksdriver.NewColumnDefinition(s.T())
+		mockRole.EXPECT().GetAutoIncrement().Return(false).Once()
+		mockRole.EXPECT().GetType().Return("enum").Once()
+		mockRole.EXPECT().GetNullable().Return(false).Once()
+		mockRole.EXPECT().GetDefault().Return("user").Once()
+		mockRole.EXPECT().GetName().Return("role").Once()
+		mockRole.EXPECT().GetAllowed().Return([]any{"admin", "user"}).Once()
+		mockRole.EXPECT().GetComment().Return("").Once()
+
+		mockAge := mocksdriver.NewColumnDefinition(s.T())
+		mockAge.EXPECT().GetAutoIncrement().Return(false).Once()
+		mockAge.EXPECT().GetType().Return("integer").Once()
+		mockAge.EXPECT().GetNullable().Return(true).Once()
+		mockAge.EXPECT().GetName().Return("age").Once()
+		mockAge.EXPECT().GetAllowed().Return(nil).Once()
+		mockAge.EXPECT().GetComment().Return("").Once()
+
+		mockBlueprint := mocksdriver.NewBlueprint(s.T())
+		mockBlueprint.EXPECT().GetTableName().Return("users").Once()
+		mockBlueprint.EXPECT().GetAddedColumns().Return([]contractsdriver.ColumnDefinition{mockID, mockName, mockRole, mockAge}).Once()
+
+		s.Equal(`{"create":"users","validator":{"$jsonSchema":{"bsonType":"object","properties":{`+
+			`"name":{"bsonType":"string","description":"The full name"},`+
+			`"role":{"bsonType":"string","enum":["admin","user"]},`+
+			`"age":{"bsonType":["int","long","null"]}},`+
+			`"required":["name"]}}}`, s.grammar.CompileCreate(mockBlueprint))
+	})
 }
 
 func (s *GrammarSuite) TestCompileDropColumn() {
@@ -66,23 +108,60 @@ func (s *GrammarSuite) TestCompileDropColumn() {
 }
 
 func (s *GrammarSuite) TestCompileDropIfExists() {
-	// MongoDB doesn't use SQL DROP TABLE commands
 	mockBlueprint := mocksdriver.NewBlueprint(s.T())
+	mockBlueprint.EXPECT().GetTableName().Return("users").Once()
 
 	result := s.grammar.CompileDropIfExists(mockBlueprint)
-	s.Equal("", result)
+	s.Equal(`{"drop":"users"}`, result)
+}
+
+func (s *GrammarSuite) TestCompileDropAllTables() {
+	result := s.grammar.CompileDropAllTables("goravel", []contractsdriver.Table{{Name: "users"}, {Name: "posts"}})
+	s.Equal([]string{`{"drop":"users"}`, `{"drop":"posts"}`}, result)
+}
+
+func (s *GrammarSuite) TestCompileDropIndex() {
+	mockBlueprint := mocksdriver.NewBlueprint(s.T())
+	mockBlueprint.EXPECT().GetTableName().Return("users").Once()
+
+	result := s.grammar.CompileDropUnique(mockBlueprint, &contractsdriver.Command{Index: "users_email_unique"})
+	s.Equal(`{"dropIndexes":"users","index":"users_email_unique"}`, result)
 }
 
 func (s *GrammarSuite) TestCompileIndex() {
-	// MongoDB handles indexes differently than SQL
 	mockBlueprint := mocksdriver.NewBlueprint(s.T())
+	mockBlueprint.EXPECT().GetTableName().Return("users").Once()
 	command := &contractsdriver.Command{
-		Index:   "users",
+		Index:   "users_role_id_permission_id_index",
 		Columns: []string{"role_id", "permission_id"},
 	}
 
 	result := s.grammar.CompileIndex(mockBlueprint, command)
-	s.Equal("", result)
+	s.Equal(`{"createIndexes":"users","indexes":[{"key":{"role_id":1,"permission_id":1},"name":"users_role_id_permission_id_index"}]}`, result)
+}
+
+func (s *GrammarSuite) TestCompileUnique() {
+	mockBlueprint := mocksdriver.NewBlueprint(s.T())
+	mockBlueprint.EXPECT().GetTableName().Return("users").Once()
+
+	result := s.grammar.CompileUnique(mockBlueprint, &contractsdriver.Command{Index: "users_email_unique", Columns: []string{"email"}})
+	s.Equal(`{"createIndexes":"users","indexes":[{"key":{"email":1},"name":"users_email_unique","unique":true}]}`, result)
+}
+
+func (s *GrammarSuite) TestCompileFullText() {
+	mockBlueprint := mocksdriver.NewBlueprint(s.T())
+	mockBlueprint.EXPECT().GetTableName().Return("posts").Once()
+
+	result := s.grammar.CompileFullText(mockBlueprint, &contractsdriver.Command{Index: "posts_title_fulltext", Columns: []string{"title"}, Language: "english"})
+	s.Equal(`{"createIndexes":"posts","indexes":[{"key":{"title":"text"},"name":"posts_title_fulltext","default_language":"english"}]}`, result)
+}
+
+func (s *GrammarSuite) TestCompileTablesAndIndexes() {
+	s.Equal(`{"listCollections":1,"filter":{"type":"collection"},"nameOnly":true}`, s.grammar.CompileTables("goravel"))
+
+	result, err := s.grammar.CompileIndexes("goravel", "users")
+	s.NoError(err)
+	s.Equal(`{"listIndexes":"users"}`, result)
 }
 
 func (s *GrammarSuite) TestCompileJsonColumnsUpdate() {
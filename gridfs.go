@@ -0,0 +1,157 @@
+package mongodb
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/gridfs"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/portofolio-mager/goravel-mongodb/contracts"
+)
+
+var _ contracts.GridFS = &GridFS{}
+
+// DefaultBucket is the GridFS bucket used when no name is given
+const DefaultBucket = "fs"
+
+type GridFS struct {
+	bucket   *gridfs.Bucket
+	database *mongo.Database
+	name     string
+	// ctx is the parent of the contexts of the operations, set by WithContext
+	ctx context.Context
+}
+
+func NewGridFS(database *mongo.Database, name string) (*GridFS, error) {
+	if name == "" {
+		name = DefaultBucket
+	}
+
+	bucket, err := gridfs.NewBucket(database, options.GridFSBucket().SetName(name))
+	if err != nil {
+		return nil, err
+	}
+
+	return &GridFS{
+		bucket:   bucket,
+		database: database,
+		name:     name,
+	}, nil
+}
+
+func (g *GridFS) Native() *gridfs.Bucket {
+	return g.bucket
+}
+
+// Upload stores the content of source as a new file, metadata is optional
+func (g *GridFS) Upload(name string, source io.Reader, metadata interface{}) (primitive.ObjectID, error) {
+	uploadOpts := options.GridFSUpload()
+	if metadata != nil {
+		uploadOpts.SetMetadata(metadata)
+	}
+
+	return g.bucket.UploadFromStream(name, source, uploadOpts)
+}
+
+func (g *GridFS) Download(id interface{}, destination io.Writer) (int64, error) {
+	return g.bucket.DownloadToStream(id, destination)
+}
+
+// DownloadByName writes the latest revision of the file to destination
+func (g *GridFS) DownloadByName(name string, destination io.Writer) (int64, error) {
+	return g.bucket.DownloadToStreamByName(name, destination)
+}
+
+func (g *GridFS) OpenDownloadStream(id interface{}) (*gridfs.DownloadStream, error) {
+	return g.bucket.OpenDownloadStream(id)
+}
+
+// OpenDownloadStreamByName opens the latest revision of the file
+func (g *GridFS) OpenDownloadStreamByName(name string) (*gridfs.DownloadStream, error) {
+	return g.bucket.OpenDownloadStreamByName(name)
+}
+
+func (g *GridFS) Delete(id interface{}) error {
+	ctx, cancel := g.context()
+	defer cancel()
+
+	return g.bucket.DeleteContext(ctx, id)
+}
+
+func (g *GridFS) Find(filter interface{}, opts ...interface{}) ([]gridfs.File, error) {
+	ctx, cancel := g.context()
+	defer cancel()
+
+	var findOpts *options.GridFSFindOptions
+	if len(opts) > 0 {
+		if opt, ok := opts[0].(*options.GridFSFindOptions); ok {
+			findOpts = opt
+		}
+	}
+
+	cursor, err := g.bucket.FindContext(ctx, filter, findOpts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var files []gridfs.File
+	if err := cursor.All(ctx, &files); err != nil {
+		return nil, err
+	}
+
+	return files, nil
+}
+
+func (g *GridFS) Rename(id interface{}, name string) error {
+	ctx, cancel := g.context()
+	defer cancel()
+
+	return g.bucket.RenameContext(ctx, id, name)
+}
+
+func (g *GridFS) Drop() error {
+	ctx, cancel := g.context()
+	defer cancel()
+
+	return g.bucket.DropContext(ctx)
+}
+
+func (g *GridFS) Name() string {
+	return g.name
+}
+
+func (g *GridFS) WithContext(ctx context.Context) contracts.GridFS {
+	gridFS := &GridFS{
+		bucket:   g.bucket,
+		database: g.database,
+		name:     g.name,
+		ctx:      ctx,
+	}
+
+	// The uploads and downloads only take deadlines, they are set on a bucket
+	// of its own so the other operations are not bounded by them
+	if deadline, ok := ctx.Deadline(); ok && g.database != nil {
+		if bucket, err := gridfs.NewBucket(g.database, options.GridFSBucket().SetName(g.name)); err == nil {
+			_ = bucket.SetReadDeadline(deadline)
+			_ = bucket.SetWriteDeadline(deadline)
+			gridFS.bucket = bucket
+		}
+	}
+
+	return gridFS
+}
+
+// context returns the context of an operation, bounded to 30 seconds
+func (g *GridFS) context() (context.Context, context.CancelFunc) {
+	ctx := g.ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	return context.WithTimeout(ctx, 30*time.Second)
+}
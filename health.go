@@ -0,0 +1,166 @@
+package mongodb
+
+import (
+	"fmt"
+	nethttp "net/http"
+	"sort"
+	"time"
+
+	"github.com/goravel/framework/contracts/config"
+	"github.com/goravel/framework/contracts/database/driver"
+	"github.com/goravel/framework/contracts/http"
+	"go.mongodb.org/mongo-driver/bson"
+
+	"github.com/portofolio-mager/goravel-mongodb/contracts"
+)
+
+// The statuses of a health check
+const (
+	HealthUp   = "up"
+	HealthDown = "down"
+)
+
+// HealthCheck pings the deployment, then describes its topology, server
+// version and connection pool. The failures are reported as down with the
+// error, they are not returned.
+func (m *MongoDB) HealthCheck() contracts.Health {
+	health := contracts.Health{
+		Connection: m.config.Connection(),
+		Status:     HealthDown,
+		Topology:   TopologyUnknown,
+	}
+	client, pool, err := m.connect()
+	if err != nil {
+		health.Error = err.Error()
+		return health
+	}
+
+	ctx, cancel := operationContext(m.ctx, 5*time.Second)
+	defer cancel()
+
+	start := time.Now()
+	if err := client.Ping(ctx, nil); err != nil {
+		health.Error = fmt.Sprintf("failed to ping MongoDB: %v", err)
+		return health
+	}
+	health.Latency = time.Since(start)
+	health.Pool = pool.stats()
+
+	topology, err := topologyOf(client)
+	if err != nil {
+		health.Error = err.Error()
+		return health
+	}
+	health.Topology = topology.Kind
+	health.Primary = topology.Primary
+
+	var buildInfo struct {
+		Version string `bson:"version"`
+	}
+	if err := client.Database("admin").RunCommand(ctx, bson.D{{Key: "buildInfo", Value: 1}}).Decode(&buildInfo); err != nil {
+		health.Error = fmt.Sprintf("failed to get the server version: %v", err)
+		return health
+	}
+	health.ServerVersion = buildInfo.Version
+	health.Status = HealthUp
+
+	return health
+}
+
+// HealthHandler reports the health of the MongoDB connections over HTTP, for
+// the readiness probes. The facades.HealthHandler checks every connection,
+// a handler checking some of them is registered with:
+//
+//	facades.Route().Get("/health/orders", mongodb.NewHealthHandler(facades.Config(), mongodbfacades.MongoDB, "orders").Handle)
+type HealthHandler struct {
+	config      config.Config
+	client      func(connection string) (contracts.Client, error)
+	connections []string
+}
+
+// NewHealthHandler checks the given connections, or every connection of
+// database.connections using this driver when none is given
+func NewHealthHandler(config config.Config, client func(connection string) (contracts.Client, error), connections ...string) *HealthHandler {
+	return &HealthHandler{
+		config:      config,
+		client:      client,
+		connections: connections,
+	}
+}
+
+// Connections returns the names of the checked connections, sorted. The
+// connections use this driver when their driver is mongodb or their via
+// returns it.
+func (r *HealthHandler) Connections() []string {
+	if len(r.connections) > 0 {
+		return r.connections
+	}
+
+	connections, _ := r.config.Get("database.connections").(map[string]any)
+
+	var names []string
+	for name, connection := range connections {
+		settings, _ := connection.(map[string]any)
+		if settings["driver"] == Name {
+			names = append(names, name)
+			continue
+		}
+
+		via, ok := settings["via"].(func() (driver.Driver, error))
+		if !ok {
+			continue
+		}
+		if instance, err := via(); err == nil {
+			if _, ok := instance.(*MongoDB); ok {
+				names = append(names, name)
+			}
+		}
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// Check runs the health checks of the connections, the report is up when
+// every connection is up
+func (r *HealthHandler) Check() contracts.HealthReport {
+	report := contracts.HealthReport{
+		Status:      HealthUp,
+		Connections: []contracts.Health{},
+	}
+
+	for _, connection := range r.Connections() {
+		var health contracts.Health
+		if client, err := r.client(connection); err != nil {
+			health = contracts.Health{
+				Connection: connection,
+				Status:     HealthDown,
+				Topology:   TopologyUnknown,
+				Error:      err.Error(),
+			}
+		} else {
+			health = client.HealthCheck()
+			health.Connection = connection
+		}
+
+		if health.Status != HealthUp {
+			report.Status = HealthDown
+		}
+		report.Connections = append(report.Connections, health)
+	}
+
+	return report
+}
+
+// Handle responds with the report, with 503 Service Unavailable when a
+// connection is down
+func (r *HealthHandler) Handle(ctx http.Context) http.Response {
+	report := r.Check()
+
+	code := nethttp.StatusOK
+	if report.Status != HealthUp {
+		code = nethttp.StatusServiceUnavailable
+	}
+
+	return ctx.Response().Json(code, report)
+}
@@ -0,0 +1,157 @@
+package mongodb
+
+import (
+	"encoding/json"
+	"errors"
+	nethttp "net/http"
+	"testing"
+	"time"
+
+	"github.com/goravel/framework/contracts/database/driver"
+	mocksconfig "github.com/goravel/framework/mocks/config"
+	mockshttp "github.com/goravel/framework/mocks/http"
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/event"
+
+	"github.com/portofolio-mager/goravel-mongodb/contracts"
+	mockscontracts "github.com/portofolio-mager/goravel-mongodb/mocks"
+)
+
+type HealthTestSuite struct {
+	suite.Suite
+	mockConfig *mocksconfig.Config
+	clients    map[string]contracts.Client
+}
+
+func TestHealthTestSuite(t *testing.T) {
+	suite.Run(t, new(HealthTestSuite))
+}
+
+func (s *HealthTestSuite) SetupTest() {
+	s.mockConfig = mocksconfig.NewConfig(s.T())
+	s.clients = make(map[string]contracts.Client)
+}
+
+func (s *HealthTestSuite) handler(connections ...string) *HealthHandler {
+	return NewHealthHandler(s.mockConfig, func(connection string) (contracts.Client, error) {
+		client, ok := s.clients[connection]
+		if !ok {
+			return nil, errors.New("please register mongodb service provider")
+		}
+		return client, nil
+	}, connections...)
+}
+
+func (s *HealthTestSuite) TestConnections() {
+	s.mockConfig.EXPECT().Get("database.connections").Return(map[string]any{
+		"postgres": map[string]any{"driver": "postgres"},
+		"orders":   map[string]any{"driver": "mongodb"},
+		"mongodb": map[string]any{
+			"via": func() (driver.Driver, error) {
+				return NewMongoDB(s.mockConfig, nil, "mongodb"), nil
+			},
+		},
+		"mysql": map[string]any{
+			"via": func() (driver.Driver, error) {
+				return nil, errors.New("mysql is not installed")
+			},
+		},
+	}).Once()
+
+	s.Equal([]string{"mongodb", "orders"}, s.handler().Connections())
+	s.Equal([]string{"orders"}, s.handler("orders").Connections())
+}
+
+func (s *HealthTestSuite) TestCheck() {
+	up := mockscontracts.NewClient(s.T())
+	up.EXPECT().HealthCheck().Return(contracts.Health{Connection: "mongodb", Status: HealthUp, Topology: TopologyReplicaSet}).Twice()
+	down := mockscontracts.NewClient(s.T())
+	down.EXPECT().HealthCheck().Return(contracts.Health{Connection: "orders", Status: HealthDown, Topology: TopologyUnknown, Error: "failed to ping MongoDB: timeout"}).Once()
+	s.clients["mongodb"] = up
+	s.clients["orders"] = down
+
+	s.Equal(contracts.HealthReport{
+		Status:      HealthUp,
+		Connections: []contracts.Health{{Connection: "mongodb", Status: HealthUp, Topology: TopologyReplicaSet}},
+	}, s.handler("mongodb").Check())
+
+	s.Equal(contracts.HealthReport{
+		Status: HealthDown,
+		Connections: []contracts.Health{
+			{Connection: "mongodb", Status: HealthUp, Topology: TopologyReplicaSet},
+			{Connection: "orders", Status: HealthDown, Topology: TopologyUnknown, Error: "failed to ping MongoDB: timeout"},
+			{Connection: "audit", Status: HealthDown, Topology: TopologyUnknown, Error: "please register mongodb service provider"},
+		},
+	}, s.handler("mongodb", "orders", "audit").Check())
+
+	s.mockConfig.EXPECT().Get("database.connections").Return(nil).Once()
+	s.Equal(contracts.HealthReport{Status: HealthUp, Connections: []contracts.Health{}}, s.handler().Check())
+}
+
+func (s *HealthTestSuite) TestHandle() {
+	tests := []struct {
+		name   string
+		status string
+		code   int
+	}{
+		{name: "Up", status: HealthUp, code: nethttp.StatusOK},
+		{name: "Down", status: HealthDown, code: nethttp.StatusServiceUnavailable},
+	}
+
+	for _, test := range tests {
+		s.Run(test.name, func() {
+			s.SetupTest()
+			client := mockscontracts.NewClient(s.T())
+			client.EXPECT().HealthCheck().Return(contracts.Health{Status: test.status}).Once()
+			s.clients["mongodb"] = client
+
+			mockContext := mockshttp.NewContext(s.T())
+			mockResponse := mockshttp.NewContextResponse(s.T())
+			mockAbortable := mockshttp.NewAbortableResponse(s.T())
+			mockContext.EXPECT().Response().Return(mockResponse).Once()
+			mockResponse.EXPECT().Json(test.code, contracts.HealthReport{
+				Status:      test.status,
+				Connections: []contracts.Health{{Connection: "mongodb", Status: test.status}},
+			}).Return(mockAbortable).Once()
+
+			s.Equal(mockAbortable, s.handler("mongodb").Handle(mockContext))
+		})
+	}
+}
+
+func (s *HealthTestSuite) TestHealthJSON() {
+	data, err := json.Marshal(contracts.Health{
+		Connection:    "mongodb",
+		Status:        HealthUp,
+		Latency:       1500 * time.Microsecond,
+		Topology:      TopologyStandalone,
+		ServerVersion: "7.0.2",
+		Pool:          contracts.PoolStats{Open: 2, InUse: 1, Idle: 1, Max: 100},
+	})
+	s.NoError(err)
+	s.JSONEq(`{
+		"connection": "mongodb",
+		"status": "up",
+		"latency": "1.5ms",
+		"topology": "standalone",
+		"server_version": "7.0.2",
+		"pool": {"open": 2, "in_use": 1, "idle": 1, "max": 100}
+	}`, string(data))
+}
+
+func (s *HealthTestSuite) TestPoolMonitor() {
+	var pool *poolMonitor
+	s.Equal(contracts.PoolStats{}, pool.stats())
+
+	pool = newPoolMonitor(0, "mongodb", nil)
+	monitor := pool.monitor()
+	for _, eventType := range []string{
+		event.ConnectionCreated, event.ConnectionCreated, event.ConnectionCreated, event.ConnectionClosed,
+		event.GetSucceeded, event.GetSucceeded, event.ConnectionReturned, event.GetFailed,
+	} {
+		monitor.Event(&event.PoolEvent{Type: eventType})
+	}
+
+	s.Equal(contracts.PoolStats{Open: 2, InUse: 1, Idle: 1, Max: defaultMaxPoolSize}, pool.stats())
+	s.Equal(uint64(20), newPoolMonitor(20, "mongodb", nil).stats().Max)
+}
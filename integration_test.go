@@ -6,9 +6,12 @@ import (
 	"testing"
 	"time"
 
+	mocksconfig "github.com/goravel/framework/mocks/config"
+	mockslog "github.com/goravel/framework/mocks/log"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
 
 	"github.com/portofolio-mager/goravel-mongodb/contracts"
 )
@@ -469,3 +472,53 @@ func TestPoolDialectorIntegration(t *testing.T) {
 
 	t.Logf("✅ SUCCESS: fullConfigToDialector handles empty URI correctly")
 }
+
+// TestDummyConnectionStatements tests that the SQL statements reaching the dummy
+// driver fail in strict mode and are logged otherwise
+func TestDummyConnectionStatements(t *testing.T) {
+	t.Run("strict", func(t *testing.T) {
+		instance, err := gorm.Open(&Dialector{DSN: "mongodb://localhost:27017/testdb", Strict: true}, &gorm.Config{
+			Logger: logger.Default.LogMode(logger.Silent),
+		})
+		require.NoError(t, err)
+
+		err = instance.Exec("DELETE FROM users").Error
+		assert.ErrorIs(t, err, UnsupportedStatement)
+		assert.ErrorContains(t, err, `"DELETE FROM users"`)
+		assert.ErrorContains(t, err, "facades.MongoDB()")
+
+		var count int
+		assert.ErrorIs(t, instance.Raw("SELECT count(*) FROM users").Scan(&count).Error, UnsupportedStatement)
+
+		db, err := instance.DB()
+		require.NoError(t, err)
+		_, err = db.Exec("UPDATE users SET name = ?", "Goravel")
+		assert.ErrorIs(t, err, UnsupportedStatement)
+
+		err = instance.Exec(`{"dropDatabase": 1}`).Error
+		assert.ErrorIs(t, err, UnsupportedStatement)
+		assert.ErrorContains(t, err, `MongoDB command "{\"dropDatabase\": 1}" is not a schema command`)
+	})
+
+	t.Run("warning", func(t *testing.T) {
+		mockLog := mockslog.NewLog(t)
+		mockLog.EXPECT().Warningf(ignoredStatementWarning, "DELETE FROM users").Once()
+
+		instance, err := gorm.Open(&Dialector{DSN: "mongodb://localhost:27017/testdb", log: mockLog}, &gorm.Config{})
+		require.NoError(t, err)
+
+		assert.NoError(t, instance.Exec("DELETE FROM users").Error)
+	})
+
+	t.Run("strict from config", func(t *testing.T) {
+		mockConfig := mocksconfig.NewConfig(t)
+		mockConfig.EXPECT().GetBool("database.connections.mongodb.strict", false).Return(true).Once()
+
+		mongodb := NewMongoDB(mockConfig, nil, "mongodb")
+		dialector := mongodb.fullConfigToDialector(contracts.FullConfig{
+			Config:     contracts.Config{URI: "mongodb://localhost:27017/testdb"},
+			Connection: "mongodb",
+		})
+		assert.True(t, dialector.(*Dialector).Strict)
+	})
+}
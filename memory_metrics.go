@@ -0,0 +1,82 @@
+package mongodb
+
+import (
+	"sync"
+
+	"github.com/portofolio-mager/goravel-mongodb/contracts"
+)
+
+var _ contracts.MetricsSink = &MemoryMetrics{}
+
+// MemoryMetrics keeps the metrics in memory, to read them in tests or to
+// export them to another system
+type MemoryMetrics struct {
+	mu         sync.RWMutex
+	counters   map[string]float64
+	gauges     map[string]float64
+	histograms map[string][]float64
+}
+
+func NewMemoryMetrics() *MemoryMetrics {
+	return &MemoryMetrics{
+		counters:   make(map[string]float64),
+		gauges:     make(map[string]float64),
+		histograms: make(map[string][]float64),
+	}
+}
+
+func (r *MemoryMetrics) AddCounter(name string, labels map[string]string, delta float64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.counters[name+labelString(labels)] += delta
+}
+
+func (r *MemoryMetrics) AddGauge(name string, labels map[string]string, delta float64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.gauges[name+labelString(labels)] += delta
+}
+
+func (r *MemoryMetrics) ObserveHistogram(name string, labels map[string]string, value float64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := name + labelString(labels)
+	r.histograms[key] = append(r.histograms[key], value)
+}
+
+// Counter returns the value of a counter, 0 when it was never added to
+func (r *MemoryMetrics) Counter(name string, labels map[string]string) float64 {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.counters[name+labelString(labels)]
+}
+
+// Gauge returns the value of a gauge, 0 when it was never added to
+func (r *MemoryMetrics) Gauge(name string, labels map[string]string) float64 {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.gauges[name+labelString(labels)]
+}
+
+// Histogram returns the values observed by a histogram, in order
+func (r *MemoryMetrics) Histogram(name string, labels map[string]string) []float64 {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return append([]float64(nil), r.histograms[name+labelString(labels)]...)
+}
+
+// Reset forgets every metric
+func (r *MemoryMetrics) Reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.counters = make(map[string]float64)
+	r.gauges = make(map[string]float64)
+	r.histograms = make(map[string][]float64)
+}
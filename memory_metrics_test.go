@@ -0,0 +1,43 @@
+package mongodb
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type MemoryMetricsTestSuite struct {
+	suite.Suite
+}
+
+func TestMemoryMetricsTestSuite(t *testing.T) {
+	suite.Run(t, new(MemoryMetricsTestSuite))
+}
+
+func (s *MemoryMetricsTestSuite) TestMetrics() {
+	metrics := NewMemoryMetrics()
+	users := map[string]string{"collection": "users", "command": "find"}
+	posts := map[string]string{"command": "find", "collection": "posts"}
+
+	metrics.AddCounter("commands_total", users, 1)
+	metrics.AddCounter("commands_total", map[string]string{"command": "find", "collection": "users"}, 2)
+	metrics.AddGauge("connections", nil, 3)
+	metrics.AddGauge("connections", nil, -1)
+	metrics.ObserveHistogram("duration_seconds", users, 0.5)
+	metrics.ObserveHistogram("duration_seconds", users, 0.25)
+
+	s.Equal(float64(3), metrics.Counter("commands_total", users))
+	s.Zero(metrics.Counter("commands_total", posts))
+	s.Equal(float64(2), metrics.Gauge("connections", nil))
+	s.Equal([]float64{0.5, 0.25}, metrics.Histogram("duration_seconds", users))
+	s.Empty(metrics.Histogram("duration_seconds", posts))
+
+	// The histogram returned is a copy
+	metrics.Histogram("duration_seconds", users)[0] = 1
+	s.Equal([]float64{0.5, 0.25}, metrics.Histogram("duration_seconds", users))
+
+	metrics.Reset()
+	s.Zero(metrics.Counter("commands_total", users))
+	s.Zero(metrics.Gauge("connections", nil))
+	s.Empty(metrics.Histogram("duration_seconds", users))
+}
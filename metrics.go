@@ -0,0 +1,124 @@
+package mongodb
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"go.mongodb.org/mongo-driver/event"
+
+	"github.com/portofolio-mager/goravel-mongodb/contracts"
+)
+
+// The metrics sent to the MetricsSink of a connection, all of them are
+// labelled with the connection
+const (
+	// MetricPoolConnectionsOpen is the gauge of the established connections
+	MetricPoolConnectionsOpen = "mongodb_pool_connections_open"
+	// MetricPoolConnectionsCheckedOut is the gauge of the connections in use
+	MetricPoolConnectionsCheckedOut = "mongodb_pool_connections_checked_out"
+	// MetricPoolWaitSeconds is the histogram of the time waited for a
+	// connection
+	MetricPoolWaitSeconds = "mongodb_pool_wait_seconds"
+	// MetricPoolCheckoutFailures counts the connections that could not be
+	// checked out, by reason
+	MetricPoolCheckoutFailures = "mongodb_pool_checkout_failures_total"
+	// MetricCommandDurationSeconds is the histogram of the command durations,
+	// by command and collection
+	MetricCommandDurationSeconds = "mongodb_command_duration_seconds"
+	// MetricCommandFailures counts the failed commands, by command and
+	// collection
+	MetricCommandFailures = "mongodb_command_failures_total"
+)
+
+// metricHelp describes the metrics for the Prometheus exposition
+var metricHelp = map[string]string{
+	MetricPoolConnectionsOpen:       "The connections established by the pool.",
+	MetricPoolConnectionsCheckedOut: "The connections checked out of the pool.",
+	MetricPoolWaitSeconds:           "The time waited to check a connection out of the pool.",
+	MetricPoolCheckoutFailures:      "The connections that could not be checked out of the pool.",
+	MetricCommandDurationSeconds:    "The duration of the commands.",
+	MetricCommandFailures:           "The commands that failed.",
+}
+
+// metrics returns the sink of database.connections.<connection>.metrics, nil
+// when none is set
+func (m *MongoDB) metrics() contracts.MetricsSink {
+	if m.config == nil || m.config.Config() == nil {
+		return nil
+	}
+
+	sink, _ := m.config.Config().Get(fmt.Sprintf("database.connections.%s.metrics", m.config.Connection())).(contracts.MetricsSink)
+	return sink
+}
+
+// commandMonitor sends the durations and failures of the commands to a sink
+type commandMonitor struct {
+	sink       contracts.MetricsSink
+	connection string
+	// collections are the collections of the running commands, by request
+	collections sync.Map
+}
+
+func newCommandMonitor(sink contracts.MetricsSink, connection string) *commandMonitor {
+	return &commandMonitor{
+		sink:       sink,
+		connection: connection,
+	}
+}
+
+// monitor returns the driver monitor of the commands
+func (c *commandMonitor) monitor() *event.CommandMonitor {
+	return &event.CommandMonitor{
+		Started: func(_ context.Context, started *event.CommandStartedEvent) {
+			c.collections.Store(commandKey(started.ConnectionID, started.RequestID), commandCollection(started.Command))
+		},
+		Succeeded: func(_ context.Context, succeeded *event.CommandSucceededEvent) {
+			c.finished(succeeded.CommandFinishedEvent, false)
+		},
+		Failed: func(_ context.Context, failed *event.CommandFailedEvent) {
+			c.finished(failed.CommandFinishedEvent, true)
+		},
+	}
+}
+
+func (c *commandMonitor) finished(finished event.CommandFinishedEvent, failed bool) {
+	collection, _ := c.collections.LoadAndDelete(commandKey(finished.ConnectionID, finished.RequestID))
+	name, _ := collection.(string)
+
+	labels := map[string]string{
+		"connection": c.connection,
+		"command":    finished.CommandName,
+		"collection": name,
+	}
+
+	c.sink.ObserveHistogram(MetricCommandDurationSeconds, labels, finished.Duration.Seconds())
+	if failed {
+		c.sink.AddCounter(MetricCommandFailures, labels, 1)
+	}
+}
+
+// labelEscaper escapes the label values like the Prometheus exposition format
+var labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// labelString formats the labels like Prometheus, sorted by name
+func labelString(labels map[string]string) string {
+	if len(labels) == 0 {
+		return ""
+	}
+
+	names := make([]string, 0, len(labels))
+	for name := range labels {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	pairs := make([]string, len(names))
+	for i, name := range names {
+		pairs[i] = fmt.Sprintf(`%s="%s"`, name, labelEscaper.Replace(labels[name]))
+	}
+
+	return "{" + strings.Join(pairs, ",") + "}"
+}
@@ -0,0 +1,102 @@
+package mongodb
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	mocksconfig "github.com/goravel/framework/mocks/config"
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/event"
+)
+
+type MetricsTestSuite struct {
+	suite.Suite
+	metrics *MemoryMetrics
+}
+
+func TestMetricsTestSuite(t *testing.T) {
+	suite.Run(t, new(MetricsTestSuite))
+}
+
+func (s *MetricsTestSuite) SetupTest() {
+	s.metrics = NewMemoryMetrics()
+}
+
+func (s *MetricsTestSuite) TestMetrics() {
+	mockConfig := mocksconfig.NewConfig(s.T())
+	mockConfig.EXPECT().Get("database.connections.mongodb.metrics").Return(s.metrics).Once()
+	s.Equal(s.metrics, NewMongoDB(mockConfig, nil, "mongodb").metrics())
+
+	mockConfig.EXPECT().Get("database.connections.mongodb.metrics").Return(nil).Once()
+	s.Nil(NewMongoDB(mockConfig, nil, "mongodb").metrics())
+}
+
+func (s *MetricsTestSuite) TestCommandMonitor() {
+	monitor := newCommandMonitor(s.metrics, "mongodb").monitor()
+	ctx := context.Background()
+
+	monitor.Started(ctx, &event.CommandStartedEvent{
+		Command:      mustMarshal(bson.D{{Key: "find", Value: "users"}, {Key: "filter", Value: bson.D{}}}),
+		CommandName:  "find",
+		RequestID:    1,
+		ConnectionID: "localhost:27017[-1]",
+	})
+	monitor.Started(ctx, &event.CommandStartedEvent{
+		Command:      mustMarshal(bson.D{{Key: "insert", Value: "users"}}),
+		CommandName:  "insert",
+		RequestID:    1,
+		ConnectionID: "localhost:27017[-2]",
+	})
+	monitor.Succeeded(ctx, &event.CommandSucceededEvent{CommandFinishedEvent: event.CommandFinishedEvent{
+		CommandName:  "find",
+		RequestID:    1,
+		ConnectionID: "localhost:27017[-1]",
+		Duration:     20 * time.Millisecond,
+	}})
+	monitor.Failed(ctx, &event.CommandFailedEvent{CommandFinishedEvent: event.CommandFinishedEvent{
+		CommandName:  "insert",
+		RequestID:    1,
+		ConnectionID: "localhost:27017[-2]",
+		Duration:     500 * time.Millisecond,
+	}, Failure: "duplicate key"})
+
+	find := map[string]string{"connection": "mongodb", "command": "find", "collection": "users"}
+	insert := map[string]string{"connection": "mongodb", "command": "insert", "collection": "users"}
+	s.Equal([]float64{0.02}, s.metrics.Histogram(MetricCommandDurationSeconds, find))
+	s.Zero(s.metrics.Counter(MetricCommandFailures, find))
+	s.Equal([]float64{0.5}, s.metrics.Histogram(MetricCommandDurationSeconds, insert))
+	s.Equal(float64(1), s.metrics.Counter(MetricCommandFailures, insert))
+
+	// A command finishing without starting has no collection
+	monitor.Succeeded(ctx, &event.CommandSucceededEvent{CommandFinishedEvent: event.CommandFinishedEvent{
+		CommandName: "ping",
+		RequestID:   2,
+	}})
+	s.Len(s.metrics.Histogram(MetricCommandDurationSeconds, map[string]string{"connection": "mongodb", "command": "ping", "collection": ""}), 1)
+}
+
+func (s *MetricsTestSuite) TestPoolMonitor() {
+	pool := newPoolMonitor(10, "mongodb", s.metrics)
+	monitor := pool.monitor()
+	monitor.Event(&event.PoolEvent{Type: event.ConnectionCreated})
+	monitor.Event(&event.PoolEvent{Type: event.GetSucceeded, Duration: 5 * time.Millisecond})
+	monitor.Event(&event.PoolEvent{Type: event.GetFailed, Duration: time.Second, Reason: event.ReasonTimedOut})
+
+	labels := map[string]string{"connection": "mongodb"}
+	s.Equal(float64(1), s.metrics.Gauge(MetricPoolConnectionsOpen, labels))
+	s.Equal(float64(1), s.metrics.Gauge(MetricPoolConnectionsCheckedOut, labels))
+	s.Equal([]float64{0.005, 1}, s.metrics.Histogram(MetricPoolWaitSeconds, labels))
+	s.Equal(float64(1), s.metrics.Counter(MetricPoolCheckoutFailures, map[string]string{"connection": "mongodb", "reason": "timeout"}))
+
+	monitor.Event(&event.PoolEvent{Type: event.ConnectionReturned})
+	monitor.Event(&event.PoolEvent{Type: event.ConnectionClosed})
+	s.Zero(s.metrics.Gauge(MetricPoolConnectionsOpen, labels))
+	s.Zero(s.metrics.Gauge(MetricPoolConnectionsCheckedOut, labels))
+}
+
+func (s *MetricsTestSuite) TestLabelString() {
+	s.Empty(labelString(nil))
+	s.Equal(`{a="1",b="say \"hi\"\\\n"}`, labelString(map[string]string{"b": "say \"hi\"\\\n", "a": "1"}))
+}
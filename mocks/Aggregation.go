@@ -0,0 +1,126 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package contracts
+
+import mock "github.com/stretchr/testify/mock"
+
+// Aggregation is an autogenerated mock type for the Aggregation type
+type Aggregation struct {
+	mock.Mock
+}
+
+type Aggregation_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *Aggregation) EXPECT() *Aggregation_Expecter {
+	return &Aggregation_Expecter{mock: &_m.Mock}
+}
+
+// All provides a mock function with given fields: results
+func (_m *Aggregation) All(results interface{}) error {
+	ret := _m.Called(results)
+
+	if len(ret) == 0 {
+		panic("no return value specified for All")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(interface{}) error); ok {
+		r0 = rf(results)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Aggregation_All_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'All'
+type Aggregation_All_Call struct {
+	*mock.Call
+}
+
+// All is a helper method to define mock.On call
+//   - results interface{}
+func (_e *Aggregation_Expecter) All(results interface{}) *Aggregation_All_Call {
+	return &Aggregation_All_Call{Call: _e.mock.On("All", results)}
+}
+
+func (_c *Aggregation_All_Call) Run(run func(results interface{})) *Aggregation_All_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(interface{}))
+	})
+	return _c
+}
+
+func (_c *Aggregation_All_Call) Return(_a0 error) *Aggregation_All_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Aggregation_All_Call) RunAndReturn(run func(interface{}) error) *Aggregation_All_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Merge provides a mock function with given fields: target, on, whenMatched
+func (_m *Aggregation) Merge(target string, on []string, whenMatched string) error {
+	ret := _m.Called(target, on, whenMatched)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Merge")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, []string, string) error); ok {
+		r0 = rf(target, on, whenMatched)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Aggregation_Merge_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Merge'
+type Aggregation_Merge_Call struct {
+	*mock.Call
+}
+
+// Merge is a helper method to define mock.On call
+//   - target string
+//   - on []string
+//   - whenMatched string
+func (_e *Aggregation_Expecter) Merge(target interface{}, on interface{}, whenMatched interface{}) *Aggregation_Merge_Call {
+	return &Aggregation_Merge_Call{Call: _e.mock.On("Merge", target, on, whenMatched)}
+}
+
+func (_c *Aggregation_Merge_Call) Run(run func(target string, on []string, whenMatched string)) *Aggregation_Merge_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].([]string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *Aggregation_Merge_Call) Return(_a0 error) *Aggregation_Merge_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Aggregation_Merge_Call) RunAndReturn(run func(string, []string, string) error) *Aggregation_Merge_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewAggregation creates a new instance of Aggregation. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewAggregation(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *Aggregation {
+	mock := &Aggregation{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
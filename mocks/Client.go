@@ -3,6 +3,8 @@
 package contracts
 
 import (
+	context "context"
+
 	contracts "github.com/portofolio-mager/goravel-mongodb/contracts"
 	mock "github.com/stretchr/testify/mock"
 
@@ -191,6 +193,108 @@ func (_c *Client_Database_Call) RunAndReturn(run func(...string) contracts.Datab
 	return _c
 }
 
+// HealthCheck provides a mock function with no fields
+func (_m *Client) HealthCheck() contracts.Health {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for HealthCheck")
+	}
+
+	var r0 contracts.Health
+	if rf, ok := ret.Get(0).(func() contracts.Health); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(contracts.Health)
+	}
+
+	return r0
+}
+
+// Client_HealthCheck_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'HealthCheck'
+type Client_HealthCheck_Call struct {
+	*mock.Call
+}
+
+// HealthCheck is a helper method to define mock.On call
+func (_e *Client_Expecter) HealthCheck() *Client_HealthCheck_Call {
+	return &Client_HealthCheck_Call{Call: _e.mock.On("HealthCheck")}
+}
+
+func (_c *Client_HealthCheck_Call) Run(run func()) *Client_HealthCheck_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *Client_HealthCheck_Call) Return(_a0 contracts.Health) *Client_HealthCheck_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Client_HealthCheck_Call) RunAndReturn(run func() contracts.Health) *Client_HealthCheck_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListDatabases provides a mock function with no fields
+func (_m *Client) ListDatabases() ([]contracts.DatabaseInfo, error) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListDatabases")
+	}
+
+	var r0 []contracts.DatabaseInfo
+	var r1 error
+	if rf, ok := ret.Get(0).(func() ([]contracts.DatabaseInfo, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() []contracts.DatabaseInfo); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]contracts.DatabaseInfo)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Client_ListDatabases_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListDatabases'
+type Client_ListDatabases_Call struct {
+	*mock.Call
+}
+
+// ListDatabases is a helper method to define mock.On call
+func (_e *Client_Expecter) ListDatabases() *Client_ListDatabases_Call {
+	return &Client_ListDatabases_Call{Call: _e.mock.On("ListDatabases")}
+}
+
+func (_c *Client_ListDatabases_Call) Run(run func()) *Client_ListDatabases_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *Client_ListDatabases_Call) Return(_a0 []contracts.DatabaseInfo, _a1 error) *Client_ListDatabases_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Client_ListDatabases_Call) RunAndReturn(run func() ([]contracts.DatabaseInfo, error)) *Client_ListDatabases_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Native provides a mock function with no fields
 func (_m *Client) Native() *mongo.Client {
 	ret := _m.Called()
@@ -283,6 +387,264 @@ func (_c *Client_Ping_Call) RunAndReturn(run func() error) *Client_Ping_Call {
 	return _c
 }
 
+// RegisterSeeders provides a mock function with given fields: seeders
+func (_m *Client) RegisterSeeders(seeders ...contracts.Seeder) {
+	_va := make([]interface{}, len(seeders))
+	for _i := range seeders {
+		_va[_i] = seeders[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, _va...)
+	_m.Called(_ca...)
+}
+
+// Client_RegisterSeeders_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RegisterSeeders'
+type Client_RegisterSeeders_Call struct {
+	*mock.Call
+}
+
+// RegisterSeeders is a helper method to define mock.On call
+//   - seeders ...contracts.Seeder
+func (_e *Client_Expecter) RegisterSeeders(seeders ...interface{}) *Client_RegisterSeeders_Call {
+	return &Client_RegisterSeeders_Call{Call: _e.mock.On("RegisterSeeders",
+		append([]interface{}{}, seeders...)...)}
+}
+
+func (_c *Client_RegisterSeeders_Call) Run(run func(seeders ...contracts.Seeder)) *Client_RegisterSeeders_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]contracts.Seeder, len(args)-0)
+		for i, a := range args[0:] {
+			if a != nil {
+				variadicArgs[i] = a.(contracts.Seeder)
+			}
+		}
+		run(variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *Client_RegisterSeeders_Call) Return() *Client_RegisterSeeders_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *Client_RegisterSeeders_Call) RunAndReturn(run func(...contracts.Seeder)) *Client_RegisterSeeders_Call {
+	_c.Run(run)
+	return _c
+}
+
+// Seeder provides a mock function with given fields: signature
+func (_m *Client) Seeder(signature string) contracts.Seeder {
+	ret := _m.Called(signature)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Seeder")
+	}
+
+	var r0 contracts.Seeder
+	if rf, ok := ret.Get(0).(func(string) contracts.Seeder); ok {
+		r0 = rf(signature)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(contracts.Seeder)
+		}
+	}
+
+	return r0
+}
+
+// Client_Seeder_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Seeder'
+type Client_Seeder_Call struct {
+	*mock.Call
+}
+
+// Seeder is a helper method to define mock.On call
+//   - signature string
+func (_e *Client_Expecter) Seeder(signature interface{}) *Client_Seeder_Call {
+	return &Client_Seeder_Call{Call: _e.mock.On("Seeder", signature)}
+}
+
+func (_c *Client_Seeder_Call) Run(run func(signature string)) *Client_Seeder_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Client_Seeder_Call) Return(_a0 contracts.Seeder) *Client_Seeder_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Client_Seeder_Call) RunAndReturn(run func(string) contracts.Seeder) *Client_Seeder_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Seeders provides a mock function with no fields
+func (_m *Client) Seeders() []contracts.Seeder {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Seeders")
+	}
+
+	var r0 []contracts.Seeder
+	if rf, ok := ret.Get(0).(func() []contracts.Seeder); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]contracts.Seeder)
+		}
+	}
+
+	return r0
+}
+
+// Client_Seeders_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Seeders'
+type Client_Seeders_Call struct {
+	*mock.Call
+}
+
+// Seeders is a helper method to define mock.On call
+func (_e *Client_Expecter) Seeders() *Client_Seeders_Call {
+	return &Client_Seeders_Call{Call: _e.mock.On("Seeders")}
+}
+
+func (_c *Client_Seeders_Call) Run(run func()) *Client_Seeders_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *Client_Seeders_Call) Return(_a0 []contracts.Seeder) *Client_Seeders_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Client_Seeders_Call) RunAndReturn(run func() []contracts.Seeder) *Client_Seeders_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Watch provides a mock function with given fields: pipeline, opts
+func (_m *Client) Watch(pipeline interface{}, opts ...interface{}) (contracts.ChangeStream, error) {
+	var _ca []interface{}
+	_ca = append(_ca, pipeline)
+	_ca = append(_ca, opts...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Watch")
+	}
+
+	var r0 contracts.ChangeStream
+	var r1 error
+	if rf, ok := ret.Get(0).(func(interface{}, ...interface{}) (contracts.ChangeStream, error)); ok {
+		return rf(pipeline, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(interface{}, ...interface{}) contracts.ChangeStream); ok {
+		r0 = rf(pipeline, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(contracts.ChangeStream)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(interface{}, ...interface{}) error); ok {
+		r1 = rf(pipeline, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Client_Watch_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Watch'
+type Client_Watch_Call struct {
+	*mock.Call
+}
+
+// Watch is a helper method to define mock.On call
+//   - pipeline interface{}
+//   - opts ...interface{}
+func (_e *Client_Expecter) Watch(pipeline interface{}, opts ...interface{}) *Client_Watch_Call {
+	return &Client_Watch_Call{Call: _e.mock.On("Watch",
+		append([]interface{}{pipeline}, opts...)...)}
+}
+
+func (_c *Client_Watch_Call) Run(run func(pipeline interface{}, opts ...interface{})) *Client_Watch_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]interface{}, len(args)-1)
+		for i, a := range args[1:] {
+			if a != nil {
+				variadicArgs[i] = a.(interface{})
+			}
+		}
+		run(args[0].(interface{}), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *Client_Watch_Call) Return(_a0 contracts.ChangeStream, _a1 error) *Client_Watch_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Client_Watch_Call) RunAndReturn(run func(interface{}, ...interface{}) (contracts.ChangeStream, error)) *Client_Watch_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// WithContext provides a mock function with given fields: ctx
+func (_m *Client) WithContext(ctx context.Context) contracts.Client {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for WithContext")
+	}
+
+	var r0 contracts.Client
+	if rf, ok := ret.Get(0).(func(context.Context) contracts.Client); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(contracts.Client)
+		}
+	}
+
+	return r0
+}
+
+// Client_WithContext_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'WithContext'
+type Client_WithContext_Call struct {
+	*mock.Call
+}
+
+// WithContext is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *Client_Expecter) WithContext(ctx interface{}) *Client_WithContext_Call {
+	return &Client_WithContext_Call{Call: _e.mock.On("WithContext", ctx)}
+}
+
+func (_c *Client_WithContext_Call) Run(run func(ctx context.Context)) *Client_WithContext_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *Client_WithContext_Call) Return(_a0 contracts.Client) *Client_WithContext_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Client_WithContext_Call) RunAndReturn(run func(context.Context) contracts.Client) *Client_WithContext_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NewClient creates a new instance of Client. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewClient(t interface {
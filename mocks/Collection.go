@@ -3,6 +3,8 @@
 package contracts
 
 import (
+	context "context"
+
 	contracts "github.com/portofolio-mager/goravel-mongodb/contracts"
 	mock "github.com/stretchr/testify/mock"
 
@@ -22,6 +24,65 @@ func (_m *Collection) EXPECT() *Collection_Expecter {
 	return &Collection_Expecter{mock: &_m.Mock}
 }
 
+// Aggregate provides a mock function with given fields: pipeline, opts
+func (_m *Collection) Aggregate(pipeline interface{}, opts ...interface{}) contracts.Aggregation {
+	var _ca []interface{}
+	_ca = append(_ca, pipeline)
+	_ca = append(_ca, opts...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Aggregate")
+	}
+
+	var r0 contracts.Aggregation
+	if rf, ok := ret.Get(0).(func(interface{}, ...interface{}) contracts.Aggregation); ok {
+		r0 = rf(pipeline, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(contracts.Aggregation)
+		}
+	}
+
+	return r0
+}
+
+// Collection_Aggregate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Aggregate'
+type Collection_Aggregate_Call struct {
+	*mock.Call
+}
+
+// Aggregate is a helper method to define mock.On call
+//   - pipeline interface{}
+//   - opts ...interface{}
+func (_e *Collection_Expecter) Aggregate(pipeline interface{}, opts ...interface{}) *Collection_Aggregate_Call {
+	return &Collection_Aggregate_Call{Call: _e.mock.On("Aggregate",
+		append([]interface{}{pipeline}, opts...)...)}
+}
+
+func (_c *Collection_Aggregate_Call) Run(run func(pipeline interface{}, opts ...interface{})) *Collection_Aggregate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]interface{}, len(args)-1)
+		for i, a := range args[1:] {
+			if a != nil {
+				variadicArgs[i] = a.(interface{})
+			}
+		}
+		run(args[0].(interface{}), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *Collection_Aggregate_Call) Return(_a0 contracts.Aggregation) *Collection_Aggregate_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Collection_Aggregate_Call) RunAndReturn(run func(interface{}, ...interface{}) contracts.Aggregation) *Collection_Aggregate_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // CountDocuments provides a mock function with given fields: filter, opts
 func (_m *Collection) CountDocuments(filter interface{}, opts ...interface{}) (int64, error) {
 	var _ca []interface{}
@@ -732,6 +793,134 @@ func (_c *Collection_Native_Call) RunAndReturn(run func() *mongo.Collection) *Co
 	return _c
 }
 
+// Observe provides a mock function with given fields: observer
+func (_m *Collection) Observe(observer contracts.Observer) {
+	_m.Called(observer)
+}
+
+// Collection_Observe_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Observe'
+type Collection_Observe_Call struct {
+	*mock.Call
+}
+
+// Observe is a helper method to define mock.On call
+//   - observer contracts.Observer
+func (_e *Collection_Expecter) Observe(observer interface{}) *Collection_Observe_Call {
+	return &Collection_Observe_Call{Call: _e.mock.On("Observe", observer)}
+}
+
+func (_c *Collection_Observe_Call) Run(run func(observer contracts.Observer)) *Collection_Observe_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(contracts.Observer))
+	})
+	return _c
+}
+
+func (_c *Collection_Observe_Call) Return() *Collection_Observe_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *Collection_Observe_Call) RunAndReturn(run func(contracts.Observer)) *Collection_Observe_Call {
+	_c.Run(run)
+	return _c
+}
+
+// Query provides a mock function with no fields
+func (_m *Collection) Query() contracts.QueryBuilder {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Query")
+	}
+
+	var r0 contracts.QueryBuilder
+	if rf, ok := ret.Get(0).(func() contracts.QueryBuilder); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(contracts.QueryBuilder)
+		}
+	}
+
+	return r0
+}
+
+// Collection_Query_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Query'
+type Collection_Query_Call struct {
+	*mock.Call
+}
+
+// Query is a helper method to define mock.On call
+func (_e *Collection_Expecter) Query() *Collection_Query_Call {
+	return &Collection_Query_Call{Call: _e.mock.On("Query")}
+}
+
+func (_c *Collection_Query_Call) Run(run func()) *Collection_Query_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *Collection_Query_Call) Return(_a0 contracts.QueryBuilder) *Collection_Query_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Collection_Query_Call) RunAndReturn(run func() contracts.QueryBuilder) *Collection_Query_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetValidator provides a mock function with given fields: schema, level, action
+func (_m *Collection) SetValidator(schema interface{}, level string, action string) error {
+	ret := _m.Called(schema, level, action)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetValidator")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(interface{}, string, string) error); ok {
+		r0 = rf(schema, level, action)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Collection_SetValidator_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetValidator'
+type Collection_SetValidator_Call struct {
+	*mock.Call
+}
+
+// SetValidator is a helper method to define mock.On call
+//   - schema interface{}
+//   - level string
+//   - action string
+func (_e *Collection_Expecter) SetValidator(schema interface{}, level interface{}, action interface{}) *Collection_SetValidator_Call {
+	return &Collection_SetValidator_Call{Call: _e.mock.On("SetValidator", schema, level, action)}
+}
+
+func (_c *Collection_SetValidator_Call) Run(run func(schema interface{}, level string, action string)) *Collection_SetValidator_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(interface{}), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *Collection_SetValidator_Call) Return(_a0 error) *Collection_SetValidator_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Collection_SetValidator_Call) RunAndReturn(run func(interface{}, string, string) error) *Collection_SetValidator_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // UpdateMany provides a mock function with given fields: filter, update, opts
 func (_m *Collection) UpdateMany(filter interface{}, update interface{}, opts ...interface{}) (*mongo.UpdateResult, error) {
 	var _ca []interface{}
@@ -872,6 +1061,75 @@ func (_c *Collection_UpdateOne_Call) RunAndReturn(run func(interface{}, interfac
 	return _c
 }
 
+// Watch provides a mock function with given fields: pipeline, opts
+func (_m *Collection) Watch(pipeline interface{}, opts ...interface{}) (contracts.ChangeStream, error) {
+	var _ca []interface{}
+	_ca = append(_ca, pipeline)
+	_ca = append(_ca, opts...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Watch")
+	}
+
+	var r0 contracts.ChangeStream
+	var r1 error
+	if rf, ok := ret.Get(0).(func(interface{}, ...interface{}) (contracts.ChangeStream, error)); ok {
+		return rf(pipeline, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(interface{}, ...interface{}) contracts.ChangeStream); ok {
+		r0 = rf(pipeline, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(contracts.ChangeStream)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(interface{}, ...interface{}) error); ok {
+		r1 = rf(pipeline, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Collection_Watch_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Watch'
+type Collection_Watch_Call struct {
+	*mock.Call
+}
+
+// Watch is a helper method to define mock.On call
+//   - pipeline interface{}
+//   - opts ...interface{}
+func (_e *Collection_Expecter) Watch(pipeline interface{}, opts ...interface{}) *Collection_Watch_Call {
+	return &Collection_Watch_Call{Call: _e.mock.On("Watch",
+		append([]interface{}{pipeline}, opts...)...)}
+}
+
+func (_c *Collection_Watch_Call) Run(run func(pipeline interface{}, opts ...interface{})) *Collection_Watch_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]interface{}, len(args)-1)
+		for i, a := range args[1:] {
+			if a != nil {
+				variadicArgs[i] = a.(interface{})
+			}
+		}
+		run(args[0].(interface{}), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *Collection_Watch_Call) Return(_a0 contracts.ChangeStream, _a1 error) *Collection_Watch_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Collection_Watch_Call) RunAndReturn(run func(interface{}, ...interface{}) (contracts.ChangeStream, error)) *Collection_Watch_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Where provides a mock function with given fields: field, value
 func (_m *Collection) Where(field string, value interface{}) contracts.QueryBuilder {
 	ret := _m.Called(field, value)
@@ -921,6 +1179,150 @@ func (_c *Collection_Where_Call) RunAndReturn(run func(string, interface{}) cont
 	return _c
 }
 
+// WithContext provides a mock function with given fields: ctx
+func (_m *Collection) WithContext(ctx context.Context) contracts.Collection {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for WithContext")
+	}
+
+	var r0 contracts.Collection
+	if rf, ok := ret.Get(0).(func(context.Context) contracts.Collection); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(contracts.Collection)
+		}
+	}
+
+	return r0
+}
+
+// Collection_WithContext_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'WithContext'
+type Collection_WithContext_Call struct {
+	*mock.Call
+}
+
+// WithContext is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *Collection_Expecter) WithContext(ctx interface{}) *Collection_WithContext_Call {
+	return &Collection_WithContext_Call{Call: _e.mock.On("WithContext", ctx)}
+}
+
+func (_c *Collection_WithContext_Call) Run(run func(ctx context.Context)) *Collection_WithContext_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *Collection_WithContext_Call) Return(_a0 contracts.Collection) *Collection_WithContext_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Collection_WithContext_Call) RunAndReturn(run func(context.Context) contracts.Collection) *Collection_WithContext_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// WithScope provides a mock function with given fields: name, scope
+func (_m *Collection) WithScope(name string, scope func(contracts.QueryBuilder)) contracts.Collection {
+	ret := _m.Called(name, scope)
+
+	if len(ret) == 0 {
+		panic("no return value specified for WithScope")
+	}
+
+	var r0 contracts.Collection
+	if rf, ok := ret.Get(0).(func(string, func(contracts.QueryBuilder)) contracts.Collection); ok {
+		r0 = rf(name, scope)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(contracts.Collection)
+		}
+	}
+
+	return r0
+}
+
+// Collection_WithScope_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'WithScope'
+type Collection_WithScope_Call struct {
+	*mock.Call
+}
+
+// WithScope is a helper method to define mock.On call
+//   - name string
+//   - scope func(contracts.QueryBuilder)
+func (_e *Collection_Expecter) WithScope(name interface{}, scope interface{}) *Collection_WithScope_Call {
+	return &Collection_WithScope_Call{Call: _e.mock.On("WithScope", name, scope)}
+}
+
+func (_c *Collection_WithScope_Call) Run(run func(name string, scope func(contracts.QueryBuilder))) *Collection_WithScope_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(func(contracts.QueryBuilder)))
+	})
+	return _c
+}
+
+func (_c *Collection_WithScope_Call) Return(_a0 contracts.Collection) *Collection_WithScope_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Collection_WithScope_Call) RunAndReturn(run func(string, func(contracts.QueryBuilder)) contracts.Collection) *Collection_WithScope_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// WithSoftDeletes provides a mock function with no fields
+func (_m *Collection) WithSoftDeletes() contracts.Collection {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for WithSoftDeletes")
+	}
+
+	var r0 contracts.Collection
+	if rf, ok := ret.Get(0).(func() contracts.Collection); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(contracts.Collection)
+		}
+	}
+
+	return r0
+}
+
+// Collection_WithSoftDeletes_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'WithSoftDeletes'
+type Collection_WithSoftDeletes_Call struct {
+	*mock.Call
+}
+
+// WithSoftDeletes is a helper method to define mock.On call
+func (_e *Collection_Expecter) WithSoftDeletes() *Collection_WithSoftDeletes_Call {
+	return &Collection_WithSoftDeletes_Call{Call: _e.mock.On("WithSoftDeletes")}
+}
+
+func (_c *Collection_WithSoftDeletes_Call) Run(run func()) *Collection_WithSoftDeletes_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *Collection_WithSoftDeletes_Call) Return(_a0 contracts.Collection) *Collection_WithSoftDeletes_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Collection_WithSoftDeletes_Call) RunAndReturn(run func() contracts.Collection) *Collection_WithSoftDeletes_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NewCollection creates a new instance of Collection. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewCollection(t interface {
@@ -3,10 +3,14 @@
 package contracts
 
 import (
+	context "context"
+
 	contracts "github.com/portofolio-mager/goravel-mongodb/contracts"
 	mock "github.com/stretchr/testify/mock"
 
 	mongo "go.mongodb.org/mongo-driver/mongo"
+
+	time "time"
 )
 
 // Database is an autogenerated mock type for the Database type
@@ -70,6 +74,158 @@ func (_c *Database_Collection_Call) RunAndReturn(run func(string) contracts.Coll
 	return _c
 }
 
+// CollectionInfo provides a mock function with given fields: name
+func (_m *Database) CollectionInfo(name string) (*contracts.CollectionInfo, error) {
+	ret := _m.Called(name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CollectionInfo")
+	}
+
+	var r0 *contracts.CollectionInfo
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (*contracts.CollectionInfo, error)); ok {
+		return rf(name)
+	}
+	if rf, ok := ret.Get(0).(func(string) *contracts.CollectionInfo); ok {
+		r0 = rf(name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*contracts.CollectionInfo)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_CollectionInfo_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CollectionInfo'
+type Database_CollectionInfo_Call struct {
+	*mock.Call
+}
+
+// CollectionInfo is a helper method to define mock.On call
+//   - name string
+func (_e *Database_Expecter) CollectionInfo(name interface{}) *Database_CollectionInfo_Call {
+	return &Database_CollectionInfo_Call{Call: _e.mock.On("CollectionInfo", name)}
+}
+
+func (_c *Database_CollectionInfo_Call) Run(run func(name string)) *Database_CollectionInfo_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_CollectionInfo_Call) Return(_a0 *contracts.CollectionInfo, _a1 error) *Database_CollectionInfo_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_CollectionInfo_Call) RunAndReturn(run func(string) (*contracts.CollectionInfo, error)) *Database_CollectionInfo_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateCapped provides a mock function with given fields: name, size, max
+func (_m *Database) CreateCapped(name string, size int64, max int64) error {
+	ret := _m.Called(name, size, max)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateCapped")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, int64, int64) error); ok {
+		r0 = rf(name, size, max)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Database_CreateCapped_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateCapped'
+type Database_CreateCapped_Call struct {
+	*mock.Call
+}
+
+// CreateCapped is a helper method to define mock.On call
+//   - name string
+//   - size int64
+//   - max int64
+func (_e *Database_Expecter) CreateCapped(name interface{}, size interface{}, max interface{}) *Database_CreateCapped_Call {
+	return &Database_CreateCapped_Call{Call: _e.mock.On("CreateCapped", name, size, max)}
+}
+
+func (_c *Database_CreateCapped_Call) Run(run func(name string, size int64, max int64)) *Database_CreateCapped_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(int64), args[2].(int64))
+	})
+	return _c
+}
+
+func (_c *Database_CreateCapped_Call) Return(_a0 error) *Database_CreateCapped_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_CreateCapped_Call) RunAndReturn(run func(string, int64, int64) error) *Database_CreateCapped_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateClustered provides a mock function with given fields: name
+func (_m *Database) CreateClustered(name string) error {
+	ret := _m.Called(name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateClustered")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(name)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Database_CreateClustered_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateClustered'
+type Database_CreateClustered_Call struct {
+	*mock.Call
+}
+
+// CreateClustered is a helper method to define mock.On call
+//   - name string
+func (_e *Database_Expecter) CreateClustered(name interface{}) *Database_CreateClustered_Call {
+	return &Database_CreateClustered_Call{Call: _e.mock.On("CreateClustered", name)}
+}
+
+func (_c *Database_CreateClustered_Call) Run(run func(name string)) *Database_CreateClustered_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_CreateClustered_Call) Return(_a0 error) *Database_CreateClustered_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_CreateClustered_Call) RunAndReturn(run func(string) error) *Database_CreateClustered_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // CreateCollection provides a mock function with given fields: name, opts
 func (_m *Database) CreateCollection(name string, opts ...interface{}) error {
 	var _ca []interface{}
@@ -127,6 +283,104 @@ func (_c *Database_CreateCollection_Call) RunAndReturn(run func(string, ...inter
 	return _c
 }
 
+// CreateTimeSeries provides a mock function with given fields: name, timeField, metaField, granularity, expireAfter
+func (_m *Database) CreateTimeSeries(name string, timeField string, metaField string, granularity string, expireAfter time.Duration) error {
+	ret := _m.Called(name, timeField, metaField, granularity, expireAfter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateTimeSeries")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string, string, string, time.Duration) error); ok {
+		r0 = rf(name, timeField, metaField, granularity, expireAfter)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Database_CreateTimeSeries_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateTimeSeries'
+type Database_CreateTimeSeries_Call struct {
+	*mock.Call
+}
+
+// CreateTimeSeries is a helper method to define mock.On call
+//   - name string
+//   - timeField string
+//   - metaField string
+//   - granularity string
+//   - expireAfter time.Duration
+func (_e *Database_Expecter) CreateTimeSeries(name interface{}, timeField interface{}, metaField interface{}, granularity interface{}, expireAfter interface{}) *Database_CreateTimeSeries_Call {
+	return &Database_CreateTimeSeries_Call{Call: _e.mock.On("CreateTimeSeries", name, timeField, metaField, granularity, expireAfter)}
+}
+
+func (_c *Database_CreateTimeSeries_Call) Run(run func(name string, timeField string, metaField string, granularity string, expireAfter time.Duration)) *Database_CreateTimeSeries_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(string), args[2].(string), args[3].(string), args[4].(time.Duration))
+	})
+	return _c
+}
+
+func (_c *Database_CreateTimeSeries_Call) Return(_a0 error) *Database_CreateTimeSeries_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_CreateTimeSeries_Call) RunAndReturn(run func(string, string, string, string, time.Duration) error) *Database_CreateTimeSeries_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateView provides a mock function with given fields: name, source, pipeline
+func (_m *Database) CreateView(name string, source string, pipeline interface{}) error {
+	ret := _m.Called(name, source, pipeline)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateView")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string, interface{}) error); ok {
+		r0 = rf(name, source, pipeline)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Database_CreateView_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateView'
+type Database_CreateView_Call struct {
+	*mock.Call
+}
+
+// CreateView is a helper method to define mock.On call
+//   - name string
+//   - source string
+//   - pipeline interface{}
+func (_e *Database_Expecter) CreateView(name interface{}, source interface{}, pipeline interface{}) *Database_CreateView_Call {
+	return &Database_CreateView_Call{Call: _e.mock.On("CreateView", name, source, pipeline)}
+}
+
+func (_c *Database_CreateView_Call) Run(run func(name string, source string, pipeline interface{})) *Database_CreateView_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(string), args[2].(interface{}))
+	})
+	return _c
+}
+
+func (_c *Database_CreateView_Call) Return(_a0 error) *Database_CreateView_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_CreateView_Call) RunAndReturn(run func(string, string, interface{}) error) *Database_CreateView_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Drop provides a mock function with no fields
 func (_m *Database) Drop() error {
 	ret := _m.Called()
@@ -172,6 +426,196 @@ func (_c *Database_Drop_Call) RunAndReturn(run func() error) *Database_Drop_Call
 	return _c
 }
 
+// DumpFixtures provides a mock function with given fields: dir, collections
+func (_m *Database) DumpFixtures(dir string, collections ...string) error {
+	_va := make([]interface{}, len(collections))
+	for _i := range collections {
+		_va[_i] = collections[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, dir)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DumpFixtures")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, ...string) error); ok {
+		r0 = rf(dir, collections...)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Database_DumpFixtures_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DumpFixtures'
+type Database_DumpFixtures_Call struct {
+	*mock.Call
+}
+
+// DumpFixtures is a helper method to define mock.On call
+//   - dir string
+//   - collections ...string
+func (_e *Database_Expecter) DumpFixtures(dir interface{}, collections ...interface{}) *Database_DumpFixtures_Call {
+	return &Database_DumpFixtures_Call{Call: _e.mock.On("DumpFixtures",
+		append([]interface{}{dir}, collections...)...)}
+}
+
+func (_c *Database_DumpFixtures_Call) Run(run func(dir string, collections ...string)) *Database_DumpFixtures_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]string, len(args)-1)
+		for i, a := range args[1:] {
+			if a != nil {
+				variadicArgs[i] = a.(string)
+			}
+		}
+		run(args[0].(string), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *Database_DumpFixtures_Call) Return(_a0 error) *Database_DumpFixtures_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_DumpFixtures_Call) RunAndReturn(run func(string, ...string) error) *Database_DumpFixtures_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GridFS provides a mock function with given fields: bucket
+func (_m *Database) GridFS(bucket ...string) (contracts.GridFS, error) {
+	_va := make([]interface{}, len(bucket))
+	for _i := range bucket {
+		_va[_i] = bucket[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GridFS")
+	}
+
+	var r0 contracts.GridFS
+	var r1 error
+	if rf, ok := ret.Get(0).(func(...string) (contracts.GridFS, error)); ok {
+		return rf(bucket...)
+	}
+	if rf, ok := ret.Get(0).(func(...string) contracts.GridFS); ok {
+		r0 = rf(bucket...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(contracts.GridFS)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(...string) error); ok {
+		r1 = rf(bucket...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_GridFS_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GridFS'
+type Database_GridFS_Call struct {
+	*mock.Call
+}
+
+// GridFS is a helper method to define mock.On call
+//   - bucket ...string
+func (_e *Database_Expecter) GridFS(bucket ...interface{}) *Database_GridFS_Call {
+	return &Database_GridFS_Call{Call: _e.mock.On("GridFS",
+		append([]interface{}{}, bucket...)...)}
+}
+
+func (_c *Database_GridFS_Call) Run(run func(bucket ...string)) *Database_GridFS_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]string, len(args)-0)
+		for i, a := range args[0:] {
+			if a != nil {
+				variadicArgs[i] = a.(string)
+			}
+		}
+		run(variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *Database_GridFS_Call) Return(_a0 contracts.GridFS, _a1 error) *Database_GridFS_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_GridFS_Call) RunAndReturn(run func(...string) (contracts.GridFS, error)) *Database_GridFS_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListCollectionSpecs provides a mock function with given fields: filter
+func (_m *Database) ListCollectionSpecs(filter interface{}) ([]contracts.CollectionInfo, error) {
+	ret := _m.Called(filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListCollectionSpecs")
+	}
+
+	var r0 []contracts.CollectionInfo
+	var r1 error
+	if rf, ok := ret.Get(0).(func(interface{}) ([]contracts.CollectionInfo, error)); ok {
+		return rf(filter)
+	}
+	if rf, ok := ret.Get(0).(func(interface{}) []contracts.CollectionInfo); ok {
+		r0 = rf(filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]contracts.CollectionInfo)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(interface{}) error); ok {
+		r1 = rf(filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_ListCollectionSpecs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListCollectionSpecs'
+type Database_ListCollectionSpecs_Call struct {
+	*mock.Call
+}
+
+// ListCollectionSpecs is a helper method to define mock.On call
+//   - filter interface{}
+func (_e *Database_Expecter) ListCollectionSpecs(filter interface{}) *Database_ListCollectionSpecs_Call {
+	return &Database_ListCollectionSpecs_Call{Call: _e.mock.On("ListCollectionSpecs", filter)}
+}
+
+func (_c *Database_ListCollectionSpecs_Call) Run(run func(filter interface{})) *Database_ListCollectionSpecs_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(interface{}))
+	})
+	return _c
+}
+
+func (_c *Database_ListCollectionSpecs_Call) Return(_a0 []contracts.CollectionInfo, _a1 error) *Database_ListCollectionSpecs_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_ListCollectionSpecs_Call) RunAndReturn(run func(interface{}) ([]contracts.CollectionInfo, error)) *Database_ListCollectionSpecs_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // ListCollections provides a mock function with no fields
 func (_m *Database) ListCollections() ([]string, error) {
 	ret := _m.Called()
@@ -229,6 +673,109 @@ func (_c *Database_ListCollections_Call) RunAndReturn(run func() ([]string, erro
 	return _c
 }
 
+// ListViews provides a mock function with no fields
+func (_m *Database) ListViews() ([]string, error) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListViews")
+	}
+
+	var r0 []string
+	var r1 error
+	if rf, ok := ret.Get(0).(func() ([]string, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() []string); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_ListViews_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListViews'
+type Database_ListViews_Call struct {
+	*mock.Call
+}
+
+// ListViews is a helper method to define mock.On call
+func (_e *Database_Expecter) ListViews() *Database_ListViews_Call {
+	return &Database_ListViews_Call{Call: _e.mock.On("ListViews")}
+}
+
+func (_c *Database_ListViews_Call) Run(run func()) *Database_ListViews_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *Database_ListViews_Call) Return(_a0 []string, _a1 error) *Database_ListViews_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_ListViews_Call) RunAndReturn(run func() ([]string, error)) *Database_ListViews_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// LoadFixtures provides a mock function with given fields: dir
+func (_m *Database) LoadFixtures(dir string) error {
+	ret := _m.Called(dir)
+
+	if len(ret) == 0 {
+		panic("no return value specified for LoadFixtures")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(dir)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Database_LoadFixtures_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'LoadFixtures'
+type Database_LoadFixtures_Call struct {
+	*mock.Call
+}
+
+// LoadFixtures is a helper method to define mock.On call
+//   - dir string
+func (_e *Database_Expecter) LoadFixtures(dir interface{}) *Database_LoadFixtures_Call {
+	return &Database_LoadFixtures_Call{Call: _e.mock.On("LoadFixtures", dir)}
+}
+
+func (_c *Database_LoadFixtures_Call) Run(run func(dir string)) *Database_LoadFixtures_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Database_LoadFixtures_Call) Return(_a0 error) *Database_LoadFixtures_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_LoadFixtures_Call) RunAndReturn(run func(string) error) *Database_LoadFixtures_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Name provides a mock function with no fields
 func (_m *Database) Name() string {
 	ret := _m.Called()
@@ -321,6 +868,123 @@ func (_c *Database_Native_Call) RunAndReturn(run func() *mongo.Database) *Databa
 	return _c
 }
 
+// Watch provides a mock function with given fields: pipeline, opts
+func (_m *Database) Watch(pipeline interface{}, opts ...interface{}) (contracts.ChangeStream, error) {
+	var _ca []interface{}
+	_ca = append(_ca, pipeline)
+	_ca = append(_ca, opts...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Watch")
+	}
+
+	var r0 contracts.ChangeStream
+	var r1 error
+	if rf, ok := ret.Get(0).(func(interface{}, ...interface{}) (contracts.ChangeStream, error)); ok {
+		return rf(pipeline, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(interface{}, ...interface{}) contracts.ChangeStream); ok {
+		r0 = rf(pipeline, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(contracts.ChangeStream)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(interface{}, ...interface{}) error); ok {
+		r1 = rf(pipeline, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_Watch_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Watch'
+type Database_Watch_Call struct {
+	*mock.Call
+}
+
+// Watch is a helper method to define mock.On call
+//   - pipeline interface{}
+//   - opts ...interface{}
+func (_e *Database_Expecter) Watch(pipeline interface{}, opts ...interface{}) *Database_Watch_Call {
+	return &Database_Watch_Call{Call: _e.mock.On("Watch",
+		append([]interface{}{pipeline}, opts...)...)}
+}
+
+func (_c *Database_Watch_Call) Run(run func(pipeline interface{}, opts ...interface{})) *Database_Watch_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]interface{}, len(args)-1)
+		for i, a := range args[1:] {
+			if a != nil {
+				variadicArgs[i] = a.(interface{})
+			}
+		}
+		run(args[0].(interface{}), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *Database_Watch_Call) Return(_a0 contracts.ChangeStream, _a1 error) *Database_Watch_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_Watch_Call) RunAndReturn(run func(interface{}, ...interface{}) (contracts.ChangeStream, error)) *Database_Watch_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// WithContext provides a mock function with given fields: ctx
+func (_m *Database) WithContext(ctx context.Context) contracts.Database {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for WithContext")
+	}
+
+	var r0 contracts.Database
+	if rf, ok := ret.Get(0).(func(context.Context) contracts.Database); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(contracts.Database)
+		}
+	}
+
+	return r0
+}
+
+// Database_WithContext_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'WithContext'
+type Database_WithContext_Call struct {
+	*mock.Call
+}
+
+// WithContext is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *Database_Expecter) WithContext(ctx interface{}) *Database_WithContext_Call {
+	return &Database_WithContext_Call{Call: _e.mock.On("WithContext", ctx)}
+}
+
+func (_c *Database_WithContext_Call) Run(run func(ctx context.Context)) *Database_WithContext_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *Database_WithContext_Call) Return(_a0 contracts.Database) *Database_WithContext_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_WithContext_Call) RunAndReturn(run func(context.Context) contracts.Database) *Database_WithContext_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NewDatabase creates a new instance of Database. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewDatabase(t interface {
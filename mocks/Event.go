@@ -0,0 +1,317 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package contracts
+
+import (
+	context "context"
+
+	contracts "github.com/portofolio-mager/goravel-mongodb/contracts"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// Event is an autogenerated mock type for the Event type
+type Event struct {
+	mock.Mock
+}
+
+type Event_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *Event) EXPECT() *Event_Expecter {
+	return &Event_Expecter{mock: &_m.Mock}
+}
+
+// Collection provides a mock function with no fields
+func (_m *Event) Collection() contracts.Collection {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Collection")
+	}
+
+	var r0 contracts.Collection
+	if rf, ok := ret.Get(0).(func() contracts.Collection); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(contracts.Collection)
+		}
+	}
+
+	return r0
+}
+
+// Event_Collection_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Collection'
+type Event_Collection_Call struct {
+	*mock.Call
+}
+
+// Collection is a helper method to define mock.On call
+func (_e *Event_Expecter) Collection() *Event_Collection_Call {
+	return &Event_Collection_Call{Call: _e.mock.On("Collection")}
+}
+
+func (_c *Event_Collection_Call) Run(run func()) *Event_Collection_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *Event_Collection_Call) Return(_a0 contracts.Collection) *Event_Collection_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Event_Collection_Call) RunAndReturn(run func() contracts.Collection) *Event_Collection_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Context provides a mock function with no fields
+func (_m *Event) Context() context.Context {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Context")
+	}
+
+	var r0 context.Context
+	if rf, ok := ret.Get(0).(func() context.Context); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(context.Context)
+		}
+	}
+
+	return r0
+}
+
+// Event_Context_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Context'
+type Event_Context_Call struct {
+	*mock.Call
+}
+
+// Context is a helper method to define mock.On call
+func (_e *Event_Expecter) Context() *Event_Context_Call {
+	return &Event_Context_Call{Call: _e.mock.On("Context")}
+}
+
+func (_c *Event_Context_Call) Run(run func()) *Event_Context_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *Event_Context_Call) Return(_a0 context.Context) *Event_Context_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Event_Context_Call) RunAndReturn(run func() context.Context) *Event_Context_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Filter provides a mock function with no fields
+func (_m *Event) Filter() interface{} {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Filter")
+	}
+
+	var r0 interface{}
+	if rf, ok := ret.Get(0).(func() interface{}); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(interface{})
+		}
+	}
+
+	return r0
+}
+
+// Event_Filter_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Filter'
+type Event_Filter_Call struct {
+	*mock.Call
+}
+
+// Filter is a helper method to define mock.On call
+func (_e *Event_Expecter) Filter() *Event_Filter_Call {
+	return &Event_Filter_Call{Call: _e.mock.On("Filter")}
+}
+
+func (_c *Event_Filter_Call) Run(run func()) *Event_Filter_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *Event_Filter_Call) Return(_a0 interface{}) *Event_Filter_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Event_Filter_Call) RunAndReturn(run func() interface{}) *Event_Filter_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Model provides a mock function with no fields
+func (_m *Event) Model() interface{} {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Model")
+	}
+
+	var r0 interface{}
+	if rf, ok := ret.Get(0).(func() interface{}); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(interface{})
+		}
+	}
+
+	return r0
+}
+
+// Event_Model_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Model'
+type Event_Model_Call struct {
+	*mock.Call
+}
+
+// Model is a helper method to define mock.On call
+func (_e *Event_Expecter) Model() *Event_Model_Call {
+	return &Event_Model_Call{Call: _e.mock.On("Model")}
+}
+
+func (_c *Event_Model_Call) Run(run func()) *Event_Model_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *Event_Model_Call) Return(_a0 interface{}) *Event_Model_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Event_Model_Call) RunAndReturn(run func() interface{}) *Event_Model_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Type provides a mock function with no fields
+func (_m *Event) Type() contracts.EventType {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Type")
+	}
+
+	var r0 contracts.EventType
+	if rf, ok := ret.Get(0).(func() contracts.EventType); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(contracts.EventType)
+	}
+
+	return r0
+}
+
+// Event_Type_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Type'
+type Event_Type_Call struct {
+	*mock.Call
+}
+
+// Type is a helper method to define mock.On call
+func (_e *Event_Expecter) Type() *Event_Type_Call {
+	return &Event_Type_Call{Call: _e.mock.On("Type")}
+}
+
+func (_c *Event_Type_Call) Run(run func()) *Event_Type_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *Event_Type_Call) Return(_a0 contracts.EventType) *Event_Type_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Event_Type_Call) RunAndReturn(run func() contracts.EventType) *Event_Type_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Update provides a mock function with no fields
+func (_m *Event) Update() interface{} {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Update")
+	}
+
+	var r0 interface{}
+	if rf, ok := ret.Get(0).(func() interface{}); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(interface{})
+		}
+	}
+
+	return r0
+}
+
+// Event_Update_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Update'
+type Event_Update_Call struct {
+	*mock.Call
+}
+
+// Update is a helper method to define mock.On call
+func (_e *Event_Expecter) Update() *Event_Update_Call {
+	return &Event_Update_Call{Call: _e.mock.On("Update")}
+}
+
+func (_c *Event_Update_Call) Run(run func()) *Event_Update_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *Event_Update_Call) Return(_a0 interface{}) *Event_Update_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Event_Update_Call) RunAndReturn(run func() interface{}) *Event_Update_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewEvent creates a new instance of Event. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewEvent(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *Event {
+	mock := &Event{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
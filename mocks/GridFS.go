@@ -0,0 +1,680 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package contracts
+
+import (
+	context "context"
+
+	contracts "github.com/portofolio-mager/goravel-mongodb/contracts"
+	gridfs "go.mongodb.org/mongo-driver/mongo/gridfs"
+
+	io "io"
+
+	mock "github.com/stretchr/testify/mock"
+
+	primitive "go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// GridFS is an autogenerated mock type for the GridFS type
+type GridFS struct {
+	mock.Mock
+}
+
+type GridFS_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *GridFS) EXPECT() *GridFS_Expecter {
+	return &GridFS_Expecter{mock: &_m.Mock}
+}
+
+// Delete provides a mock function with given fields: id
+func (_m *GridFS) Delete(id interface{}) error {
+	ret := _m.Called(id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(interface{}) error); ok {
+		r0 = rf(id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GridFS_Delete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Delete'
+type GridFS_Delete_Call struct {
+	*mock.Call
+}
+
+// Delete is a helper method to define mock.On call
+//   - id interface{}
+func (_e *GridFS_Expecter) Delete(id interface{}) *GridFS_Delete_Call {
+	return &GridFS_Delete_Call{Call: _e.mock.On("Delete", id)}
+}
+
+func (_c *GridFS_Delete_Call) Run(run func(id interface{})) *GridFS_Delete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(interface{}))
+	})
+	return _c
+}
+
+func (_c *GridFS_Delete_Call) Return(_a0 error) *GridFS_Delete_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *GridFS_Delete_Call) RunAndReturn(run func(interface{}) error) *GridFS_Delete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Download provides a mock function with given fields: id, destination
+func (_m *GridFS) Download(id interface{}, destination io.Writer) (int64, error) {
+	ret := _m.Called(id, destination)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Download")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(interface{}, io.Writer) (int64, error)); ok {
+		return rf(id, destination)
+	}
+	if rf, ok := ret.Get(0).(func(interface{}, io.Writer) int64); ok {
+		r0 = rf(id, destination)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(interface{}, io.Writer) error); ok {
+		r1 = rf(id, destination)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GridFS_Download_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Download'
+type GridFS_Download_Call struct {
+	*mock.Call
+}
+
+// Download is a helper method to define mock.On call
+//   - id interface{}
+//   - destination io.Writer
+func (_e *GridFS_Expecter) Download(id interface{}, destination interface{}) *GridFS_Download_Call {
+	return &GridFS_Download_Call{Call: _e.mock.On("Download", id, destination)}
+}
+
+func (_c *GridFS_Download_Call) Run(run func(id interface{}, destination io.Writer)) *GridFS_Download_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(interface{}), args[1].(io.Writer))
+	})
+	return _c
+}
+
+func (_c *GridFS_Download_Call) Return(_a0 int64, _a1 error) *GridFS_Download_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *GridFS_Download_Call) RunAndReturn(run func(interface{}, io.Writer) (int64, error)) *GridFS_Download_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DownloadByName provides a mock function with given fields: name, destination
+func (_m *GridFS) DownloadByName(name string, destination io.Writer) (int64, error) {
+	ret := _m.Called(name, destination)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DownloadByName")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, io.Writer) (int64, error)); ok {
+		return rf(name, destination)
+	}
+	if rf, ok := ret.Get(0).(func(string, io.Writer) int64); ok {
+		r0 = rf(name, destination)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, io.Writer) error); ok {
+		r1 = rf(name, destination)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GridFS_DownloadByName_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DownloadByName'
+type GridFS_DownloadByName_Call struct {
+	*mock.Call
+}
+
+// DownloadByName is a helper method to define mock.On call
+//   - name string
+//   - destination io.Writer
+func (_e *GridFS_Expecter) DownloadByName(name interface{}, destination interface{}) *GridFS_DownloadByName_Call {
+	return &GridFS_DownloadByName_Call{Call: _e.mock.On("DownloadByName", name, destination)}
+}
+
+func (_c *GridFS_DownloadByName_Call) Run(run func(name string, destination io.Writer)) *GridFS_DownloadByName_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(io.Writer))
+	})
+	return _c
+}
+
+func (_c *GridFS_DownloadByName_Call) Return(_a0 int64, _a1 error) *GridFS_DownloadByName_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *GridFS_DownloadByName_Call) RunAndReturn(run func(string, io.Writer) (int64, error)) *GridFS_DownloadByName_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Drop provides a mock function with no fields
+func (_m *GridFS) Drop() error {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Drop")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func() error); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GridFS_Drop_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Drop'
+type GridFS_Drop_Call struct {
+	*mock.Call
+}
+
+// Drop is a helper method to define mock.On call
+func (_e *GridFS_Expecter) Drop() *GridFS_Drop_Call {
+	return &GridFS_Drop_Call{Call: _e.mock.On("Drop")}
+}
+
+func (_c *GridFS_Drop_Call) Run(run func()) *GridFS_Drop_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *GridFS_Drop_Call) Return(_a0 error) *GridFS_Drop_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *GridFS_Drop_Call) RunAndReturn(run func() error) *GridFS_Drop_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Find provides a mock function with given fields: filter, opts
+func (_m *GridFS) Find(filter interface{}, opts ...interface{}) ([]gridfs.File, error) {
+	var _ca []interface{}
+	_ca = append(_ca, filter)
+	_ca = append(_ca, opts...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Find")
+	}
+
+	var r0 []gridfs.File
+	var r1 error
+	if rf, ok := ret.Get(0).(func(interface{}, ...interface{}) ([]gridfs.File, error)); ok {
+		return rf(filter, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(interface{}, ...interface{}) []gridfs.File); ok {
+		r0 = rf(filter, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]gridfs.File)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(interface{}, ...interface{}) error); ok {
+		r1 = rf(filter, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GridFS_Find_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Find'
+type GridFS_Find_Call struct {
+	*mock.Call
+}
+
+// Find is a helper method to define mock.On call
+//   - filter interface{}
+//   - opts ...interface{}
+func (_e *GridFS_Expecter) Find(filter interface{}, opts ...interface{}) *GridFS_Find_Call {
+	return &GridFS_Find_Call{Call: _e.mock.On("Find",
+		append([]interface{}{filter}, opts...)...)}
+}
+
+func (_c *GridFS_Find_Call) Run(run func(filter interface{}, opts ...interface{})) *GridFS_Find_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]interface{}, len(args)-1)
+		for i, a := range args[1:] {
+			if a != nil {
+				variadicArgs[i] = a.(interface{})
+			}
+		}
+		run(args[0].(interface{}), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *GridFS_Find_Call) Return(_a0 []gridfs.File, _a1 error) *GridFS_Find_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *GridFS_Find_Call) RunAndReturn(run func(interface{}, ...interface{}) ([]gridfs.File, error)) *GridFS_Find_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Name provides a mock function with no fields
+func (_m *GridFS) Name() string {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Name")
+	}
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func() string); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
+// GridFS_Name_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Name'
+type GridFS_Name_Call struct {
+	*mock.Call
+}
+
+// Name is a helper method to define mock.On call
+func (_e *GridFS_Expecter) Name() *GridFS_Name_Call {
+	return &GridFS_Name_Call{Call: _e.mock.On("Name")}
+}
+
+func (_c *GridFS_Name_Call) Run(run func()) *GridFS_Name_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *GridFS_Name_Call) Return(_a0 string) *GridFS_Name_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *GridFS_Name_Call) RunAndReturn(run func() string) *GridFS_Name_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Native provides a mock function with no fields
+func (_m *GridFS) Native() *gridfs.Bucket {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Native")
+	}
+
+	var r0 *gridfs.Bucket
+	if rf, ok := ret.Get(0).(func() *gridfs.Bucket); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*gridfs.Bucket)
+		}
+	}
+
+	return r0
+}
+
+// GridFS_Native_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Native'
+type GridFS_Native_Call struct {
+	*mock.Call
+}
+
+// Native is a helper method to define mock.On call
+func (_e *GridFS_Expecter) Native() *GridFS_Native_Call {
+	return &GridFS_Native_Call{Call: _e.mock.On("Native")}
+}
+
+func (_c *GridFS_Native_Call) Run(run func()) *GridFS_Native_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *GridFS_Native_Call) Return(_a0 *gridfs.Bucket) *GridFS_Native_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *GridFS_Native_Call) RunAndReturn(run func() *gridfs.Bucket) *GridFS_Native_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// OpenDownloadStream provides a mock function with given fields: id
+func (_m *GridFS) OpenDownloadStream(id interface{}) (*gridfs.DownloadStream, error) {
+	ret := _m.Called(id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for OpenDownloadStream")
+	}
+
+	var r0 *gridfs.DownloadStream
+	var r1 error
+	if rf, ok := ret.Get(0).(func(interface{}) (*gridfs.DownloadStream, error)); ok {
+		return rf(id)
+	}
+	if rf, ok := ret.Get(0).(func(interface{}) *gridfs.DownloadStream); ok {
+		r0 = rf(id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*gridfs.DownloadStream)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(interface{}) error); ok {
+		r1 = rf(id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GridFS_OpenDownloadStream_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'OpenDownloadStream'
+type GridFS_OpenDownloadStream_Call struct {
+	*mock.Call
+}
+
+// OpenDownloadStream is a helper method to define mock.On call
+//   - id interface{}
+func (_e *GridFS_Expecter) OpenDownloadStream(id interface{}) *GridFS_OpenDownloadStream_Call {
+	return &GridFS_OpenDownloadStream_Call{Call: _e.mock.On("OpenDownloadStream", id)}
+}
+
+func (_c *GridFS_OpenDownloadStream_Call) Run(run func(id interface{})) *GridFS_OpenDownloadStream_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(interface{}))
+	})
+	return _c
+}
+
+func (_c *GridFS_OpenDownloadStream_Call) Return(_a0 *gridfs.DownloadStream, _a1 error) *GridFS_OpenDownloadStream_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *GridFS_OpenDownloadStream_Call) RunAndReturn(run func(interface{}) (*gridfs.DownloadStream, error)) *GridFS_OpenDownloadStream_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// OpenDownloadStreamByName provides a mock function with given fields: name
+func (_m *GridFS) OpenDownloadStreamByName(name string) (*gridfs.DownloadStream, error) {
+	ret := _m.Called(name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for OpenDownloadStreamByName")
+	}
+
+	var r0 *gridfs.DownloadStream
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (*gridfs.DownloadStream, error)); ok {
+		return rf(name)
+	}
+	if rf, ok := ret.Get(0).(func(string) *gridfs.DownloadStream); ok {
+		r0 = rf(name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*gridfs.DownloadStream)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GridFS_OpenDownloadStreamByName_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'OpenDownloadStreamByName'
+type GridFS_OpenDownloadStreamByName_Call struct {
+	*mock.Call
+}
+
+// OpenDownloadStreamByName is a helper method to define mock.On call
+//   - name string
+func (_e *GridFS_Expecter) OpenDownloadStreamByName(name interface{}) *GridFS_OpenDownloadStreamByName_Call {
+	return &GridFS_OpenDownloadStreamByName_Call{Call: _e.mock.On("OpenDownloadStreamByName", name)}
+}
+
+func (_c *GridFS_OpenDownloadStreamByName_Call) Run(run func(name string)) *GridFS_OpenDownloadStreamByName_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *GridFS_OpenDownloadStreamByName_Call) Return(_a0 *gridfs.DownloadStream, _a1 error) *GridFS_OpenDownloadStreamByName_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *GridFS_OpenDownloadStreamByName_Call) RunAndReturn(run func(string) (*gridfs.DownloadStream, error)) *GridFS_OpenDownloadStreamByName_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Rename provides a mock function with given fields: id, name
+func (_m *GridFS) Rename(id interface{}, name string) error {
+	ret := _m.Called(id, name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Rename")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(interface{}, string) error); ok {
+		r0 = rf(id, name)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GridFS_Rename_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Rename'
+type GridFS_Rename_Call struct {
+	*mock.Call
+}
+
+// Rename is a helper method to define mock.On call
+//   - id interface{}
+//   - name string
+func (_e *GridFS_Expecter) Rename(id interface{}, name interface{}) *GridFS_Rename_Call {
+	return &GridFS_Rename_Call{Call: _e.mock.On("Rename", id, name)}
+}
+
+func (_c *GridFS_Rename_Call) Run(run func(id interface{}, name string)) *GridFS_Rename_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(interface{}), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *GridFS_Rename_Call) Return(_a0 error) *GridFS_Rename_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *GridFS_Rename_Call) RunAndReturn(run func(interface{}, string) error) *GridFS_Rename_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Upload provides a mock function with given fields: name, source, metadata
+func (_m *GridFS) Upload(name string, source io.Reader, metadata interface{}) (primitive.ObjectID, error) {
+	ret := _m.Called(name, source, metadata)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Upload")
+	}
+
+	var r0 primitive.ObjectID
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, io.Reader, interface{}) (primitive.ObjectID, error)); ok {
+		return rf(name, source, metadata)
+	}
+	if rf, ok := ret.Get(0).(func(string, io.Reader, interface{}) primitive.ObjectID); ok {
+		r0 = rf(name, source, metadata)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(primitive.ObjectID)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, io.Reader, interface{}) error); ok {
+		r1 = rf(name, source, metadata)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GridFS_Upload_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Upload'
+type GridFS_Upload_Call struct {
+	*mock.Call
+}
+
+// Upload is a helper method to define mock.On call
+//   - name string
+//   - source io.Reader
+//   - metadata interface{}
+func (_e *GridFS_Expecter) Upload(name interface{}, source interface{}, metadata interface{}) *GridFS_Upload_Call {
+	return &GridFS_Upload_Call{Call: _e.mock.On("Upload", name, source, metadata)}
+}
+
+func (_c *GridFS_Upload_Call) Run(run func(name string, source io.Reader, metadata interface{})) *GridFS_Upload_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(io.Reader), args[2].(interface{}))
+	})
+	return _c
+}
+
+func (_c *GridFS_Upload_Call) Return(_a0 primitive.ObjectID, _a1 error) *GridFS_Upload_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *GridFS_Upload_Call) RunAndReturn(run func(string, io.Reader, interface{}) (primitive.ObjectID, error)) *GridFS_Upload_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// WithContext provides a mock function with given fields: ctx
+func (_m *GridFS) WithContext(ctx context.Context) contracts.GridFS {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for WithContext")
+	}
+
+	var r0 contracts.GridFS
+	if rf, ok := ret.Get(0).(func(context.Context) contracts.GridFS); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(contracts.GridFS)
+		}
+	}
+
+	return r0
+}
+
+// GridFS_WithContext_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'WithContext'
+type GridFS_WithContext_Call struct {
+	*mock.Call
+}
+
+// WithContext is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *GridFS_Expecter) WithContext(ctx interface{}) *GridFS_WithContext_Call {
+	return &GridFS_WithContext_Call{Call: _e.mock.On("WithContext", ctx)}
+}
+
+func (_c *GridFS_WithContext_Call) Run(run func(ctx context.Context)) *GridFS_WithContext_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *GridFS_WithContext_Call) Return(_a0 contracts.GridFS) *GridFS_WithContext_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *GridFS_WithContext_Call) RunAndReturn(run func(context.Context) contracts.GridFS) *GridFS_WithContext_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewGridFS creates a new instance of GridFS. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewGridFS(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *GridFS {
+	mock := &GridFS{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,137 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package contracts
+
+import mock "github.com/stretchr/testify/mock"
+
+// MetricsSink is an autogenerated mock type for the MetricsSink type
+type MetricsSink struct {
+	mock.Mock
+}
+
+type MetricsSink_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MetricsSink) EXPECT() *MetricsSink_Expecter {
+	return &MetricsSink_Expecter{mock: &_m.Mock}
+}
+
+// AddCounter provides a mock function with given fields: name, labels, delta
+func (_m *MetricsSink) AddCounter(name string, labels map[string]string, delta float64) {
+	_m.Called(name, labels, delta)
+}
+
+// MetricsSink_AddCounter_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddCounter'
+type MetricsSink_AddCounter_Call struct {
+	*mock.Call
+}
+
+// AddCounter is a helper method to define mock.On call
+//   - name string
+//   - labels map[string]string
+//   - delta float64
+func (_e *MetricsSink_Expecter) AddCounter(name interface{}, labels interface{}, delta interface{}) *MetricsSink_AddCounter_Call {
+	return &MetricsSink_AddCounter_Call{Call: _e.mock.On("AddCounter", name, labels, delta)}
+}
+
+func (_c *MetricsSink_AddCounter_Call) Run(run func(name string, labels map[string]string, delta float64)) *MetricsSink_AddCounter_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(map[string]string), args[2].(float64))
+	})
+	return _c
+}
+
+func (_c *MetricsSink_AddCounter_Call) Return() *MetricsSink_AddCounter_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MetricsSink_AddCounter_Call) RunAndReturn(run func(string, map[string]string, float64)) *MetricsSink_AddCounter_Call {
+	_c.Run(run)
+	return _c
+}
+
+// AddGauge provides a mock function with given fields: name, labels, delta
+func (_m *MetricsSink) AddGauge(name string, labels map[string]string, delta float64) {
+	_m.Called(name, labels, delta)
+}
+
+// MetricsSink_AddGauge_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddGauge'
+type MetricsSink_AddGauge_Call struct {
+	*mock.Call
+}
+
+// AddGauge is a helper method to define mock.On call
+//   - name string
+//   - labels map[string]string
+//   - delta float64
+func (_e *MetricsSink_Expecter) AddGauge(name interface{}, labels interface{}, delta interface{}) *MetricsSink_AddGauge_Call {
+	return &MetricsSink_AddGauge_Call{Call: _e.mock.On("AddGauge", name, labels, delta)}
+}
+
+func (_c *MetricsSink_AddGauge_Call) Run(run func(name string, labels map[string]string, delta float64)) *MetricsSink_AddGauge_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(map[string]string), args[2].(float64))
+	})
+	return _c
+}
+
+func (_c *MetricsSink_AddGauge_Call) Return() *MetricsSink_AddGauge_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MetricsSink_AddGauge_Call) RunAndReturn(run func(string, map[string]string, float64)) *MetricsSink_AddGauge_Call {
+	_c.Run(run)
+	return _c
+}
+
+// ObserveHistogram provides a mock function with given fields: name, labels, value
+func (_m *MetricsSink) ObserveHistogram(name string, labels map[string]string, value float64) {
+	_m.Called(name, labels, value)
+}
+
+// MetricsSink_ObserveHistogram_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ObserveHistogram'
+type MetricsSink_ObserveHistogram_Call struct {
+	*mock.Call
+}
+
+// ObserveHistogram is a helper method to define mock.On call
+//   - name string
+//   - labels map[string]string
+//   - value float64
+func (_e *MetricsSink_Expecter) ObserveHistogram(name interface{}, labels interface{}, value interface{}) *MetricsSink_ObserveHistogram_Call {
+	return &MetricsSink_ObserveHistogram_Call{Call: _e.mock.On("ObserveHistogram", name, labels, value)}
+}
+
+func (_c *MetricsSink_ObserveHistogram_Call) Run(run func(name string, labels map[string]string, value float64)) *MetricsSink_ObserveHistogram_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(map[string]string), args[2].(float64))
+	})
+	return _c
+}
+
+func (_c *MetricsSink_ObserveHistogram_Call) Return() *MetricsSink_ObserveHistogram_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MetricsSink_ObserveHistogram_Call) RunAndReturn(run func(string, map[string]string, float64)) *MetricsSink_ObserveHistogram_Call {
+	_c.Run(run)
+	return _c
+}
+
+// NewMetricsSink creates a new instance of MetricsSink. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMetricsSink(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MetricsSink {
+	mock := &MetricsSink{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
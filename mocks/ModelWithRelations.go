@@ -0,0 +1,82 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package contracts
+
+import (
+	contracts "github.com/portofolio-mager/goravel-mongodb/contracts"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// ModelWithRelations is an autogenerated mock type for the ModelWithRelations type
+type ModelWithRelations struct {
+	mock.Mock
+}
+
+type ModelWithRelations_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *ModelWithRelations) EXPECT() *ModelWithRelations_Expecter {
+	return &ModelWithRelations_Expecter{mock: &_m.Mock}
+}
+
+// Relations provides a mock function with no fields
+func (_m *ModelWithRelations) Relations() map[string]contracts.Relation {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Relations")
+	}
+
+	var r0 map[string]contracts.Relation
+	if rf, ok := ret.Get(0).(func() map[string]contracts.Relation); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]contracts.Relation)
+		}
+	}
+
+	return r0
+}
+
+// ModelWithRelations_Relations_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Relations'
+type ModelWithRelations_Relations_Call struct {
+	*mock.Call
+}
+
+// Relations is a helper method to define mock.On call
+func (_e *ModelWithRelations_Expecter) Relations() *ModelWithRelations_Relations_Call {
+	return &ModelWithRelations_Relations_Call{Call: _e.mock.On("Relations")}
+}
+
+func (_c *ModelWithRelations_Relations_Call) Run(run func()) *ModelWithRelations_Relations_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *ModelWithRelations_Relations_Call) Return(_a0 map[string]contracts.Relation) *ModelWithRelations_Relations_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *ModelWithRelations_Relations_Call) RunAndReturn(run func() map[string]contracts.Relation) *ModelWithRelations_Relations_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewModelWithRelations creates a new instance of ModelWithRelations. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewModelWithRelations(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ModelWithRelations {
+	mock := &ModelWithRelations{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
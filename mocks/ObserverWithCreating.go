@@ -0,0 +1,81 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package contracts
+
+import (
+	contracts "github.com/portofolio-mager/goravel-mongodb/contracts"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// ObserverWithCreating is an autogenerated mock type for the ObserverWithCreating type
+type ObserverWithCreating struct {
+	mock.Mock
+}
+
+type ObserverWithCreating_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *ObserverWithCreating) EXPECT() *ObserverWithCreating_Expecter {
+	return &ObserverWithCreating_Expecter{mock: &_m.Mock}
+}
+
+// Creating provides a mock function with given fields: _a0
+func (_m *ObserverWithCreating) Creating(_a0 contracts.Event) error {
+	ret := _m.Called(_a0)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Creating")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(contracts.Event) error); ok {
+		r0 = rf(_a0)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ObserverWithCreating_Creating_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Creating'
+type ObserverWithCreating_Creating_Call struct {
+	*mock.Call
+}
+
+// Creating is a helper method to define mock.On call
+//   - _a0 contracts.Event
+func (_e *ObserverWithCreating_Expecter) Creating(_a0 interface{}) *ObserverWithCreating_Creating_Call {
+	return &ObserverWithCreating_Creating_Call{Call: _e.mock.On("Creating", _a0)}
+}
+
+func (_c *ObserverWithCreating_Creating_Call) Run(run func(_a0 contracts.Event)) *ObserverWithCreating_Creating_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(contracts.Event))
+	})
+	return _c
+}
+
+func (_c *ObserverWithCreating_Creating_Call) Return(_a0 error) *ObserverWithCreating_Creating_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *ObserverWithCreating_Creating_Call) RunAndReturn(run func(contracts.Event) error) *ObserverWithCreating_Creating_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewObserverWithCreating creates a new instance of ObserverWithCreating. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewObserverWithCreating(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ObserverWithCreating {
+	mock := &ObserverWithCreating{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
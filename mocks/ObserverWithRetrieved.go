@@ -0,0 +1,81 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package contracts
+
+import (
+	contracts "github.com/portofolio-mager/goravel-mongodb/contracts"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// ObserverWithRetrieved is an autogenerated mock type for the ObserverWithRetrieved type
+type ObserverWithRetrieved struct {
+	mock.Mock
+}
+
+type ObserverWithRetrieved_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *ObserverWithRetrieved) EXPECT() *ObserverWithRetrieved_Expecter {
+	return &ObserverWithRetrieved_Expecter{mock: &_m.Mock}
+}
+
+// Retrieved provides a mock function with given fields: _a0
+func (_m *ObserverWithRetrieved) Retrieved(_a0 contracts.Event) error {
+	ret := _m.Called(_a0)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Retrieved")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(contracts.Event) error); ok {
+		r0 = rf(_a0)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ObserverWithRetrieved_Retrieved_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Retrieved'
+type ObserverWithRetrieved_Retrieved_Call struct {
+	*mock.Call
+}
+
+// Retrieved is a helper method to define mock.On call
+//   - _a0 contracts.Event
+func (_e *ObserverWithRetrieved_Expecter) Retrieved(_a0 interface{}) *ObserverWithRetrieved_Retrieved_Call {
+	return &ObserverWithRetrieved_Retrieved_Call{Call: _e.mock.On("Retrieved", _a0)}
+}
+
+func (_c *ObserverWithRetrieved_Retrieved_Call) Run(run func(_a0 contracts.Event)) *ObserverWithRetrieved_Retrieved_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(contracts.Event))
+	})
+	return _c
+}
+
+func (_c *ObserverWithRetrieved_Retrieved_Call) Return(_a0 error) *ObserverWithRetrieved_Retrieved_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *ObserverWithRetrieved_Retrieved_Call) RunAndReturn(run func(contracts.Event) error) *ObserverWithRetrieved_Retrieved_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewObserverWithRetrieved creates a new instance of ObserverWithRetrieved. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewObserverWithRetrieved(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ObserverWithRetrieved {
+	mock := &ObserverWithRetrieved{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
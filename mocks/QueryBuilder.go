@@ -3,6 +3,8 @@
 package contracts
 
 import (
+	context "context"
+
 	contracts "github.com/portofolio-mager/goravel-mongodb/contracts"
 	mock "github.com/stretchr/testify/mock"
 )
@@ -75,6 +77,61 @@ func (_c *QueryBuilder_Count_Call) RunAndReturn(run func() (int64, error)) *Quer
 	return _c
 }
 
+// Delete provides a mock function with no fields
+func (_m *QueryBuilder) Delete() (int64, error) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func() (int64, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() int64); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// QueryBuilder_Delete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Delete'
+type QueryBuilder_Delete_Call struct {
+	*mock.Call
+}
+
+// Delete is a helper method to define mock.On call
+func (_e *QueryBuilder_Expecter) Delete() *QueryBuilder_Delete_Call {
+	return &QueryBuilder_Delete_Call{Call: _e.mock.On("Delete")}
+}
+
+func (_c *QueryBuilder_Delete_Call) Run(run func()) *QueryBuilder_Delete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *QueryBuilder_Delete_Call) Return(_a0 int64, _a1 error) *QueryBuilder_Delete_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *QueryBuilder_Delete_Call) RunAndReturn(run func() (int64, error)) *QueryBuilder_Delete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Find provides a mock function with given fields: results
 func (_m *QueryBuilder) Find(results interface{}) error {
 	ret := _m.Called(results)
@@ -167,6 +224,61 @@ func (_c *QueryBuilder_First_Call) RunAndReturn(run func(interface{}) error) *Qu
 	return _c
 }
 
+// ForceDelete provides a mock function with no fields
+func (_m *QueryBuilder) ForceDelete() (int64, error) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for ForceDelete")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func() (int64, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() int64); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// QueryBuilder_ForceDelete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ForceDelete'
+type QueryBuilder_ForceDelete_Call struct {
+	*mock.Call
+}
+
+// ForceDelete is a helper method to define mock.On call
+func (_e *QueryBuilder_Expecter) ForceDelete() *QueryBuilder_ForceDelete_Call {
+	return &QueryBuilder_ForceDelete_Call{Call: _e.mock.On("ForceDelete")}
+}
+
+func (_c *QueryBuilder_ForceDelete_Call) Run(run func()) *QueryBuilder_ForceDelete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *QueryBuilder_ForceDelete_Call) Return(_a0 int64, _a1 error) *QueryBuilder_ForceDelete_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *QueryBuilder_ForceDelete_Call) RunAndReturn(run func() (int64, error)) *QueryBuilder_ForceDelete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Limit provides a mock function with given fields: limit
 func (_m *QueryBuilder) Limit(limit int64) contracts.QueryBuilder {
 	ret := _m.Called(limit)
@@ -215,6 +327,169 @@ func (_c *QueryBuilder_Limit_Call) RunAndReturn(run func(int64) contracts.QueryB
 	return _c
 }
 
+// OnlyTrashed provides a mock function with no fields
+func (_m *QueryBuilder) OnlyTrashed() contracts.QueryBuilder {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for OnlyTrashed")
+	}
+
+	var r0 contracts.QueryBuilder
+	if rf, ok := ret.Get(0).(func() contracts.QueryBuilder); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(contracts.QueryBuilder)
+		}
+	}
+
+	return r0
+}
+
+// QueryBuilder_OnlyTrashed_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'OnlyTrashed'
+type QueryBuilder_OnlyTrashed_Call struct {
+	*mock.Call
+}
+
+// OnlyTrashed is a helper method to define mock.On call
+func (_e *QueryBuilder_Expecter) OnlyTrashed() *QueryBuilder_OnlyTrashed_Call {
+	return &QueryBuilder_OnlyTrashed_Call{Call: _e.mock.On("OnlyTrashed")}
+}
+
+func (_c *QueryBuilder_OnlyTrashed_Call) Run(run func()) *QueryBuilder_OnlyTrashed_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *QueryBuilder_OnlyTrashed_Call) Return(_a0 contracts.QueryBuilder) *QueryBuilder_OnlyTrashed_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *QueryBuilder_OnlyTrashed_Call) RunAndReturn(run func() contracts.QueryBuilder) *QueryBuilder_OnlyTrashed_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Restore provides a mock function with no fields
+func (_m *QueryBuilder) Restore() (int64, error) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Restore")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func() (int64, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() int64); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// QueryBuilder_Restore_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Restore'
+type QueryBuilder_Restore_Call struct {
+	*mock.Call
+}
+
+// Restore is a helper method to define mock.On call
+func (_e *QueryBuilder_Expecter) Restore() *QueryBuilder_Restore_Call {
+	return &QueryBuilder_Restore_Call{Call: _e.mock.On("Restore")}
+}
+
+func (_c *QueryBuilder_Restore_Call) Run(run func()) *QueryBuilder_Restore_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *QueryBuilder_Restore_Call) Return(_a0 int64, _a1 error) *QueryBuilder_Restore_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *QueryBuilder_Restore_Call) RunAndReturn(run func() (int64, error)) *QueryBuilder_Restore_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Scopes provides a mock function with given fields: scopes
+func (_m *QueryBuilder) Scopes(scopes ...func(contracts.QueryBuilder)) contracts.QueryBuilder {
+	_va := make([]interface{}, len(scopes))
+	for _i := range scopes {
+		_va[_i] = scopes[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Scopes")
+	}
+
+	var r0 contracts.QueryBuilder
+	if rf, ok := ret.Get(0).(func(...func(contracts.QueryBuilder)) contracts.QueryBuilder); ok {
+		r0 = rf(scopes...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(contracts.QueryBuilder)
+		}
+	}
+
+	return r0
+}
+
+// QueryBuilder_Scopes_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Scopes'
+type QueryBuilder_Scopes_Call struct {
+	*mock.Call
+}
+
+// Scopes is a helper method to define mock.On call
+//   - scopes ...func(contracts.QueryBuilder)
+func (_e *QueryBuilder_Expecter) Scopes(scopes ...interface{}) *QueryBuilder_Scopes_Call {
+	return &QueryBuilder_Scopes_Call{Call: _e.mock.On("Scopes",
+		append([]interface{}{}, scopes...)...)}
+}
+
+func (_c *QueryBuilder_Scopes_Call) Run(run func(scopes ...func(contracts.QueryBuilder))) *QueryBuilder_Scopes_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]func(contracts.QueryBuilder), len(args)-0)
+		for i, a := range args[0:] {
+			if a != nil {
+				variadicArgs[i] = a.(func(contracts.QueryBuilder))
+			}
+		}
+		run(variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *QueryBuilder_Scopes_Call) Return(_a0 contracts.QueryBuilder) *QueryBuilder_Scopes_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *QueryBuilder_Scopes_Call) RunAndReturn(run func(...func(contracts.QueryBuilder)) contracts.QueryBuilder) *QueryBuilder_Scopes_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Select provides a mock function with given fields: fields
 func (_m *QueryBuilder) Select(fields ...string) contracts.QueryBuilder {
 	_va := make([]interface{}, len(fields))
@@ -373,6 +648,62 @@ func (_c *QueryBuilder_Sort_Call) RunAndReturn(run func(string, int) contracts.Q
 	return _c
 }
 
+// Update provides a mock function with given fields: update
+func (_m *QueryBuilder) Update(update interface{}) (int64, error) {
+	ret := _m.Called(update)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Update")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(interface{}) (int64, error)); ok {
+		return rf(update)
+	}
+	if rf, ok := ret.Get(0).(func(interface{}) int64); ok {
+		r0 = rf(update)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(interface{}) error); ok {
+		r1 = rf(update)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// QueryBuilder_Update_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Update'
+type QueryBuilder_Update_Call struct {
+	*mock.Call
+}
+
+// Update is a helper method to define mock.On call
+//   - update interface{}
+func (_e *QueryBuilder_Expecter) Update(update interface{}) *QueryBuilder_Update_Call {
+	return &QueryBuilder_Update_Call{Call: _e.mock.On("Update", update)}
+}
+
+func (_c *QueryBuilder_Update_Call) Run(run func(update interface{})) *QueryBuilder_Update_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(interface{}))
+	})
+	return _c
+}
+
+func (_c *QueryBuilder_Update_Call) Return(_a0 int64, _a1 error) *QueryBuilder_Update_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *QueryBuilder_Update_Call) RunAndReturn(run func(interface{}) (int64, error)) *QueryBuilder_Update_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Where provides a mock function with given fields: field, value
 func (_m *QueryBuilder) Where(field string, value interface{}) contracts.QueryBuilder {
 	ret := _m.Called(field, value)
@@ -925,6 +1256,223 @@ func (_c *QueryBuilder_WhereRegex_Call) RunAndReturn(run func(string, string, ..
 	return _c
 }
 
+// With provides a mock function with given fields: relations
+func (_m *QueryBuilder) With(relations ...string) contracts.QueryBuilder {
+	_va := make([]interface{}, len(relations))
+	for _i := range relations {
+		_va[_i] = relations[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for With")
+	}
+
+	var r0 contracts.QueryBuilder
+	if rf, ok := ret.Get(0).(func(...string) contracts.QueryBuilder); ok {
+		r0 = rf(relations...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(contracts.QueryBuilder)
+		}
+	}
+
+	return r0
+}
+
+// QueryBuilder_With_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'With'
+type QueryBuilder_With_Call struct {
+	*mock.Call
+}
+
+// With is a helper method to define mock.On call
+//   - relations ...string
+func (_e *QueryBuilder_Expecter) With(relations ...interface{}) *QueryBuilder_With_Call {
+	return &QueryBuilder_With_Call{Call: _e.mock.On("With",
+		append([]interface{}{}, relations...)...)}
+}
+
+func (_c *QueryBuilder_With_Call) Run(run func(relations ...string)) *QueryBuilder_With_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]string, len(args)-0)
+		for i, a := range args[0:] {
+			if a != nil {
+				variadicArgs[i] = a.(string)
+			}
+		}
+		run(variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *QueryBuilder_With_Call) Return(_a0 contracts.QueryBuilder) *QueryBuilder_With_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *QueryBuilder_With_Call) RunAndReturn(run func(...string) contracts.QueryBuilder) *QueryBuilder_With_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// WithContext provides a mock function with given fields: ctx
+func (_m *QueryBuilder) WithContext(ctx context.Context) contracts.QueryBuilder {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for WithContext")
+	}
+
+	var r0 contracts.QueryBuilder
+	if rf, ok := ret.Get(0).(func(context.Context) contracts.QueryBuilder); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(contracts.QueryBuilder)
+		}
+	}
+
+	return r0
+}
+
+// QueryBuilder_WithContext_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'WithContext'
+type QueryBuilder_WithContext_Call struct {
+	*mock.Call
+}
+
+// WithContext is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *QueryBuilder_Expecter) WithContext(ctx interface{}) *QueryBuilder_WithContext_Call {
+	return &QueryBuilder_WithContext_Call{Call: _e.mock.On("WithContext", ctx)}
+}
+
+func (_c *QueryBuilder_WithContext_Call) Run(run func(ctx context.Context)) *QueryBuilder_WithContext_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *QueryBuilder_WithContext_Call) Return(_a0 contracts.QueryBuilder) *QueryBuilder_WithContext_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *QueryBuilder_WithContext_Call) RunAndReturn(run func(context.Context) contracts.QueryBuilder) *QueryBuilder_WithContext_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// WithTrashed provides a mock function with no fields
+func (_m *QueryBuilder) WithTrashed() contracts.QueryBuilder {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for WithTrashed")
+	}
+
+	var r0 contracts.QueryBuilder
+	if rf, ok := ret.Get(0).(func() contracts.QueryBuilder); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(contracts.QueryBuilder)
+		}
+	}
+
+	return r0
+}
+
+// QueryBuilder_WithTrashed_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'WithTrashed'
+type QueryBuilder_WithTrashed_Call struct {
+	*mock.Call
+}
+
+// WithTrashed is a helper method to define mock.On call
+func (_e *QueryBuilder_Expecter) WithTrashed() *QueryBuilder_WithTrashed_Call {
+	return &QueryBuilder_WithTrashed_Call{Call: _e.mock.On("WithTrashed")}
+}
+
+func (_c *QueryBuilder_WithTrashed_Call) Run(run func()) *QueryBuilder_WithTrashed_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *QueryBuilder_WithTrashed_Call) Return(_a0 contracts.QueryBuilder) *QueryBuilder_WithTrashed_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *QueryBuilder_WithTrashed_Call) RunAndReturn(run func() contracts.QueryBuilder) *QueryBuilder_WithTrashed_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// WithoutGlobalScope provides a mock function with given fields: names
+func (_m *QueryBuilder) WithoutGlobalScope(names ...string) contracts.QueryBuilder {
+	_va := make([]interface{}, len(names))
+	for _i := range names {
+		_va[_i] = names[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for WithoutGlobalScope")
+	}
+
+	var r0 contracts.QueryBuilder
+	if rf, ok := ret.Get(0).(func(...string) contracts.QueryBuilder); ok {
+		r0 = rf(names...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(contracts.QueryBuilder)
+		}
+	}
+
+	return r0
+}
+
+// QueryBuilder_WithoutGlobalScope_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'WithoutGlobalScope'
+type QueryBuilder_WithoutGlobalScope_Call struct {
+	*mock.Call
+}
+
+// WithoutGlobalScope is a helper method to define mock.On call
+//   - names ...string
+func (_e *QueryBuilder_Expecter) WithoutGlobalScope(names ...interface{}) *QueryBuilder_WithoutGlobalScope_Call {
+	return &QueryBuilder_WithoutGlobalScope_Call{Call: _e.mock.On("WithoutGlobalScope",
+		append([]interface{}{}, names...)...)}
+}
+
+func (_c *QueryBuilder_WithoutGlobalScope_Call) Run(run func(names ...string)) *QueryBuilder_WithoutGlobalScope_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]string, len(args)-0)
+		for i, a := range args[0:] {
+			if a != nil {
+				variadicArgs[i] = a.(string)
+			}
+		}
+		run(variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *QueryBuilder_WithoutGlobalScope_Call) Return(_a0 contracts.QueryBuilder) *QueryBuilder_WithoutGlobalScope_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *QueryBuilder_WithoutGlobalScope_Call) RunAndReturn(run func(...string) contracts.QueryBuilder) *QueryBuilder_WithoutGlobalScope_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NewQueryBuilder creates a new instance of QueryBuilder. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewQueryBuilder(t interface {
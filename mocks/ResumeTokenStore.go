@@ -0,0 +1,141 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package contracts
+
+import (
+	bson "go.mongodb.org/mongo-driver/bson"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// ResumeTokenStore is an autogenerated mock type for the ResumeTokenStore type
+type ResumeTokenStore struct {
+	mock.Mock
+}
+
+type ResumeTokenStore_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *ResumeTokenStore) EXPECT() *ResumeTokenStore_Expecter {
+	return &ResumeTokenStore_Expecter{mock: &_m.Mock}
+}
+
+// Get provides a mock function with given fields: key
+func (_m *ResumeTokenStore) Get(key string) (bson.Raw, error) {
+	ret := _m.Called(key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Get")
+	}
+
+	var r0 bson.Raw
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (bson.Raw, error)); ok {
+		return rf(key)
+	}
+	if rf, ok := ret.Get(0).(func(string) bson.Raw); ok {
+		r0 = rf(key)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(bson.Raw)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(key)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ResumeTokenStore_Get_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Get'
+type ResumeTokenStore_Get_Call struct {
+	*mock.Call
+}
+
+// Get is a helper method to define mock.On call
+//   - key string
+func (_e *ResumeTokenStore_Expecter) Get(key interface{}) *ResumeTokenStore_Get_Call {
+	return &ResumeTokenStore_Get_Call{Call: _e.mock.On("Get", key)}
+}
+
+func (_c *ResumeTokenStore_Get_Call) Run(run func(key string)) *ResumeTokenStore_Get_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *ResumeTokenStore_Get_Call) Return(_a0 bson.Raw, _a1 error) *ResumeTokenStore_Get_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ResumeTokenStore_Get_Call) RunAndReturn(run func(string) (bson.Raw, error)) *ResumeTokenStore_Get_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Put provides a mock function with given fields: key, token
+func (_m *ResumeTokenStore) Put(key string, token bson.Raw) error {
+	ret := _m.Called(key, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Put")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, bson.Raw) error); ok {
+		r0 = rf(key, token)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ResumeTokenStore_Put_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Put'
+type ResumeTokenStore_Put_Call struct {
+	*mock.Call
+}
+
+// Put is a helper method to define mock.On call
+//   - key string
+//   - token bson.Raw
+func (_e *ResumeTokenStore_Expecter) Put(key interface{}, token interface{}) *ResumeTokenStore_Put_Call {
+	return &ResumeTokenStore_Put_Call{Call: _e.mock.On("Put", key, token)}
+}
+
+func (_c *ResumeTokenStore_Put_Call) Run(run func(key string, token bson.Raw)) *ResumeTokenStore_Put_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(bson.Raw))
+	})
+	return _c
+}
+
+func (_c *ResumeTokenStore_Put_Call) Return(_a0 error) *ResumeTokenStore_Put_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *ResumeTokenStore_Put_Call) RunAndReturn(run func(string, bson.Raw) error) *ResumeTokenStore_Put_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewResumeTokenStore creates a new instance of ResumeTokenStore. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewResumeTokenStore(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ResumeTokenStore {
+	mock := &ResumeTokenStore{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
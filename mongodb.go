@@ -3,6 +3,7 @@ package mongodb
 import (
 	"context"
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/goravel/framework/contracts/config"
@@ -11,6 +12,8 @@ import (
 	"github.com/goravel/framework/contracts/log"
 	"github.com/goravel/framework/contracts/testing/docker"
 	"github.com/goravel/framework/errors"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/event"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 	"gorm.io/gorm"
@@ -25,29 +28,60 @@ type MongoDB struct {
 	config contracts.ConfigBuilder
 	client *mongo.Client
 	log    log.Log
+	pool   *poolMonitor
+
+	// scopes and observers are the global scopes, the soft deletes and the
+	// observers of the collections of this client
+	scopes    *scopeRegistry
+	observers *observerRegistry
+	// seeders are the seeders of the mongodb:seed command, the service
+	// provider shares them between the clients of its connections
+	seeders *seederRegistry
+
+	// mu guards the client, the instance is shared by the facades, the ORM,
+	// the health checks and the commands of a connection
+	mu sync.Mutex
+
+	// ctx is the parent of the contexts of the operations and shared is the
+	// client connecting, both are set by WithContext
+	ctx    context.Context
+	shared *MongoDB
 }
 
 func NewMongoDB(config config.Config, log log.Log, connection string) *MongoDB {
 	return &MongoDB{
-		config: NewConfig(config, connection),
-		log:    log,
+		config:    NewConfig(config, connection),
+		log:       log,
+		scopes:    newScopeRegistry(),
+		observers: newObserverRegistry(),
+		seeders:   newSeederRegistry(),
 	}
 }
 
-func (m *MongoDB) connect() error {
+// connect returns the client of the connection and the monitor of its pool,
+// connecting on first use. The callers use the returned values only, as Close
+// may reset the fields at any time.
+func (m *MongoDB) connect() (*mongo.Client, *poolMonitor, error) {
+	if m.shared != nil {
+		return m.shared.connect()
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
 	if m.client != nil {
-		return nil
+		return m.client, m.pool, nil
 	}
 
 	writers := m.config.Writers()
 	if len(writers) == 0 {
-		return errors.DatabaseConfigNotFound
+		return nil, nil, errors.DatabaseConfigNotFound
 	}
 
 	fullConfig := writers[0]
 	uri := fullConfig.URI
 	if uri == "" {
-		return fmt.Errorf("MongoDB URI is required")
+		return nil, nil, fmt.Errorf("MongoDB URI is required")
 	}
 
 	clientOptions := options.Client().ApplyURI(uri)
@@ -65,13 +99,37 @@ func (m *MongoDB) connect() error {
 	}
 
 	// Apply connection pool settings
+	var maxPoolSize uint64
 	if fullConfig.MaxPoolSize != nil {
 		clientOptions.SetMaxPoolSize(*fullConfig.MaxPoolSize)
+		maxPoolSize = *fullConfig.MaxPoolSize
+	} else if clientOptions.MaxPoolSize != nil {
+		maxPoolSize = *clientOptions.MaxPoolSize
 	}
 	if fullConfig.MinPoolSize != nil {
 		clientOptions.SetMinPoolSize(*fullConfig.MinPoolSize)
 	}
 
+	// Monitor the pool for the health checks, and the commands for the sink
+	// of the metrics, the log and the traces
+	sink := m.metrics()
+	pool := newPoolMonitor(maxPoolSize, m.config.Connection(), sink)
+	clientOptions.SetPoolMonitor(pool.monitor())
+
+	var monitors []*event.CommandMonitor
+	if sink != nil {
+		monitors = append(monitors, newCommandMonitor(sink, m.config.Connection()).monitor())
+	}
+	if m.log != nil {
+		monitors = append(monitors, m.commandLogger().monitor())
+	}
+	if fullConfig.Tracing {
+		monitors = append(monitors, newCommandTracer(fullConfig.TracerProvider).monitor())
+	}
+	if monitor := combineCommandMonitors(monitors...); monitor != nil {
+		clientOptions.SetMonitor(monitor)
+	}
+
 	// Apply timeouts
 	if fullConfig.ConnectTimeout != nil {
 		clientOptions.SetConnectTimeout(time.Duration(*fullConfig.ConnectTimeout) * time.Second)
@@ -83,18 +141,19 @@ func (m *MongoDB) connect() error {
 	// Create client
 	client, err := mongo.Connect(context.TODO(), clientOptions)
 	if err != nil {
-		return fmt.Errorf("failed to connect to MongoDB: %w", err)
+		return nil, nil, fmt.Errorf("failed to connect to MongoDB: %w", err)
 	}
 
 	// Test connection
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 	if err := client.Ping(ctx, nil); err != nil {
-		return fmt.Errorf("failed to ping MongoDB: %w", err)
+		return nil, nil, fmt.Errorf("failed to ping MongoDB: %w", err)
 	}
 
 	m.client = client
-	return nil
+	m.pool = pool
+	return client, pool, nil
 }
 
 // Driver interface implementation (required for Goravel)
@@ -104,7 +163,7 @@ func (m *MongoDB) Docker() (docker.DatabaseDriver, error) {
 		return nil, errors.DatabaseConfigNotFound
 	}
 
-	return NewDocker(writers[0].Database), nil
+	return NewDocker(writers[0].Database, writers[0].Username, writers[0].Password), nil
 }
 
 func (m *MongoDB) Grammar() driver.Grammar {
@@ -123,15 +182,17 @@ func (m *MongoDB) Processor() driver.Processor {
 
 // Client interface implementation (MongoDB-specific)
 func (m *MongoDB) Native() *mongo.Client {
-	if err := m.connect(); err != nil {
+	client, _, err := m.connect()
+	if err != nil {
 		m.log.Errorf("Failed to connect to MongoDB: %v", err)
 		return nil
 	}
-	return m.client
+	return client
 }
 
 func (m *MongoDB) Database(name ...string) contracts.Database {
-	if err := m.connect(); err != nil {
+	client, _, err := m.connect()
+	if err != nil {
 		m.log.Errorf("Failed to connect to MongoDB: %v", err)
 		return nil
 	}
@@ -151,7 +212,43 @@ func (m *MongoDB) Database(name ...string) contracts.Database {
 		return nil
 	}
 
-	return NewDatabase(m.client, m.config, dbName)
+	database := NewDatabase(client, m.config, dbName)
+	if m.scopes != nil {
+		database.scopes = m.scopes
+	}
+	if m.observers != nil {
+		database.observers = m.observers
+	}
+	database.ctx = m.ctx
+
+	return database
+}
+
+// ListDatabases returns the databases of the deployment with their size on disk
+func (m *MongoDB) ListDatabases() ([]contracts.DatabaseInfo, error) {
+	client, _, err := m.connect()
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := operationContext(m.ctx, 30*time.Second)
+	defer cancel()
+
+	result, err := client.ListDatabases(ctx, bson.D{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list MongoDB databases: %w", err)
+	}
+
+	databases := make([]contracts.DatabaseInfo, len(result.Databases))
+	for i, database := range result.Databases {
+		databases[i] = contracts.DatabaseInfo{
+			Name:       database.Name,
+			SizeOnDisk: database.SizeOnDisk,
+			Empty:      database.Empty,
+		}
+	}
+
+	return databases, nil
 }
 
 func (m *MongoDB) Collection(collection string, database ...string) contracts.Collection {
@@ -162,24 +259,83 @@ func (m *MongoDB) Collection(collection string, database ...string) contracts.Co
 	return db.Collection(collection)
 }
 
+// Watch opens a change stream on the whole deployment, see Collection.Watch for the options
+func (m *MongoDB) Watch(pipeline interface{}, opts ...interface{}) (contracts.ChangeStream, error) {
+	client, _, err := m.connect()
+	if err != nil {
+		return nil, err
+	}
+
+	stream, err := watch(m.ctx, client, pipeline, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return stream, nil
+}
+
 func (m *MongoDB) Ping() error {
-	if err := m.connect(); err != nil {
+	client, _, err := m.connect()
+	if err != nil {
 		return err
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	ctx, cancel := operationContext(m.ctx, 5*time.Second)
 	defer cancel()
-	return m.client.Ping(ctx, nil)
+	return client.Ping(ctx, nil)
+}
+
+// WithContext returns the client running its operations with ctx, the spans
+// of its commands are children of the span of ctx. It shares the connection,
+// the scopes, the observers and the seeders of m.
+func (m *MongoDB) WithContext(ctx context.Context) contracts.Client {
+	shared := m
+	if m.shared != nil {
+		shared = m.shared
+	}
+
+	return &MongoDB{
+		config:    m.config,
+		log:       m.log,
+		scopes:    m.scopes,
+		observers: m.observers,
+		seeders:   m.seeders,
+		ctx:       ctx,
+		shared:    shared,
+	}
+}
+
+func (m *MongoDB) RegisterSeeders(seeders ...contracts.Seeder) {
+	m.seeders.register(seeders...)
+}
+
+func (m *MongoDB) Seeder(signature string) contracts.Seeder {
+	return m.seeders.get(signature)
 }
 
+func (m *MongoDB) Seeders() []contracts.Seeder {
+	return m.seeders.all()
+}
+
+// Close disconnects the client, the next call connects again
 func (m *MongoDB) Close() error {
-	if m.client == nil {
+	if m.shared != nil {
+		return m.shared.Close()
+	}
+
+	m.mu.Lock()
+	client := m.client
+	m.client = nil
+	m.pool = nil
+	m.mu.Unlock()
+
+	if client == nil {
 		return nil
 	}
 
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
-	return m.client.Disconnect(ctx)
+	return client.Disconnect(ctx)
 }
 
 // fullConfigToDialector creates a GORM dialector for MongoDB (similar to PostgreSQL implementation)
@@ -189,7 +345,28 @@ func (m *MongoDB) fullConfigToDialector(config contracts.FullConfig) gorm.Dialec
 	if dsn == "" {
 		dsn = "mongodb://localhost:27017/" + config.Database
 	}
-	return Open(dsn)
+
+	// Share the client of the driver, it applies the credentials and pool settings
+	return &Dialector{
+		DSN:      dsn,
+		Database: config.Database,
+		Strict:   m.strict(),
+		log:      m.log,
+		client: func() (*mongo.Client, error) {
+			client, _, err := m.connect()
+			return client, err
+		},
+	}
+}
+
+// strict reports whether database.connections.<connection>.strict makes the SQL
+// statements of facades.DB() and raw ORM queries fail
+func (m *MongoDB) strict() bool {
+	if m.config == nil || m.config.Config() == nil {
+		return false
+	}
+
+	return m.config.Config().GetBool(fmt.Sprintf("database.connections.%s.strict", m.config.Connection()), false)
 }
 
 func (m *MongoDB) fullConfigsToConfigs(fullConfigs []contracts.FullConfig) []database.Config {
@@ -204,3 +381,13 @@ func (m *MongoDB) fullConfigsToConfigs(fullConfigs []contracts.FullConfig) []dat
 	}
 	return configs
 }
+
+// operationContext returns the context of an operation bounded by timeout, ctx
+// is its parent unless it is nil
+func operationContext(ctx context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	return context.WithTimeout(ctx, timeout)
+}
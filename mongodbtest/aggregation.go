@@ -0,0 +1,248 @@
+package mongodbtest
+
+import (
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	"github.com/portofolio-mager/goravel-mongodb/contracts"
+)
+
+var _ contracts.Aggregation = &Aggregation{}
+
+// Aggregation runs the stages supported by the server's aggregate command
+type Aggregation struct {
+	collection *Collection
+	pipeline   interface{}
+}
+
+func (a *Aggregation) All(results interface{}) error {
+	pipeline, err := toPipeline(a.pipeline)
+	if err != nil {
+		return err
+	}
+
+	documents, err := a.collection.aggregate(pipeline)
+	if err != nil {
+		return err
+	}
+
+	return decodeAll(documents, results)
+}
+
+func (a *Aggregation) Merge(target string, on []string, whenMatched string) error {
+	pipeline, err := toPipeline(a.pipeline)
+	if err != nil {
+		return err
+	}
+
+	merge := bson.D{{Key: "into", Value: target}}
+	if len(on) > 0 {
+		fields := make(bson.A, len(on))
+		for i, field := range on {
+			fields[i] = field
+		}
+		merge = append(merge, bson.E{Key: "on", Value: fields})
+	}
+	if whenMatched != "" {
+		merge = append(merge, bson.E{Key: "whenMatched", Value: whenMatched})
+	}
+
+	_, err = a.collection.aggregate(append(pipeline, bson.D{{Key: "$merge", Value: merge}}))
+
+	return err
+}
+
+// aggregate runs the stages on the documents of the collection, a final
+// $merge stage writes the results and returns none
+func (c *Collection) aggregate(pipeline bson.A) ([]bson.D, error) {
+	documents, err := c.find(bson.D{}, findOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	for i, stage := range pipeline {
+		stage, ok := stage.(bson.D)
+		if !ok || len(stage) != 1 {
+			return nil, mongo.CommandError{Code: 40323, Name: "Location40323", Message: "A pipeline stage specification object must contain exactly one field."}
+		}
+
+		if stage[0].Key == "$merge" {
+			if i != len(pipeline)-1 {
+				return nil, mongo.CommandError{Code: 40601, Name: "Location40601", Message: "$merge can only be the final stage in the pipeline"}
+			}
+			return nil, c.merge(documents, stage[0].Value)
+		}
+
+		if documents, err = runStage(documents, stage[0]); err != nil {
+			return nil, err
+		}
+	}
+
+	return documents, nil
+}
+
+// merge writes the documents into the target of a $merge stage, the pipelines
+// of whenMatched are not supported
+func (c *Collection) merge(documents []bson.D, specification interface{}) error {
+	target := &Collection{store: c.store, database: c.database}
+	on := []string{"_id"}
+	whenMatched, whenNotMatched := "merge", "insert"
+
+	switch specification := specification.(type) {
+	case string:
+		target.name = specification
+	case bson.D:
+		for _, element := range specification {
+			switch element.Key {
+			case "into":
+				switch into := element.Value.(type) {
+				case string:
+					target.name = into
+				case bson.D:
+					if database, ok := field(into, "db").(string); ok {
+						target.database = database
+					}
+					target.name, _ = field(into, "coll").(string)
+				}
+			case "on":
+				switch fields := element.Value.(type) {
+				case string:
+					on = []string{fields}
+				case bson.A:
+					on = nil
+					for _, field := range fields {
+						name, _ := field.(string)
+						on = append(on, name)
+					}
+				}
+			case "whenMatched":
+				var ok bool
+				if whenMatched, ok = element.Value.(string); !ok {
+					return fmt.Errorf("$merge pipelines are %w", ErrNotSupported)
+				}
+			case "whenNotMatched":
+				whenNotMatched, _ = element.Value.(string)
+			}
+		}
+	}
+	if target.name == "" {
+		return mongo.CommandError{Code: 51178, Name: "Location51178", Message: "$merge 'into' field must be a string or an object"}
+	}
+
+	c.store.mu.Lock()
+	defer c.store.mu.Unlock()
+
+	stored := target.documents()
+	for _, document := range documents {
+		filter := bson.D{}
+		for _, name := range on {
+			value, ok := getPath(document, name)
+			if !ok {
+				return mongo.CommandError{Code: 51132, Name: "Location51132", Message: fmt.Sprintf("$merge write error: 'on' field '%s' cannot be missing", name)}
+			}
+			filter = append(filter, bson.E{Key: name, Value: value})
+		}
+
+		index := -1
+		for i, existing := range stored {
+			matched, err := matches(existing, filter)
+			if err != nil {
+				return err
+			}
+			if matched {
+				index = i
+				break
+			}
+		}
+
+		if index < 0 {
+			switch whenNotMatched {
+			case "discard":
+				continue
+			case "fail":
+				return mongo.CommandError{Code: 13113, Name: "MergeStageNoMatchingDocument", Message: "$merge could not find a matching document in the target collection"}
+			}
+
+			if _, ok := lookupKey(document, "_id"); !ok {
+				document = append(bson.D{{Key: "_id", Value: primitive.NewObjectID()}}, document...)
+			}
+			stored = append(stored, document)
+			continue
+		}
+
+		switch whenMatched {
+		case "keepExisting":
+		case "fail":
+			return mongo.WriteError{Code: 11000, Message: fmt.Sprintf("E11000 duplicate key error collection: %s", target.namespace())}
+		case "replace":
+			if _, ok := lookupKey(document, "_id"); !ok {
+				id, _ := lookupKey(stored[index], "_id")
+				document = append(bson.D{{Key: "_id", Value: id}}, document...)
+			}
+			stored[index] = document
+		case "merge":
+			var fields bson.D
+			for _, element := range document {
+				if element.Key != "_id" {
+					fields = append(fields, element)
+				}
+			}
+			if len(fields) == 0 {
+				continue
+			}
+			updated, err := applyUpdate(stored[index], bson.D{{Key: "$set", Value: fields}}, false)
+			if err != nil {
+				return err
+			}
+			stored[index] = updated
+		default:
+			return mongo.CommandError{Code: 9, Name: "FailedToParse", Message: fmt.Sprintf("unknown whenMatched mode %s", whenMatched)}
+		}
+	}
+
+	target.setDocuments(stored)
+
+	return nil
+}
+
+// toPipeline converts the stages of a pipeline given as mongo.Pipeline,
+// []bson.D, []bson.M or []interface{} to documents, like the driver a single
+// document is not a pipeline
+func toPipeline(pipeline interface{}) (bson.A, error) {
+	var items []interface{}
+	switch pipeline := pipeline.(type) {
+	case nil:
+	case mongo.Pipeline:
+		for _, stage := range pipeline {
+			items = append(items, stage)
+		}
+	case []bson.D:
+		for _, stage := range pipeline {
+			items = append(items, stage)
+		}
+	case []bson.M:
+		for _, stage := range pipeline {
+			items = append(items, stage)
+		}
+	case bson.A:
+		items = pipeline
+	case []interface{}:
+		items = pipeline
+	default:
+		return nil, fmt.Errorf("pipeline must be a slice of stages, got %T", pipeline)
+	}
+
+	stages := make(bson.A, len(items))
+	for i, item := range items {
+		stage, err := toDocument(item)
+		if err != nil {
+			return nil, err
+		}
+		stages[i] = stage
+	}
+
+	return stages, nil
+}
@@ -0,0 +1,155 @@
+package mongodbtest
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson"
+
+	mongodb "github.com/portofolio-mager/goravel-mongodb"
+	"github.com/portofolio-mager/goravel-mongodb/contracts"
+)
+
+// The assertions work with any contracts.Collection, the in-memory one or a
+// real collection of a Docker database. They count the documents with
+// CountDocuments, so soft deleted documents and global scopes are not
+// filtered out, like Goravel's AssertDatabaseHas. A nil filter matches every
+// document.
+
+type tHelper interface {
+	Helper()
+}
+
+// AssertDocumentExists asserts that a document of the collection matches the filter
+func AssertDocumentExists(t assert.TestingT, collection contracts.Collection, filter interface{}, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	count, err := collection.CountDocuments(filterOrAll(filter))
+	if err != nil {
+		return assert.Fail(t, fmt.Sprintf("Failed to count the documents of collection [%s]: %v", collection.Name(), err), msgAndArgs...)
+	}
+	if count == 0 {
+		return assert.Fail(t, fmt.Sprintf("Failed asserting that a document in collection [%s] matches %s", collection.Name(), describe(filter)), msgAndArgs...)
+	}
+
+	return true
+}
+
+// AssertDocumentMissing asserts that no document of the collection matches the filter
+func AssertDocumentMissing(t assert.TestingT, collection contracts.Collection, filter interface{}, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	count, err := collection.CountDocuments(filterOrAll(filter))
+	if err != nil {
+		return assert.Fail(t, fmt.Sprintf("Failed to count the documents of collection [%s]: %v", collection.Name(), err), msgAndArgs...)
+	}
+	if count > 0 {
+		return assert.Fail(t, fmt.Sprintf("Failed asserting that no document in collection [%s] matches %s, found %d", collection.Name(), describe(filter), count), msgAndArgs...)
+	}
+
+	return true
+}
+
+// AssertCount asserts that expected documents of the collection match the filter
+func AssertCount(t assert.TestingT, collection contracts.Collection, filter interface{}, expected int64, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	count, err := collection.CountDocuments(filterOrAll(filter))
+	if err != nil {
+		return assert.Fail(t, fmt.Sprintf("Failed to count the documents of collection [%s]: %v", collection.Name(), err), msgAndArgs...)
+	}
+	if count != expected {
+		return assert.Fail(t, fmt.Sprintf("Failed asserting that collection [%s] has %d documents matching %s, found %d", collection.Name(), expected, describe(filter), count), msgAndArgs...)
+	}
+
+	return true
+}
+
+// AssertSoftDeleted asserts that a document of the collection matches the
+// filter and has the deleted_at field set
+func AssertSoftDeleted(t assert.TestingT, collection contracts.Collection, filter interface{}, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	count, err := collection.CountDocuments(trashedFilter(filter, true))
+	if err != nil {
+		return assert.Fail(t, fmt.Sprintf("Failed to count the documents of collection [%s]: %v", collection.Name(), err), msgAndArgs...)
+	}
+	if count == 0 {
+		return assert.Fail(t, fmt.Sprintf("Failed asserting that a document in collection [%s] matching %s is soft deleted", collection.Name(), describe(filter)), msgAndArgs...)
+	}
+
+	return true
+}
+
+// AssertNotSoftDeleted asserts that a document of the collection matches the
+// filter and has no deleted_at field set
+func AssertNotSoftDeleted(t assert.TestingT, collection contracts.Collection, filter interface{}, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	count, err := collection.CountDocuments(trashedFilter(filter, false))
+	if err != nil {
+		return assert.Fail(t, fmt.Sprintf("Failed to count the documents of collection [%s]: %v", collection.Name(), err), msgAndArgs...)
+	}
+	if count == 0 {
+		return assert.Fail(t, fmt.Sprintf("Failed asserting that a document in collection [%s] matching %s is not soft deleted", collection.Name(), describe(filter)), msgAndArgs...)
+	}
+
+	return true
+}
+
+// RefreshDatabase drops the database now and again when the test and its
+// subtests complete, so every test starts from an empty database
+func RefreshDatabase(t testing.TB, database contracts.Database) {
+	t.Helper()
+
+	if err := database.Drop(); err != nil {
+		t.Fatalf("failed to refresh database %s: %v", database.Name(), err)
+	}
+
+	t.Cleanup(func() {
+		if err := database.Drop(); err != nil {
+			t.Errorf("failed to drop database %s: %v", database.Name(), err)
+		}
+	})
+}
+
+func filterOrAll(filter interface{}) interface{} {
+	if filter == nil {
+		return bson.M{}
+	}
+
+	return filter
+}
+
+func trashedFilter(filter interface{}, trashed bool) interface{} {
+	condition := bson.M{mongodb.DeletedAtField: nil}
+	if trashed {
+		condition = bson.M{mongodb.DeletedAtField: bson.M{"$ne": nil}}
+	}
+
+	return bson.M{"$and": bson.A{filterOrAll(filter), condition}}
+}
+
+// describe formats the filter as relaxed Extended JSON for the failure messages
+func describe(filter interface{}) string {
+	if filter == nil {
+		return "{}"
+	}
+
+	if json, err := bson.MarshalExtJSON(filter, false, false); err == nil {
+		return string(json)
+	}
+
+	return fmt.Sprintf("%v", filter)
+}
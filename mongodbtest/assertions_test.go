@@ -0,0 +1,117 @@
+package mongodbtest
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/bson"
+
+	"github.com/portofolio-mager/goravel-mongodb/contracts"
+)
+
+// recorder is an assert.TestingT recording the failures
+type recorder struct {
+	errors []string
+}
+
+func (r *recorder) Errorf(format string, args ...interface{}) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+type AssertionsTestSuite struct {
+	suite.Suite
+	client     *Client
+	collection contracts.Collection
+}
+
+func TestAssertionsTestSuite(t *testing.T) {
+	suite.Run(t, new(AssertionsTestSuite))
+}
+
+func (s *AssertionsTestSuite) SetupTest() {
+	s.client = NewClient("goravel")
+	s.collection = s.client.Collection("users")
+
+	_, err := s.collection.InsertMany([]interface{}{
+		bson.M{"name": "Goravel", "age": 18},
+		bson.M{"name": "Laravel", "age": 30},
+		bson.M{"name": "Deleted", "age": 40, "deleted_at": time.Now()},
+	})
+	s.Require().NoError(err)
+}
+
+func (s *AssertionsTestSuite) TestAssertDocumentExists() {
+	t := &recorder{}
+	s.True(AssertDocumentExists(t, s.collection, bson.M{"name": "Goravel"}))
+	s.True(AssertDocumentExists(t, s.collection, bson.M{"name": "Deleted"}))
+	s.True(AssertDocumentExists(t, s.collection, nil))
+	s.Empty(t.errors)
+
+	s.False(AssertDocumentExists(t, s.collection, bson.M{"name": "Symfony"}, "user %s", "Symfony"))
+	s.Len(t.errors, 1)
+	s.Contains(t.errors[0], `Failed asserting that a document in collection [users] matches {"name":"Symfony"}`)
+	s.Contains(t.errors[0], "user Symfony")
+}
+
+func (s *AssertionsTestSuite) TestAssertDocumentMissing() {
+	t := &recorder{}
+	s.True(AssertDocumentMissing(t, s.collection, bson.M{"name": "Symfony"}))
+	s.True(AssertDocumentMissing(t, s.client.Collection("posts"), nil))
+	s.Empty(t.errors)
+
+	s.False(AssertDocumentMissing(t, s.collection, bson.M{"age": bson.M{"$gte": 30}}))
+	s.Len(t.errors, 1)
+	s.Contains(t.errors[0], `Failed asserting that no document in collection [users] matches {"age":{"$gte":30}}, found 2`)
+}
+
+func (s *AssertionsTestSuite) TestAssertCount() {
+	t := &recorder{}
+	s.True(AssertCount(t, s.collection, nil, 3))
+	s.True(AssertCount(t, s.collection, bson.M{"age": bson.M{"$lt": 35}}, 2))
+	s.Empty(t.errors)
+
+	s.False(AssertCount(t, s.collection, bson.M{"name": "Goravel"}, 2))
+	s.Len(t.errors, 1)
+	s.Contains(t.errors[0], `Failed asserting that collection [users] has 2 documents matching {"name":"Goravel"}, found 1`)
+
+	s.False(AssertCount(t, s.collection, bson.M{"age": bson.M{"$unknown": 1}}, 0))
+	s.Len(t.errors, 2)
+	s.Contains(t.errors[1], "Failed to count the documents of collection [users]")
+}
+
+func (s *AssertionsTestSuite) TestAssertSoftDeleted() {
+	t := &recorder{}
+	s.True(AssertSoftDeleted(t, s.collection, bson.M{"name": "Deleted"}))
+	s.True(AssertNotSoftDeleted(t, s.collection, bson.M{"name": "Goravel"}))
+	s.Empty(t.errors)
+
+	s.False(AssertSoftDeleted(t, s.collection, bson.M{"name": "Goravel"}))
+	s.False(AssertNotSoftDeleted(t, s.collection, bson.M{"name": "Deleted"}))
+	s.Len(t.errors, 2)
+	s.Contains(t.errors[0], `Failed asserting that a document in collection [users] matching {"name":"Goravel"} is soft deleted`)
+	s.Contains(t.errors[1], `Failed asserting that a document in collection [users] matching {"name":"Deleted"} is not soft deleted`)
+
+	// The soft delete of the query builder is detected
+	_, err := s.collection.WithSoftDeletes().Where("name", "Laravel").Delete()
+	s.NoError(err)
+	s.True(AssertSoftDeleted(t, s.collection, bson.M{"name": "Laravel"}))
+}
+
+func (s *AssertionsTestSuite) TestRefreshDatabase() {
+	database := s.client.Database()
+
+	s.Run("refreshed", func() {
+		RefreshDatabase(s.T(), database)
+		AssertCount(s.T(), s.collection, nil, 0)
+
+		_, err := database.Collection("posts").InsertOne(bson.M{"title": "Goravel"})
+		s.NoError(err)
+	})
+
+	// The database is dropped when the test completes
+	collections, err := database.ListCollections()
+	s.NoError(err)
+	s.Empty(collections)
+}
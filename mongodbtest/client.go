@@ -0,0 +1,207 @@
+// Package mongodbtest provides an in-memory implementation of the contracts
+// for unit tests, so services using a contracts.Client, contracts.Database or
+// contracts.Collection can be tested without a MongoDB server:
+//
+//	client := mongodbtest.NewClient("goravel")
+//	service := NewUserService(client.Database())
+//
+// Filters, sorting, skip, limit, projections and the common update operators
+// are evaluated in memory. Model events, observers, relations, change streams,
+// GridFS and the Native accessors are not available.
+//
+// Server serves the same in-memory documents over the wire protocol, for tests
+// going through a real mongo.Client.
+package mongodbtest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"sync"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	"github.com/portofolio-mager/goravel-mongodb/contracts"
+)
+
+var _ contracts.Client = &Client{}
+
+// ErrNotSupported is returned by the features the in-memory client cannot provide
+var ErrNotSupported = errors.New("not supported by the in-memory MongoDB")
+
+// store holds the documents of every database, by database and collection name
+type store struct {
+	mu        sync.RWMutex
+	databases map[string]map[string][]bson.D
+	// specifications hold the collections created with a type or options
+	specifications map[string]map[string]specification
+}
+
+// specification is the type and options a collection was created with
+type specification struct {
+	kind    string
+	options bson.D
+}
+
+// specification returns how the collection was created, a regular collection
+// without options by default
+func (s *store) specification(database, name string) specification {
+	if specification, ok := s.specifications[database][name]; ok {
+		return specification
+	}
+
+	return specification{kind: "collection"}
+}
+
+// exists reports whether the database has a collection or a view with the name
+func (s *store) exists(database, name string) bool {
+	if _, ok := s.databases[database][name]; ok {
+		return true
+	}
+
+	return s.specifications[database][name].kind == "view"
+}
+
+func newStore() *store {
+	return &store{
+		databases:      make(map[string]map[string][]bson.D),
+		specifications: make(map[string]map[string]specification),
+	}
+}
+
+type Client struct {
+	store    *store
+	database string
+
+	mu      sync.RWMutex
+	seeders []contracts.Seeder
+}
+
+// NewClient returns an empty client, database is the default database used
+// when Database is called without a name
+func NewClient(database string) *Client {
+	return &Client{
+		store:    newStore(),
+		database: database,
+	}
+}
+
+// Native returns nil, there is no underlying driver client
+func (c *Client) Native() *mongo.Client {
+	return nil
+}
+
+func (c *Client) Database(name ...string) contracts.Database {
+	database := c.database
+	if len(name) > 0 && name[0] != "" {
+		database = name[0]
+	}
+
+	return &Database{
+		store: c.store,
+		name:  database,
+	}
+}
+
+// ListDatabases returns the databases with a collection, the size on disk is
+// the BSON size of their documents
+func (c *Client) ListDatabases() ([]contracts.DatabaseInfo, error) {
+	c.store.mu.RLock()
+	defer c.store.mu.RUnlock()
+
+	var databases []contracts.DatabaseInfo
+	for name, collections := range c.store.databases {
+		if len(collections) == 0 {
+			continue
+		}
+
+		database := contracts.DatabaseInfo{Name: name}
+		for _, documents := range collections {
+			for _, document := range documents {
+				data, err := bson.Marshal(document)
+				if err != nil {
+					return nil, err
+				}
+				database.SizeOnDisk += int64(len(data))
+			}
+		}
+		database.Empty = database.SizeOnDisk == 0
+		databases = append(databases, database)
+	}
+	sort.Slice(databases, func(i, j int) bool {
+		return databases[i].Name < databases[j].Name
+	})
+
+	return databases, nil
+}
+
+func (c *Client) Collection(collection string, database ...string) contracts.Collection {
+	return c.Database(database...).Collection(collection)
+}
+
+func (c *Client) Watch(pipeline interface{}, opts ...interface{}) (contracts.ChangeStream, error) {
+	return nil, fmt.Errorf("change streams are %w", ErrNotSupported)
+}
+
+func (c *Client) Ping() error {
+	return nil
+}
+
+// HealthCheck reports the in-memory client as a healthy standalone server
+func (c *Client) HealthCheck() contracts.Health {
+	return contracts.Health{
+		Connection: "mongodbtest",
+		Status:     "up",
+		Topology:   "standalone",
+	}
+}
+
+func (c *Client) Close() error {
+	return nil
+}
+
+// WithContext returns the client as is, the in-memory operations take no context
+func (c *Client) WithContext(ctx context.Context) contracts.Client {
+	return c
+}
+
+// RegisterSeeders registers seeders, a seeder replaces the registered one with
+// the same signature
+func (c *Client) RegisterSeeders(seeders ...contracts.Seeder) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, seeder := range seeders {
+		index := slices.IndexFunc(c.seeders, func(registered contracts.Seeder) bool {
+			return registered.Signature() == seeder.Signature()
+		})
+		if index >= 0 {
+			c.seeders[index] = seeder
+		} else {
+			c.seeders = append(c.seeders, seeder)
+		}
+	}
+}
+
+func (c *Client) Seeder(signature string) contracts.Seeder {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	for _, seeder := range c.seeders {
+		if seeder.Signature() == signature {
+			return seeder
+		}
+	}
+
+	return nil
+}
+
+func (c *Client) Seeders() []contracts.Seeder {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return slices.Clone(c.seeders)
+}
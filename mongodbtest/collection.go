@@ -0,0 +1,612 @@
+package mongodbtest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	mongodb "github.com/portofolio-mager/goravel-mongodb"
+	"github.com/portofolio-mager/goravel-mongodb/contracts"
+)
+
+var _ contracts.Collection = &Collection{}
+
+type Collection struct {
+	store       *store
+	database    string
+	name        string
+	softDeletes bool
+	scopes      []globalScope
+}
+
+type globalScope struct {
+	name  string
+	scope func(contracts.QueryBuilder)
+}
+
+// findOptions are the options of the read operations
+type findOptions struct {
+	sort       bson.D
+	skip       int64
+	limit      int64
+	projection bson.D
+}
+
+// Native returns nil, there is no underlying driver collection
+func (c *Collection) Native() *mongo.Collection {
+	return nil
+}
+
+// Basic CRUD operations
+func (c *Collection) FindOne(filter interface{}, result interface{}, opts ...interface{}) error {
+	var findOpts findOptions
+	if len(opts) > 0 {
+		if opt, ok := opts[0].(*options.FindOneOptions); ok {
+			var err error
+			if findOpts, err = toFindOptions(opt.Sort, opt.Skip, nil, opt.Projection); err != nil {
+				return err
+			}
+		}
+	}
+	findOpts.limit = 1
+
+	filterDocument, err := toDocument(filter)
+	if err != nil {
+		return err
+	}
+
+	documents, err := c.find(filterDocument, findOpts)
+	if err != nil {
+		return err
+	}
+	if len(documents) == 0 {
+		return mongo.ErrNoDocuments
+	}
+
+	return decode(documents[0], result)
+}
+
+func (c *Collection) Find(filter interface{}, opts ...interface{}) (*mongo.Cursor, error) {
+	var findOpts findOptions
+	if len(opts) > 0 {
+		if opt, ok := opts[0].(*options.FindOptions); ok {
+			var err error
+			if findOpts, err = toFindOptions(opt.Sort, opt.Skip, opt.Limit, opt.Projection); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	filterDocument, err := toDocument(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	documents, err := c.find(filterDocument, findOpts)
+	if err != nil {
+		return nil, err
+	}
+
+	return cursor(documents)
+}
+
+func (c *Collection) InsertOne(document interface{}, opts ...interface{}) (*mongo.InsertOneResult, error) {
+	ids, err := c.insert([]interface{}{document})
+	if err != nil {
+		var writeError mongo.WriteError
+		if errors.As(err, &writeError) {
+			return nil, mongo.WriteException{WriteErrors: mongo.WriteErrors{writeError}}
+		}
+		return nil, err
+	}
+
+	return &mongo.InsertOneResult{InsertedID: ids[0]}, nil
+}
+
+func (c *Collection) InsertMany(documents []interface{}, opts ...interface{}) (*mongo.InsertManyResult, error) {
+	ids, err := c.insert(documents)
+	if err != nil {
+		var writeError mongo.WriteError
+		if errors.As(err, &writeError) {
+			return &mongo.InsertManyResult{InsertedIDs: ids}, mongo.BulkWriteException{
+				WriteErrors: []mongo.BulkWriteError{{WriteError: writeError}},
+			}
+		}
+		return nil, err
+	}
+
+	return &mongo.InsertManyResult{InsertedIDs: ids}, nil
+}
+
+func (c *Collection) UpdateOne(filter interface{}, update interface{}, opts ...interface{}) (*mongo.UpdateResult, error) {
+	return c.update(filter, update, false, upsert(opts))
+}
+
+func (c *Collection) UpdateMany(filter interface{}, update interface{}, opts ...interface{}) (*mongo.UpdateResult, error) {
+	return c.update(filter, update, true, upsert(opts))
+}
+
+func (c *Collection) DeleteOne(filter interface{}, opts ...interface{}) (*mongo.DeleteResult, error) {
+	return c.delete(filter, false)
+}
+
+func (c *Collection) DeleteMany(filter interface{}, opts ...interface{}) (*mongo.DeleteResult, error) {
+	return c.delete(filter, true)
+}
+
+// ORM-like convenience methods
+func (c *Collection) Create(document interface{}) error {
+	_, err := c.InsertOne(document)
+	return err
+}
+
+func (c *Collection) First(result interface{}, filter ...interface{}) error {
+	var f interface{} = bson.M{}
+	if len(filter) > 0 {
+		f = filter[0]
+	}
+	return c.FindOne(f, result)
+}
+
+func (c *Collection) Query() contracts.QueryBuilder {
+	return NewQueryBuilder(c)
+}
+
+func (c *Collection) Where(field string, value interface{}) contracts.QueryBuilder {
+	return NewQueryBuilder(c).Where(field, value)
+}
+
+// Observe does nothing, the in-memory collection fires no model events
+func (c *Collection) Observe(observer contracts.Observer) {
+}
+
+func (c *Collection) WithScope(name string, scope func(contracts.QueryBuilder)) contracts.Collection {
+	for i := range c.scopes {
+		if c.scopes[i].name == name {
+			c.scopes[i].scope = scope
+			return c
+		}
+	}
+
+	c.scopes = append(c.scopes, globalScope{name: name, scope: scope})
+	return c
+}
+
+func (c *Collection) WithSoftDeletes() contracts.Collection {
+	c.softDeletes = true
+	return c
+}
+
+func (c *Collection) Watch(pipeline interface{}, opts ...interface{}) (contracts.ChangeStream, error) {
+	return nil, fmt.Errorf("change streams are %w", ErrNotSupported)
+}
+
+// Collection management
+func (c *Collection) Drop() error {
+	c.store.mu.Lock()
+	defer c.store.mu.Unlock()
+
+	delete(c.store.databases[c.database], c.name)
+	delete(c.store.specifications[c.database], c.name)
+
+	return nil
+}
+
+// Aggregate returns the aggregation running the pipeline as is, the options
+// are ignored
+func (c *Collection) Aggregate(pipeline interface{}, opts ...interface{}) contracts.Aggregation {
+	return &Aggregation{collection: c, pipeline: pipeline}
+}
+
+// SetValidator checks the collection exists and a struct's validator can be
+// generated, the validators are not enforced
+func (c *Collection) SetValidator(schema interface{}, level, action string) error {
+	if reflect.Indirect(reflect.ValueOf(schema)).Kind() == reflect.Struct {
+		if _, err := mongodb.Validator(schema); err != nil {
+			return err
+		}
+	}
+
+	c.store.mu.RLock()
+	defer c.store.mu.RUnlock()
+
+	if _, ok := c.store.databases[c.database][c.name]; !ok {
+		return mongo.CommandError{Code: 26, Name: "NamespaceNotFound", Message: fmt.Sprintf("ns does not exist: %s", c.namespace())}
+	}
+
+	return nil
+}
+
+func (c *Collection) Name() string {
+	return c.name
+}
+
+// WithContext returns the collection as is, the in-memory operations take no context
+func (c *Collection) WithContext(ctx context.Context) contracts.Collection {
+	return c
+}
+
+func (c *Collection) CountDocuments(filter interface{}, opts ...interface{}) (int64, error) {
+	var findOpts findOptions
+	if len(opts) > 0 {
+		if opt, ok := opts[0].(*options.CountOptions); ok {
+			var err error
+			if findOpts, err = toFindOptions(nil, opt.Skip, opt.Limit, nil); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	filterDocument, err := toDocument(filter)
+	if err != nil {
+		return 0, err
+	}
+
+	documents, err := c.find(filterDocument, findOpts)
+	if err != nil {
+		return 0, err
+	}
+
+	return int64(len(documents)), nil
+}
+
+// find returns copies of the matching documents
+func (c *Collection) find(filter bson.D, opts findOptions) ([]bson.D, error) {
+	stored, err := c.source()
+	if err != nil {
+		return nil, err
+	}
+
+	var documents []bson.D
+	for _, document := range stored {
+		matched, err := matches(document, filter)
+		if err != nil {
+			return nil, err
+		}
+		if matched {
+			documents = append(documents, document)
+		}
+	}
+
+	sortDocuments(documents, opts.sort)
+
+	if opts.skip > 0 {
+		documents = documents[min(opts.skip, int64(len(documents))):]
+	}
+	if opts.limit != 0 {
+		limit := opts.limit
+		if limit < 0 {
+			limit = -limit
+		}
+		documents = documents[:min(limit, int64(len(documents)))]
+	}
+
+	results := make([]bson.D, len(documents))
+	for i, document := range documents {
+		results[i] = project(cloneDocument(document), opts.projection)
+	}
+
+	return results, nil
+}
+
+// source returns the stored documents, or the results of the pipeline of a
+// view on its source collection
+func (c *Collection) source() ([]bson.D, error) {
+	c.store.mu.RLock()
+	specification := c.store.specification(c.database, c.name)
+	documents := append([]bson.D(nil), c.store.databases[c.database][c.name]...)
+	c.store.mu.RUnlock()
+
+	if specification.kind != "view" {
+		return documents, nil
+	}
+
+	viewOn, _ := field(specification.options, "viewOn").(string)
+	pipeline, _ := field(specification.options, "pipeline").(bson.A)
+	source := &Collection{store: c.store, database: c.database, name: viewOn}
+
+	return source.aggregate(pipeline)
+}
+
+// sortDocuments sorts the documents in place, keeping the insertion order of
+// equal documents
+func sortDocuments(documents []bson.D, fields bson.D) {
+	if len(fields) == 0 {
+		return
+	}
+
+	sort.SliceStable(documents, func(i, j int) bool {
+		for _, field := range fields {
+			a, _ := getPath(documents[i], field.Key)
+			b, _ := getPath(documents[j], field.Key)
+			if result := compare(a, b); result != 0 {
+				if direction, _ := toFloat(field.Value); direction < 0 {
+					return result > 0
+				}
+				return result < 0
+			}
+		}
+		return false
+	})
+}
+
+// insert stores the documents in order, generating the missing ids, and stops
+// at the first duplicate id with a mongo.WriteError
+func (c *Collection) insert(documents []interface{}) ([]interface{}, error) {
+	c.store.mu.Lock()
+	defer c.store.mu.Unlock()
+
+	var ids []interface{}
+	for i, document := range documents {
+		stored, err := toDocument(document)
+		if err != nil {
+			return ids, err
+		}
+
+		id, ok := lookupKey(stored, "_id")
+		if !ok {
+			id = primitive.NewObjectID()
+			stored = append(bson.D{{Key: "_id", Value: id}}, stored...)
+		}
+
+		if c.findID(id) >= 0 {
+			return ids, mongo.WriteError{
+				Index:   i,
+				Code:    11000,
+				Message: fmt.Sprintf("E11000 duplicate key error collection: %s index: _id_ dup key: { _id: %v }", c.namespace(), id),
+			}
+		}
+
+		c.setDocuments(append(c.documents(), stored))
+		ids = append(ids, id)
+	}
+
+	return ids, nil
+}
+
+func (c *Collection) update(filter interface{}, update interface{}, many bool, upsert bool) (*mongo.UpdateResult, error) {
+	switch update.(type) {
+	case bson.A, mongo.Pipeline, []bson.D:
+		return nil, fmt.Errorf("update pipelines are %w", ErrNotSupported)
+	}
+
+	filterDocument, err := toDocument(filter)
+	if err != nil {
+		return nil, err
+	}
+	updateDocument, err := toDocument(update)
+	if err != nil {
+		return nil, err
+	}
+
+	c.store.mu.Lock()
+	defer c.store.mu.Unlock()
+
+	result := &mongo.UpdateResult{}
+	documents := c.documents()
+	for i, document := range documents {
+		matched, err := matches(document, filterDocument)
+		if err != nil {
+			return nil, err
+		}
+		if !matched {
+			continue
+		}
+
+		result.MatchedCount++
+		updated, err := applyUpdate(document, updateDocument, false)
+		if err != nil {
+			return nil, err
+		}
+		if compare(document, updated) != 0 {
+			result.ModifiedCount++
+			documents[i] = updated
+		}
+
+		if !many {
+			break
+		}
+	}
+
+	if result.MatchedCount > 0 || !upsert {
+		return result, nil
+	}
+
+	document, err := upsertDocument(filterDocument)
+	if err != nil {
+		return nil, err
+	}
+	if document, err = applyUpdate(document, updateDocument, true); err != nil {
+		return nil, err
+	}
+
+	id, ok := lookupKey(document, "_id")
+	if !ok {
+		id = primitive.NewObjectID()
+		document = append(bson.D{{Key: "_id", Value: id}}, document...)
+	}
+	c.setDocuments(append(documents, document))
+
+	result.UpsertedCount = 1
+	result.UpsertedID = id
+
+	return result, nil
+}
+
+func (c *Collection) delete(filter interface{}, many bool) (*mongo.DeleteResult, error) {
+	filterDocument, err := toDocument(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	c.store.mu.Lock()
+	defer c.store.mu.Unlock()
+
+	result := &mongo.DeleteResult{}
+	var kept []bson.D
+	for _, document := range c.store.databases[c.database][c.name] {
+		if many || result.DeletedCount == 0 {
+			matched, err := matches(document, filterDocument)
+			if err != nil {
+				return nil, err
+			}
+			if matched {
+				result.DeletedCount++
+				continue
+			}
+		}
+		kept = append(kept, document)
+	}
+
+	if result.DeletedCount > 0 {
+		c.setDocuments(kept)
+	}
+
+	return result, nil
+}
+
+// documents returns the stored documents, the caller holds the lock
+func (c *Collection) documents() []bson.D {
+	return c.store.databases[c.database][c.name]
+}
+
+// setDocuments stores the documents creating the collection if needed, the
+// caller holds the lock
+func (c *Collection) setDocuments(documents []bson.D) {
+	collections := c.store.databases[c.database]
+	if collections == nil {
+		collections = make(map[string][]bson.D)
+		c.store.databases[c.database] = collections
+	}
+	if documents == nil {
+		documents = []bson.D{}
+	}
+	collections[c.name] = documents
+}
+
+// findID returns the index of the document with the id, -1 when there is none
+func (c *Collection) findID(id interface{}) int {
+	for i, document := range c.documents() {
+		if documentID, ok := lookupKey(document, "_id"); ok && equal(documentID, id) {
+			return i
+		}
+	}
+
+	return -1
+}
+
+func toFindOptions(sort interface{}, skip *int64, limit *int64, projection interface{}) (findOptions, error) {
+	var opts findOptions
+	var err error
+	if sort != nil {
+		if opts.sort, err = toDocument(sort); err != nil {
+			return opts, err
+		}
+	}
+	if projection != nil {
+		if opts.projection, err = toDocument(projection); err != nil {
+			return opts, err
+		}
+	}
+	if skip != nil {
+		opts.skip = *skip
+	}
+	if limit != nil {
+		opts.limit = *limit
+	}
+
+	return opts, nil
+}
+
+func upsert(opts []interface{}) bool {
+	if len(opts) > 0 {
+		if opt, ok := opts[0].(*options.UpdateOptions); ok && opt.Upsert != nil {
+			return *opt.Upsert
+		}
+	}
+
+	return false
+}
+
+// project applies an inclusion or exclusion projection to the document
+func project(document bson.D, projection bson.D) bson.D {
+	if len(projection) == 0 {
+		return document
+	}
+
+	inclusion := false
+	includeID := true
+	for _, field := range projection {
+		if field.Key == "_id" {
+			includeID = truthy(field.Value)
+		} else if truthy(field.Value) {
+			inclusion = true
+		}
+	}
+
+	if !inclusion {
+		for _, field := range projection {
+			if !truthy(field.Value) {
+				document = removePath(document, field.Key)
+			}
+		}
+		return document
+	}
+
+	projected := bson.D{}
+	if id, ok := lookupKey(document, "_id"); ok && includeID {
+		projected = append(projected, bson.E{Key: "_id", Value: id})
+	}
+	for _, field := range projection {
+		if field.Key == "_id" || !truthy(field.Value) {
+			continue
+		}
+		if value, ok := getPath(document, field.Key); ok {
+			// the path was found so it can be set again
+			projected, _ = setPath(projected, field.Key, value)
+		}
+	}
+
+	return projected
+}
+
+func decode(document bson.D, result interface{}) error {
+	data, err := bson.Marshal(document)
+	if err != nil {
+		return err
+	}
+
+	return bson.Unmarshal(data, result)
+}
+
+func cursor(documents []bson.D) (*mongo.Cursor, error) {
+	values := make([]interface{}, len(documents))
+	for i, document := range documents {
+		values[i] = document
+	}
+
+	return mongo.NewCursorFromDocuments(values, nil, nil)
+}
+
+// decodeAll decodes the documents into results like mongo.Cursor.All
+func decodeAll(documents []bson.D, results interface{}) error {
+	cursor, err := cursor(documents)
+	if err != nil {
+		return err
+	}
+
+	return cursor.All(context.Background(), results)
+}
+
+// namespace is used in error messages
+func (c *Collection) namespace() string {
+	return strings.Join([]string{c.database, c.name}, ".")
+}
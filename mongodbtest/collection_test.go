@@ -0,0 +1,255 @@
+package mongodbtest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	mongodb "github.com/portofolio-mager/goravel-mongodb"
+	"github.com/portofolio-mager/goravel-mongodb/contracts"
+)
+
+type testUser struct {
+	ID     primitive.ObjectID `bson:"_id,omitempty"`
+	Name   string             `bson:"name"`
+	Age    int                `bson:"age"`
+	Active bool               `bson:"active"`
+}
+
+type CollectionTestSuite struct {
+	suite.Suite
+	client     *Client
+	collection contracts.Collection
+}
+
+func TestCollectionTestSuite(t *testing.T) {
+	suite.Run(t, new(CollectionTestSuite))
+}
+
+func (s *CollectionTestSuite) SetupTest() {
+	s.client = NewClient("goravel")
+	s.collection = s.client.Collection("users")
+}
+
+func (s *CollectionTestSuite) TestInsertAndFindOne() {
+	result, err := s.collection.InsertOne(testUser{Name: "Goravel", Age: 18})
+	s.NoError(err)
+	id, ok := result.InsertedID.(primitive.ObjectID)
+	s.True(ok)
+
+	var user testUser
+	s.NoError(s.collection.FindOne(bson.M{"_id": id}, &user))
+	s.Equal(testUser{ID: id, Name: "Goravel", Age: 18}, user)
+
+	s.ErrorIs(s.collection.FindOne(bson.M{"name": "Laravel"}, &user), mongo.ErrNoDocuments)
+
+	// The stored document is a copy
+	user.Name = "Changed"
+	var stored testUser
+	s.NoError(s.collection.First(&stored))
+	s.Equal("Goravel", stored.Name)
+
+	// The collections are shared by the databases of the client
+	var other testUser
+	s.NoError(s.client.Database().Collection("users").First(&other, bson.M{"name": "Goravel"}))
+	s.Equal(id, other.ID)
+	s.ErrorIs(s.client.Database("other").Collection("users").First(&other), mongo.ErrNoDocuments)
+}
+
+func (s *CollectionTestSuite) TestInsertDuplicateKey() {
+	_, err := s.collection.InsertOne(bson.M{"_id": 1, "name": "Goravel"})
+	s.NoError(err)
+
+	_, err = s.collection.InsertOne(bson.M{"_id": 1, "name": "Laravel"})
+	s.True(mongo.IsDuplicateKeyError(err))
+
+	result, err := s.collection.InsertMany([]interface{}{bson.M{"_id": 2}, bson.M{"_id": 1}, bson.M{"_id": 3}})
+	s.True(mongo.IsDuplicateKeyError(err))
+	s.Equal([]interface{}{int32(2)}, result.InsertedIDs)
+
+	count, err := s.collection.CountDocuments(bson.M{})
+	s.NoError(err)
+	s.Equal(int64(2), count)
+}
+
+func (s *CollectionTestSuite) TestFind() {
+	_, err := s.collection.InsertMany([]interface{}{
+		testUser{Name: "Goravel", Age: 18, Active: true},
+		testUser{Name: "Laravel", Age: 30, Active: true},
+		testUser{Name: "Rails", Age: 25},
+		testUser{Name: "Django", Age: 25, Active: true},
+	})
+	s.NoError(err)
+
+	cursor, err := s.collection.Find(bson.M{"active": true}, options.Find().
+		SetSort(bson.D{{Key: "age", Value: -1}, {Key: "name", Value: 1}}).
+		SetSkip(1).
+		SetLimit(2).
+		SetProjection(bson.M{"name": 1, "_id": 0}))
+	s.NoError(err)
+
+	var results []bson.M
+	s.NoError(cursor.All(context.Background(), &results))
+	s.Equal([]bson.M{{"name": "Django"}, {"name": "Goravel"}}, results)
+
+	cursor, err = s.collection.Find(bson.M{"age": 25}, options.Find().SetProjection(bson.M{"_id": 0, "active": 0}).SetSort(bson.M{"name": 1}))
+	s.NoError(err)
+	results = nil
+	s.NoError(cursor.All(context.Background(), &results))
+	s.Equal([]bson.M{{"name": "Django", "age": int32(25)}, {"name": "Rails", "age": int32(25)}}, results)
+
+	var user testUser
+	s.NoError(s.collection.FindOne(bson.M{"age": bson.M{"$gte": 25}}, &user, options.FindOne().SetSort(bson.M{"age": 1}).SetSkip(1)))
+	s.Equal("Django", user.Name)
+
+	count, err := s.collection.CountDocuments(bson.M{"active": true}, options.Count().SetLimit(2))
+	s.NoError(err)
+	s.Equal(int64(2), count)
+
+	_, err = s.collection.Find(bson.M{"$where": "true"})
+	s.EqualError(err, "unknown top level operator: $where")
+}
+
+func (s *CollectionTestSuite) TestUpdate() {
+	_, err := s.collection.InsertMany([]interface{}{
+		bson.M{"_id": 1, "name": "Goravel", "age": 18},
+		bson.M{"_id": 2, "name": "Laravel", "age": 30},
+		bson.M{"_id": 3, "name": "Rails", "age": 30},
+	})
+	s.NoError(err)
+
+	result, err := s.collection.UpdateOne(bson.M{"age": 30}, bson.M{"$inc": bson.M{"age": 1}})
+	s.NoError(err)
+	s.Equal(&mongo.UpdateResult{MatchedCount: 1, ModifiedCount: 1}, result)
+
+	result, err = s.collection.UpdateMany(bson.M{"age": bson.M{"$gte": 30}}, bson.M{"$set": bson.M{"age": 31}})
+	s.NoError(err)
+	s.Equal(&mongo.UpdateResult{MatchedCount: 2, ModifiedCount: 1}, result)
+
+	result, err = s.collection.UpdateOne(bson.M{"name": "Django"}, bson.M{"$set": bson.M{"age": 25}, "$setOnInsert": bson.M{"active": true}}, options.Update().SetUpsert(true))
+	s.NoError(err)
+	s.Equal(int64(1), result.UpsertedCount)
+	s.IsType(primitive.ObjectID{}, result.UpsertedID)
+
+	var upserted bson.M
+	s.NoError(s.collection.FindOne(bson.M{"_id": result.UpsertedID}, &upserted))
+	s.Equal(bson.M{"_id": result.UpsertedID, "name": "Django", "age": int32(25), "active": true}, upserted)
+
+	_, err = s.collection.UpdateOne(bson.M{"_id": 1}, bson.M{"name": "Framework"})
+	s.EqualError(err, "update document requires atomic operators")
+	_, err = s.collection.UpdateOne(bson.M{"_id": 1}, mongo.Pipeline{})
+	s.ErrorIs(err, ErrNotSupported)
+}
+
+func (s *CollectionTestSuite) TestDelete() {
+	_, err := s.collection.InsertMany([]interface{}{
+		bson.M{"name": "Goravel", "age": 18},
+		bson.M{"name": "Laravel", "age": 30},
+		bson.M{"name": "Rails", "age": 30},
+	})
+	s.NoError(err)
+
+	result, err := s.collection.DeleteOne(bson.M{"age": 30})
+	s.NoError(err)
+	s.Equal(int64(1), result.DeletedCount)
+
+	result, err = s.collection.DeleteMany(bson.M{})
+	s.NoError(err)
+	s.Equal(int64(2), result.DeletedCount)
+
+	names, err := s.client.Database().ListCollections()
+	s.NoError(err)
+	s.Equal([]string{"users"}, names)
+
+	s.NoError(s.collection.Drop())
+	names, err = s.client.Database().ListCollections()
+	s.NoError(err)
+	s.Empty(names)
+}
+
+func (s *CollectionTestSuite) TestCollectionHelpers() {
+	database := s.client.Database()
+	s.NoError(database.CreateTimeSeries("metrics", "timestamp", "", "", 0))
+	s.NoError(database.CreateCapped("logs", 1024, 0))
+	s.NoError(database.CreateClustered("events"))
+
+	var commandError mongo.CommandError
+	s.ErrorAs(database.CreateCapped("logs", 1024, 0), &commandError)
+	s.Equal("NamespaceExists", commandError.Name)
+
+	info, err := database.CollectionInfo("metrics")
+	s.NoError(err)
+	s.Equal(&contracts.CollectionInfo{Name: "metrics", Type: "timeseries", Options: bson.M{"timeseries": bson.M{"timeField": "timestamp"}}}, info)
+
+	info, err = database.CollectionInfo("logs")
+	s.NoError(err)
+	s.Equal(bson.M{"capped": true, "size": int64(1024)}, info.Options)
+
+	info, err = database.CollectionInfo("events")
+	s.NoError(err)
+	s.Contains(info.Options, "clusteredIndex")
+
+	s.NoError(database.Drop())
+	_, err = database.CollectionInfo("metrics")
+	s.ErrorIs(err, mongodb.CollectionNotFound)
+}
+
+func (s *CollectionTestSuite) TestAggregate() {
+	_, err := s.collection.InsertMany([]interface{}{
+		bson.M{"_id": 1, "name": "Goravel", "age": 18},
+		bson.M{"_id": 2, "name": "Laravel", "age": 30},
+	})
+	s.NoError(err)
+
+	database := s.client.Database()
+	s.NoError(database.CreateView("adults", "users", []bson.M{{"$match": bson.M{"age": bson.M{"$gte": 20}}}}))
+
+	var adults []bson.M
+	s.NoError(database.Collection("adults").Query().Find(&adults))
+	s.Len(adults, 1)
+	s.Equal("Laravel", adults[0]["name"])
+
+	var commandError mongo.CommandError
+	s.ErrorAs(database.CreateView("adults", "users", nil), &commandError)
+	s.Equal("NamespaceExists", commandError.Name)
+
+	s.NoError(s.collection.Aggregate(nil).Merge("copies", nil, ""))
+	s.NoError(s.collection.Aggregate(bson.A{bson.M{"$project": bson.M{"age": 1}}}).Merge("copies", []string{"_id"}, "merge"))
+	s.Error(s.collection.Aggregate(nil).Merge("copies", nil, "fail"))
+
+	var copies []bson.M
+	s.NoError(database.Collection("adults").Aggregate(nil).All(&copies))
+	s.Len(copies, 1)
+
+	s.NoError(database.Collection("copies").Aggregate([]bson.M{{"$sort": bson.M{"_id": -1}}}).All(&copies))
+	s.Equal([]bson.M{{"_id": int32(2), "name": "Laravel", "age": int32(30)}, {"_id": int32(1), "name": "Goravel", "age": int32(18)}}, copies)
+
+	collections, err := database.ListCollections()
+	s.NoError(err)
+	s.Equal([]string{"adults", "copies", "users"}, collections)
+	views, err := database.ListViews()
+	s.NoError(err)
+	s.Equal([]string{"adults"}, views)
+
+	s.NoError(database.Collection("adults").Drop())
+	views, err = database.ListViews()
+	s.NoError(err)
+	s.Empty(views)
+
+	s.ErrorContains(s.collection.Aggregate(bson.M{"$match": bson.M{}}).All(&copies), "pipeline must be a slice of stages")
+}
+
+func (s *CollectionTestSuite) TestNotSupported() {
+	s.Nil(s.collection.Native())
+
+	_, err := s.collection.Watch(nil)
+	s.ErrorIs(err, ErrNotSupported)
+
+	_, err = s.client.Database().GridFS()
+	s.ErrorIs(err, ErrNotSupported)
+}
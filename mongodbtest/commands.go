@@ -0,0 +1,831 @@
+package mongodbtest
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// The wire version of MongoDB 6.0, the driver requires at least 6
+const maxWireVersion = 17
+
+// defaultBatchSize is the size of the first batch of a cursor when the
+// command sets none, like MongoDB
+const defaultBatchSize = 101
+
+type command func(s *Server, request *request) (bson.D, error)
+
+var commands = map[string]command{
+	"hello":           (*Server).hello,
+	"isMaster":        (*Server).hello,
+	"ismaster":        (*Server).hello,
+	"ping":            (*Server).ok,
+	"endSessions":     (*Server).ok,
+	"buildInfo":       (*Server).buildInfo,
+	"insert":          (*Server).insert,
+	"find":            (*Server).find,
+	"getMore":         (*Server).getMore,
+	"killCursors":     (*Server).killCursors,
+	"update":          (*Server).update,
+	"delete":          (*Server).delete,
+	"findAndModify":   (*Server).findAndModify,
+	"count":           (*Server).count,
+	"aggregate":       (*Server).aggregate,
+	"create":          (*Server).create,
+	"collMod":         (*Server).collMod,
+	"listCollections": (*Server).listCollections,
+	"listDatabases":   (*Server).listDatabases,
+	"drop":            (*Server).drop,
+	"createIndexes":   (*Server).createIndexes,
+	"listIndexes":     (*Server).listIndexes,
+	"dropIndexes":     (*Server).dropIndexes,
+	"dropDatabase":    (*Server).dropDatabase,
+}
+
+// run executes the command of the request and returns the reply, failures
+// are replied with ok: 0 like MongoDB
+func (s *Server) run(request *request) bson.D {
+	if len(request.command) == 0 {
+		return errorReply(mongo.CommandError{Code: 9, Name: "FailedToParse", Message: "empty command"})
+	}
+
+	name := request.command[0].Key
+	handler, ok := commands[name]
+	if !ok {
+		return errorReply(mongo.CommandError{Code: 59, Name: "CommandNotFound", Message: fmt.Sprintf("no such command: '%s'", name)})
+	}
+
+	reply, err := handler(s, request)
+	if err != nil {
+		return errorReply(err)
+	}
+
+	return append(reply, bson.E{Key: "ok", Value: 1.0})
+}
+
+func (s *Server) hello(request *request) (bson.D, error) {
+	return bson.D{
+		{Key: "helloOk", Value: true},
+		{Key: "ismaster", Value: true},
+		{Key: "isWritablePrimary", Value: true},
+		{Key: "maxBsonObjectSize", Value: int32(16 * 1024 * 1024)},
+		{Key: "maxMessageSizeBytes", Value: int32(maxMessageSize)},
+		{Key: "maxWriteBatchSize", Value: int32(100000)},
+		{Key: "localTime", Value: time.Now()},
+		{Key: "logicalSessionTimeoutMinutes", Value: int32(30)},
+		{Key: "connectionId", Value: request.connectionID},
+		{Key: "minWireVersion", Value: int32(0)},
+		{Key: "maxWireVersion", Value: int32(maxWireVersion)},
+		{Key: "readOnly", Value: false},
+	}, nil
+}
+
+func (s *Server) ok(*request) (bson.D, error) {
+	return bson.D{}, nil
+}
+
+func (s *Server) buildInfo(*request) (bson.D, error) {
+	return bson.D{
+		{Key: "version", Value: "6.0.0"},
+		{Key: "versionArray", Value: bson.A{int32(6), int32(0), int32(0), int32(0)}},
+		{Key: "maxBsonObjectSize", Value: int32(16 * 1024 * 1024)},
+	}, nil
+}
+
+func (s *Server) insert(request *request) (bson.D, error) {
+	collection, err := s.collection(request)
+	if err != nil {
+		return nil, err
+	}
+
+	documents, _ := field(request.command, "documents").(bson.A)
+	ordered := boolField(request.command, "ordered", true)
+
+	var inserted int32
+	var writeErrors bson.A
+	for i, document := range documents {
+		if _, err := collection.insert([]interface{}{document}); err != nil {
+			writeErrors = append(writeErrors, writeError(i, err))
+			if ordered {
+				break
+			}
+			continue
+		}
+		inserted++
+	}
+
+	return withWriteErrors(bson.D{{Key: "n", Value: inserted}}, writeErrors), nil
+}
+
+func (s *Server) update(request *request) (bson.D, error) {
+	collection, err := s.collection(request)
+	if err != nil {
+		return nil, err
+	}
+
+	statements, _ := field(request.command, "updates").(bson.A)
+	ordered := boolField(request.command, "ordered", true)
+
+	var matched, modified int32
+	var upserted, writeErrors bson.A
+	for i, statement := range statements {
+		statement, _ := statement.(bson.D)
+		result, err := collection.update(
+			field(statement, "q"),
+			field(statement, "u"),
+			boolField(statement, "multi", false),
+			boolField(statement, "upsert", false),
+		)
+		if err != nil {
+			writeErrors = append(writeErrors, writeError(i, err))
+			if ordered {
+				break
+			}
+			continue
+		}
+
+		matched += int32(result.MatchedCount)
+		modified += int32(result.ModifiedCount)
+		if result.UpsertedCount > 0 {
+			matched++
+			upserted = append(upserted, bson.D{{Key: "index", Value: int32(i)}, {Key: "_id", Value: result.UpsertedID}})
+		}
+	}
+
+	reply := bson.D{{Key: "n", Value: matched}, {Key: "nModified", Value: modified}}
+	if len(upserted) > 0 {
+		reply = append(reply, bson.E{Key: "upserted", Value: upserted})
+	}
+
+	return withWriteErrors(reply, writeErrors), nil
+}
+
+func (s *Server) delete(request *request) (bson.D, error) {
+	collection, err := s.collection(request)
+	if err != nil {
+		return nil, err
+	}
+
+	statements, _ := field(request.command, "deletes").(bson.A)
+	ordered := boolField(request.command, "ordered", true)
+
+	var deleted int32
+	var writeErrors bson.A
+	for i, statement := range statements {
+		statement, _ := statement.(bson.D)
+		// A limit of 0 deletes every matching document, 1 the first one
+		result, err := collection.delete(field(statement, "q"), int64Field(statement, "limit") == 0)
+		if err != nil {
+			writeErrors = append(writeErrors, writeError(i, err))
+			if ordered {
+				break
+			}
+			continue
+		}
+		deleted += int32(result.DeletedCount)
+	}
+
+	return withWriteErrors(bson.D{{Key: "n", Value: deleted}}, writeErrors), nil
+}
+
+// findAndModify updates or removes the first matching document, the commands
+// run one at a time so counters incremented with FindOneAndUpdate stay unique
+func (s *Server) findAndModify(request *request) (bson.D, error) {
+	collection, err := s.collection(request)
+	if err != nil {
+		return nil, err
+	}
+
+	s.modifyMu.Lock()
+	defer s.modifyMu.Unlock()
+
+	documents, err := collection.find(documentField(request.command, "query"), findOptions{
+		sort:  documentField(request.command, "sort"),
+		limit: 1,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	projection := documentField(request.command, "fields")
+	lastErrorObject := bson.D{{Key: "n", Value: int32(len(documents))}}
+	var value interface{}
+	if len(documents) > 0 {
+		value = project(documents[0], projection)
+	}
+
+	if boolField(request.command, "remove", false) {
+		if len(documents) > 0 {
+			id, _ := lookupKey(documents[0], "_id")
+			if _, err := collection.delete(bson.D{{Key: "_id", Value: id}}, false); err != nil {
+				return nil, err
+			}
+		}
+
+		return bson.D{{Key: "lastErrorObject", Value: lastErrorObject}, {Key: "value", Value: value}}, nil
+	}
+
+	filter := documentField(request.command, "query")
+	if len(documents) > 0 {
+		id, _ := lookupKey(documents[0], "_id")
+		filter = bson.D{{Key: "_id", Value: id}}
+	}
+	result, err := collection.update(filter, documentField(request.command, "update"), false, boolField(request.command, "upsert", false))
+	if err != nil {
+		return nil, err
+	}
+
+	lastErrorObject = append(lastErrorObject, bson.E{Key: "updatedExisting", Value: result.MatchedCount > 0})
+	if result.UpsertedCount > 0 {
+		lastErrorObject[0].Value = int32(1)
+		lastErrorObject = append(lastErrorObject, bson.E{Key: "upserted", Value: result.UpsertedID})
+		filter = bson.D{{Key: "_id", Value: result.UpsertedID}}
+	}
+
+	if boolField(request.command, "new", false) && (len(documents) > 0 || result.UpsertedCount > 0) {
+		updated, err := collection.find(filter, findOptions{limit: 1, projection: projection})
+		if err != nil {
+			return nil, err
+		}
+		value = nil
+		if len(updated) > 0 {
+			value = updated[0]
+		}
+	}
+
+	return bson.D{{Key: "lastErrorObject", Value: lastErrorObject}, {Key: "value", Value: value}}, nil
+}
+
+func (s *Server) find(request *request) (bson.D, error) {
+	collection, err := s.collection(request)
+	if err != nil {
+		return nil, err
+	}
+
+	limit := int64Field(request.command, "limit")
+	documents, err := collection.find(documentField(request.command, "filter"), findOptions{
+		sort:       documentField(request.command, "sort"),
+		skip:       int64Field(request.command, "skip"),
+		limit:      limit,
+		projection: documentField(request.command, "projection"),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	singleBatch := boolField(request.command, "singleBatch", false) || limit < 0
+
+	return s.cursorReply(collection.namespace(), documents, int64Field(request.command, "batchSize"), singleBatch), nil
+}
+
+func (s *Server) getMore(request *request) (bson.D, error) {
+	id, _ := request.command[0].Value.(int64)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	cursor, ok := s.cursors[id]
+	if !ok {
+		return nil, mongo.CommandError{Code: 43, Name: "CursorNotFound", Message: fmt.Sprintf("cursor id %d not found", id)}
+	}
+
+	batch := cursor.documents
+	if batchSize := int64Field(request.command, "batchSize"); batchSize > 0 && batchSize < int64(len(batch)) {
+		batch = batch[:batchSize]
+	}
+	cursor.documents = cursor.documents[len(batch):]
+	if len(cursor.documents) == 0 {
+		delete(s.cursors, id)
+		id = 0
+	}
+
+	return bson.D{{Key: "cursor", Value: bson.D{
+		{Key: "nextBatch", Value: toArray(batch)},
+		{Key: "id", Value: id},
+		{Key: "ns", Value: cursor.namespace},
+	}}}, nil
+}
+
+func (s *Server) killCursors(request *request) (bson.D, error) {
+	ids, _ := field(request.command, "cursors").(bson.A)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	killed, notFound := bson.A{}, bson.A{}
+	for _, id := range ids {
+		id, _ := id.(int64)
+		if _, ok := s.cursors[id]; !ok {
+			notFound = append(notFound, id)
+			continue
+		}
+		delete(s.cursors, id)
+		killed = append(killed, id)
+	}
+
+	return bson.D{
+		{Key: "cursorsKilled", Value: killed},
+		{Key: "cursorsNotFound", Value: notFound},
+		{Key: "cursorsAlive", Value: bson.A{}},
+		{Key: "cursorsUnknown", Value: bson.A{}},
+	}, nil
+}
+
+func (s *Server) count(request *request) (bson.D, error) {
+	collection, err := s.collection(request)
+	if err != nil {
+		return nil, err
+	}
+
+	documents, err := collection.find(documentField(request.command, "query"), findOptions{
+		skip:  int64Field(request.command, "skip"),
+		limit: int64Field(request.command, "limit"),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return bson.D{{Key: "n", Value: int32(len(documents))}}, nil
+}
+
+// aggregate runs the $match, $sort, $skip, $limit, $project and $count
+// stages, and the $group stage with a constant _id and $sum accumulators
+// CountDocuments sends
+func (s *Server) aggregate(request *request) (bson.D, error) {
+	collection, err := s.collection(request)
+	if err != nil {
+		return nil, err
+	}
+
+	pipeline, _ := field(request.command, "pipeline").(bson.A)
+	documents, err := collection.aggregate(pipeline)
+	if err != nil {
+		return nil, err
+	}
+
+	cursor := documentField(request.command, "cursor")
+
+	return s.cursorReply(collection.namespace(), documents, int64Field(cursor, "batchSize"), false), nil
+}
+
+// createOptions are the options of create kept for listCollections
+var createOptions = map[string]bool{
+	"capped":             true,
+	"size":               true,
+	"max":                true,
+	"timeseries":         true,
+	"expireAfterSeconds": true,
+	"clusteredIndex":     true,
+	"validator":          true,
+	"validationLevel":    true,
+	"validationAction":   true,
+	"viewOn":             true,
+	"pipeline":           true,
+}
+
+func (s *Server) create(request *request) (bson.D, error) {
+	name, err := collectionName(request)
+	if err != nil {
+		return nil, err
+	}
+
+	// The options are listed by listCollections, only their type and the
+	// pipeline of a view are used
+	kind := "collection"
+	var options bson.D
+	for _, element := range request.command[1:] {
+		if !createOptions[element.Key] {
+			continue
+		}
+		switch element.Key {
+		case "timeseries":
+			kind = "timeseries"
+		case "viewOn":
+			kind = "view"
+		}
+		options = append(options, element)
+	}
+
+	database := &Database{store: s.store, name: request.database}
+	if err := database.createCollection(name, kind, options); err != nil {
+		return nil, err
+	}
+
+	return bson.D{}, nil
+}
+
+// collMod accepts the changes of an existing collection, validators are not
+// enforced
+func (s *Server) collMod(request *request) (bson.D, error) {
+	collection, err := s.collection(request)
+	if err != nil {
+		return nil, err
+	}
+
+	return bson.D{}, collection.SetValidator(nil, "", "")
+}
+
+func (s *Server) listCollections(request *request) (bson.D, error) {
+	database := &Database{store: s.store, name: request.database}
+	specifications, err := database.specifications(documentField(request.command, "filter"))
+	if err != nil {
+		return nil, err
+	}
+
+	cursor := documentField(request.command, "cursor")
+
+	return s.cursorReply(request.database+".$cmd.listCollections", specifications, int64Field(cursor, "batchSize"), false), nil
+}
+
+func (s *Server) listDatabases(request *request) (bson.D, error) {
+	databases, err := (&Client{store: s.store}).ListDatabases()
+	if err != nil {
+		return nil, err
+	}
+
+	filter := documentField(request.command, "filter")
+	var specifications bson.A
+	var totalSize int64
+	for _, database := range databases {
+		specification := bson.D{
+			{Key: "name", Value: database.Name},
+			{Key: "sizeOnDisk", Value: database.SizeOnDisk},
+			{Key: "empty", Value: database.Empty},
+		}
+
+		matched, err := matches(specification, filter)
+		if err != nil {
+			return nil, err
+		}
+		if matched {
+			specifications = append(specifications, specification)
+			totalSize += database.SizeOnDisk
+		}
+	}
+
+	return bson.D{
+		{Key: "databases", Value: specifications},
+		{Key: "totalSize", Value: totalSize},
+	}, nil
+}
+
+func (s *Server) drop(request *request) (bson.D, error) {
+	collection, err := s.collection(request)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := collection.Drop(); err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	delete(s.indexes, collection.namespace())
+	s.mu.Unlock()
+
+	return bson.D{{Key: "ns", Value: collection.namespace()}}, nil
+}
+
+func (s *Server) dropDatabase(request *request) (bson.D, error) {
+	database := &Database{store: s.store, name: request.database}
+	if err := database.Drop(); err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	for namespace := range s.indexes {
+		if strings.HasPrefix(namespace, request.database+".") {
+			delete(s.indexes, namespace)
+		}
+	}
+	s.mu.Unlock()
+
+	return bson.D{{Key: "dropped", Value: request.database}}, nil
+}
+
+// createIndexes records the index specifications for listIndexes, the
+// indexes are not used by queries and unique indexes are not enforced
+func (s *Server) createIndexes(request *request) (bson.D, error) {
+	collection, err := s.collection(request)
+	if err != nil {
+		return nil, err
+	}
+
+	// Creating an index creates the collection like the first insert does
+	created := false
+	collection.store.mu.Lock()
+	if _, ok := collection.store.databases[collection.database][collection.name]; !ok {
+		collection.setDocuments(nil)
+		created = true
+	}
+	collection.store.mu.Unlock()
+
+	specifications, _ := field(request.command, "indexes").(bson.A)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	namespace := collection.namespace()
+	before := int32(len(s.indexes[namespace]) + 1)
+	for _, specification := range specifications {
+		specification, _ := specification.(bson.D)
+		name, _ := field(specification, "name").(string)
+		if name == "" || len(documentField(specification, "key")) == 0 {
+			return nil, mongo.CommandError{Code: 67, Name: "CannotCreateIndex", Message: "index specification must have a name and a key"}
+		}
+
+		exists := false
+		for _, index := range s.indexes[namespace] {
+			if field(index, "name") == name {
+				exists = true
+				break
+			}
+		}
+		if !exists {
+			s.indexes[namespace] = append(s.indexes[namespace], append(bson.D{{Key: "v", Value: int32(2)}}, specification...))
+		}
+	}
+
+	return bson.D{
+		{Key: "createdCollectionAutomatically", Value: created},
+		{Key: "numIndexesBefore", Value: before},
+		{Key: "numIndexesAfter", Value: int32(len(s.indexes[namespace]) + 1)},
+	}, nil
+}
+
+func (s *Server) listIndexes(request *request) (bson.D, error) {
+	collection, err := s.collection(request)
+	if err != nil {
+		return nil, err
+	}
+
+	collection.store.mu.RLock()
+	_, ok := collection.store.databases[collection.database][collection.name]
+	collection.store.mu.RUnlock()
+	if !ok {
+		return nil, mongo.CommandError{Code: 26, Name: "NamespaceNotFound", Message: fmt.Sprintf("ns does not exist: %s", collection.namespace())}
+	}
+
+	s.mu.Lock()
+	indexes := []bson.D{{
+		{Key: "v", Value: int32(2)},
+		{Key: "key", Value: bson.D{{Key: "_id", Value: int32(1)}}},
+		{Key: "name", Value: "_id_"},
+	}}
+	indexes = append(indexes, s.indexes[collection.namespace()]...)
+	s.mu.Unlock()
+
+	cursor := documentField(request.command, "cursor")
+
+	return s.cursorReply(collection.namespace(), indexes, int64Field(cursor, "batchSize"), false), nil
+}
+
+func (s *Server) dropIndexes(request *request) (bson.D, error) {
+	collection, err := s.collection(request)
+	if err != nil {
+		return nil, err
+	}
+
+	name, _ := field(request.command, "index").(string)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	namespace := collection.namespace()
+	before := int32(len(s.indexes[namespace]) + 1)
+	if name == "*" {
+		delete(s.indexes, namespace)
+		return bson.D{{Key: "nIndexesWas", Value: before}}, nil
+	}
+
+	for i, index := range s.indexes[namespace] {
+		if field(index, "name") == name {
+			s.indexes[namespace] = append(s.indexes[namespace][:i:i], s.indexes[namespace][i+1:]...)
+			return bson.D{{Key: "nIndexesWas", Value: before}}, nil
+		}
+	}
+
+	return nil, mongo.CommandError{Code: 27, Name: "IndexNotFound", Message: fmt.Sprintf("index not found with name [%s]", name)}
+}
+
+func (s *Server) collection(request *request) (*Collection, error) {
+	name, err := collectionName(request)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Collection{
+		store:    s.store,
+		database: request.database,
+		name:     name,
+	}, nil
+}
+
+// cursorReply returns the first batch of the documents, the others are kept
+// for getMore unless a single batch is requested
+func (s *Server) cursorReply(namespace string, documents []bson.D, batchSize int64, singleBatch bool) bson.D {
+	if batchSize <= 0 {
+		batchSize = defaultBatchSize
+	}
+
+	batch := documents
+	if batchSize < int64(len(batch)) {
+		batch = batch[:batchSize]
+	}
+
+	var id int64
+	if rest := documents[len(batch):]; len(rest) > 0 && !singleBatch {
+		id = s.cursorID.Add(1)
+		s.mu.Lock()
+		s.cursors[id] = &serverCursor{namespace: namespace, documents: rest}
+		s.mu.Unlock()
+	}
+
+	return bson.D{{Key: "cursor", Value: bson.D{
+		{Key: "firstBatch", Value: toArray(batch)},
+		{Key: "id", Value: id},
+		{Key: "ns", Value: namespace},
+	}}}
+}
+
+func runStage(documents []bson.D, stage bson.E) ([]bson.D, error) {
+	switch stage.Key {
+	case "$match":
+		filter, _ := stage.Value.(bson.D)
+		var matched []bson.D
+		for _, document := range documents {
+			ok, err := matches(document, filter)
+			if err != nil {
+				return nil, err
+			}
+			if ok {
+				matched = append(matched, document)
+			}
+		}
+		return matched, nil
+	case "$sort":
+		fields, _ := stage.Value.(bson.D)
+		sortDocuments(documents, fields)
+		return documents, nil
+	case "$skip":
+		skip, _ := toFloat(stage.Value)
+		return documents[min(int(skip), len(documents)):], nil
+	case "$limit":
+		limit, _ := toFloat(stage.Value)
+		return documents[:min(int(limit), len(documents))], nil
+	case "$project":
+		projection, _ := stage.Value.(bson.D)
+		for i, document := range documents {
+			documents[i] = project(document, projection)
+		}
+		return documents, nil
+	case "$count":
+		if len(documents) == 0 {
+			return nil, nil
+		}
+		name, _ := stage.Value.(string)
+		return []bson.D{{{Key: name, Value: int32(len(documents))}}}, nil
+	case "$group":
+		specification, _ := stage.Value.(bson.D)
+		return group(documents, specification)
+	}
+
+	return nil, fmt.Errorf("the %s stage is %w", stage.Key, ErrNotSupported)
+}
+
+// group puts every document in a single group, only a constant _id and $sum
+// of a constant are supported
+func group(documents []bson.D, specification bson.D) ([]bson.D, error) {
+	if len(documents) == 0 {
+		return nil, nil
+	}
+
+	var result bson.D
+	for _, element := range specification {
+		if element.Key == "_id" {
+			if path, ok := element.Value.(string); ok && isOperator(path) {
+				return nil, fmt.Errorf("grouping by a field is %w", ErrNotSupported)
+			}
+			result = append(result, element)
+			continue
+		}
+
+		accumulator, _ := element.Value.(bson.D)
+		value, ok := lookupKey(accumulator, "$sum")
+		if !ok || len(accumulator) != 1 {
+			return nil, fmt.Errorf("the %s accumulator is %w", element.Key, ErrNotSupported)
+		}
+
+		var sum interface{}
+		switch value := value.(type) {
+		case int32:
+			sum = int64(value) * int64(len(documents))
+		case int64:
+			sum = value * int64(len(documents))
+		case float64:
+			sum = value * float64(len(documents))
+		default:
+			return nil, fmt.Errorf("$sum of a field is %w", ErrNotSupported)
+		}
+		result = append(result, bson.E{Key: element.Key, Value: sum})
+	}
+
+	return []bson.D{result}, nil
+}
+
+func collectionName(request *request) (string, error) {
+	name, ok := request.command[0].Value.(string)
+	if !ok || name == "" {
+		return "", mongo.CommandError{
+			Code:    73,
+			Name:    "InvalidNamespace",
+			Message: fmt.Sprintf("collection name has invalid type %T", request.command[0].Value),
+		}
+	}
+
+	return name, nil
+}
+
+// errorReply converts the error to a command failure, the errors of the
+// in-memory collection are reported as BadValue
+func errorReply(err error) bson.D {
+	var commandError mongo.CommandError
+	switch {
+	case errors.As(err, &commandError):
+	case errors.Is(err, ErrNotSupported):
+		commandError = mongo.CommandError{Code: 115, Name: "CommandNotSupported", Message: err.Error()}
+	default:
+		commandError = mongo.CommandError{Code: 2, Name: "BadValue", Message: err.Error()}
+	}
+
+	return bson.D{
+		{Key: "ok", Value: 0.0},
+		{Key: "errmsg", Value: commandError.Message},
+		{Key: "code", Value: commandError.Code},
+		{Key: "codeName", Value: commandError.Name},
+	}
+}
+
+func writeError(index int, err error) bson.D {
+	code, message := int32(2), err.Error()
+
+	var writeErr mongo.WriteError
+	if errors.As(err, &writeErr) {
+		code, message = int32(writeErr.Code), writeErr.Message
+	} else if errors.Is(err, ErrNotSupported) {
+		code = 115
+	}
+
+	return bson.D{
+		{Key: "index", Value: int32(index)},
+		{Key: "code", Value: code},
+		{Key: "errmsg", Value: message},
+	}
+}
+
+func withWriteErrors(reply bson.D, writeErrors bson.A) bson.D {
+	if len(writeErrors) == 0 {
+		return reply
+	}
+
+	return append(reply, bson.E{Key: "writeErrors", Value: writeErrors})
+}
+
+func toArray(documents []bson.D) bson.A {
+	array := bson.A{}
+	for _, document := range documents {
+		array = append(array, document)
+	}
+
+	return array
+}
+
+func field(document bson.D, key string) interface{} {
+	value, _ := lookupKey(document, key)
+
+	return value
+}
+
+func documentField(document bson.D, key string) bson.D {
+	value, _ := field(document, key).(bson.D)
+
+	return value
+}
+
+func int64Field(document bson.D, key string) int64 {
+	value, _ := toFloat(field(document, key))
+
+	return int64(value)
+}
+
+func boolField(document bson.D, key string, fallback bool) bool {
+	if value, ok := field(document, key).(bool); ok {
+		return value
+	}
+
+	return fallback
+}
@@ -0,0 +1,349 @@
+package mongodbtest
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	mongodb "github.com/portofolio-mager/goravel-mongodb"
+	"github.com/portofolio-mager/goravel-mongodb/contracts"
+)
+
+var _ contracts.Database = &Database{}
+
+type Database struct {
+	store *store
+	name  string
+}
+
+// Native returns nil, there is no underlying driver database
+func (d *Database) Native() *mongo.Database {
+	return nil
+}
+
+func (d *Database) Collection(name string) contracts.Collection {
+	return &Collection{
+		store:    d.store,
+		database: d.name,
+		name:     name,
+	}
+}
+
+func (d *Database) Watch(pipeline interface{}, opts ...interface{}) (contracts.ChangeStream, error) {
+	return nil, fmt.Errorf("change streams are %w", ErrNotSupported)
+}
+
+func (d *Database) GridFS(bucket ...string) (contracts.GridFS, error) {
+	return nil, fmt.Errorf("GridFS is %w", ErrNotSupported)
+}
+
+// LoadFixtures loads the fixture files like mongodb.Database.LoadFixtures
+func (d *Database) LoadFixtures(dir string) error {
+	files, err := filepath.Glob(filepath.Join(dir, "*"+mongodb.FixtureExtension))
+	if err != nil {
+		return err
+	}
+	sort.Strings(files)
+
+	for _, file := range files {
+		documents, err := mongodb.ReadFixture(file)
+		if err != nil {
+			return fmt.Errorf("failed to read fixture %s: %w", file, err)
+		}
+
+		collection := d.Collection(strings.TrimSuffix(filepath.Base(file), mongodb.FixtureExtension))
+		if _, err := collection.DeleteMany(bson.M{}); err != nil {
+			return err
+		}
+		if len(documents) == 0 {
+			continue
+		}
+		if _, err := collection.InsertMany(documents); err != nil {
+			return fmt.Errorf("failed to load fixture %s: %w", file, err)
+		}
+	}
+
+	return nil
+}
+
+// DumpFixtures writes the fixture files like mongodb.Database.DumpFixtures
+func (d *Database) DumpFixtures(dir string, collections ...string) error {
+	if len(collections) == 0 {
+		collections = d.collectionNames()
+	}
+
+	if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+		return err
+	}
+
+	for _, name := range collections {
+		documents, err := d.Collection(name).(*Collection).find(bson.D{}, findOptions{sort: bson.D{{Key: "_id", Value: int32(1)}}})
+		if err != nil {
+			return err
+		}
+
+		raws := make([]bson.Raw, len(documents))
+		for i, document := range documents {
+			if raws[i], err = bson.Marshal(document); err != nil {
+				return err
+			}
+		}
+
+		if err := mongodb.WriteFixture(filepath.Join(dir, name+mongodb.FixtureExtension), raws); err != nil {
+			return fmt.Errorf("failed to dump collection %s: %w", name, err)
+		}
+	}
+
+	return nil
+}
+
+// CreateCollection creates an empty collection, the options and validators
+// are ignored
+func (d *Database) CreateCollection(name string, opts ...interface{}) error {
+	return d.createCollection(name, "collection", nil)
+}
+
+// CreateTimeSeries creates a collection listed as a time-series one, the
+// documents are stored and expire like in a regular collection
+func (d *Database) CreateTimeSeries(name, timeField, metaField, granularity string, expireAfter time.Duration) error {
+	timeSeries := bson.D{{Key: "timeField", Value: timeField}}
+	if metaField != "" {
+		timeSeries = append(timeSeries, bson.E{Key: "metaField", Value: metaField})
+	}
+	if granularity != "" {
+		timeSeries = append(timeSeries, bson.E{Key: "granularity", Value: granularity})
+	}
+
+	options := bson.D{{Key: "timeseries", Value: timeSeries}}
+	if expireAfter > 0 {
+		options = append(options, bson.E{Key: "expireAfterSeconds", Value: int64(expireAfter.Seconds())})
+	}
+
+	return d.createCollection(name, "timeseries", options)
+}
+
+// CreateCapped creates a collection listed as a capped one, the size and
+// number of documents are not limited
+func (d *Database) CreateCapped(name string, size, max int64) error {
+	options := bson.D{{Key: "capped", Value: true}, {Key: "size", Value: size}}
+	if max > 0 {
+		options = append(options, bson.E{Key: "max", Value: max})
+	}
+
+	return d.createCollection(name, "collection", options)
+}
+
+func (d *Database) CreateClustered(name string) error {
+	return d.createCollection(name, "collection", bson.D{{Key: "clusteredIndex", Value: bson.D{
+		{Key: "v", Value: int32(2)},
+		{Key: "key", Value: bson.D{{Key: "_id", Value: int32(1)}}},
+		{Key: "name", Value: "_id_"},
+		{Key: "unique", Value: true},
+	}}})
+}
+
+// CreateView creates a view, reading it runs the stages the server's aggregate
+// command supports on the source collection
+func (d *Database) CreateView(name, source string, pipeline interface{}) error {
+	stages, err := toPipeline(pipeline)
+	if err != nil {
+		return err
+	}
+
+	return d.createCollection(name, "view", bson.D{
+		{Key: "viewOn", Value: source},
+		{Key: "pipeline", Value: stages},
+	})
+}
+
+func (d *Database) CollectionInfo(name string) (*contracts.CollectionInfo, error) {
+	infos, err := d.ListCollectionSpecs(bson.D{{Key: "name", Value: name}})
+	if err != nil {
+		return nil, err
+	}
+	if len(infos) == 0 {
+		return nil, fmt.Errorf("%w: %s.%s", mongodb.CollectionNotFound, d.name, name)
+	}
+
+	return &infos[0], nil
+}
+
+// ListCollectionSpecs describes the collections like
+// mongodb.Database.ListCollectionSpecs, the UUID is always empty
+func (d *Database) ListCollectionSpecs(filter interface{}) ([]contracts.CollectionInfo, error) {
+	if pattern, ok := filter.(string); ok {
+		filter = bson.D{{Key: "name", Value: mongodb.NamePattern(pattern)}}
+	}
+	filterDocument, err := toDocument(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	specifications, err := d.specifications(filterDocument)
+	if err != nil {
+		return nil, err
+	}
+
+	infos := make([]contracts.CollectionInfo, len(specifications))
+	for i, specification := range specifications {
+		// Round trip the options to decode them like the driver
+		data, err := bson.Marshal(field(specification, "options"))
+		if err != nil {
+			return nil, err
+		}
+
+		infos[i] = contracts.CollectionInfo{
+			Name:     field(specification, "name").(string),
+			Type:     field(specification, "type").(string),
+			ReadOnly: field(specification, "type") == "view",
+			Options:  bson.M{},
+		}
+		if err := bson.Unmarshal(data, &infos[i].Options); err != nil {
+			return nil, err
+		}
+		if validator, ok := infos[i].Options["validator"].(bson.M); ok {
+			infos[i].Validator = validator
+		}
+	}
+
+	return infos, nil
+}
+
+// specifications returns the listCollections documents of the collections and
+// views matching the filter
+func (d *Database) specifications(filter bson.D) ([]bson.D, error) {
+	views, err := d.ListViews()
+	if err != nil {
+		return nil, err
+	}
+	names := append(d.collectionNames(), views...)
+
+	var specifications []bson.D
+	for _, name := range names {
+		d.store.mu.RLock()
+		created := d.store.specification(d.name, name)
+		d.store.mu.RUnlock()
+		if created.options == nil {
+			created.options = bson.D{}
+		}
+
+		specification := bson.D{
+			{Key: "name", Value: name},
+			{Key: "type", Value: created.kind},
+			{Key: "options", Value: created.options},
+			{Key: "info", Value: bson.D{{Key: "readOnly", Value: created.kind == "view"}}},
+		}
+
+		matched, err := matches(specification, filter)
+		if err != nil {
+			return nil, err
+		}
+		if matched {
+			specifications = append(specifications, specification)
+		}
+	}
+
+	return specifications, nil
+}
+
+// createCollection creates an empty collection of the type with the options
+func (d *Database) createCollection(name, kind string, options bson.D) error {
+	d.store.mu.Lock()
+	defer d.store.mu.Unlock()
+
+	if d.store.exists(d.name, name) {
+		return mongo.CommandError{
+			Code:    48,
+			Name:    "NamespaceExists",
+			Message: fmt.Sprintf("Collection %s.%s already exists.", d.name, name),
+		}
+	}
+
+	// A view has no documents of its own
+	if kind != "view" {
+		collections := d.store.databases[d.name]
+		if collections == nil {
+			collections = make(map[string][]bson.D)
+			d.store.databases[d.name] = collections
+		}
+		collections[name] = []bson.D{}
+	}
+
+	if kind != "collection" || len(options) > 0 {
+		if d.store.specifications[d.name] == nil {
+			d.store.specifications[d.name] = make(map[string]specification)
+		}
+		d.store.specifications[d.name][name] = specification{kind: kind, options: options}
+	}
+
+	return nil
+}
+
+// ListCollections returns the names of the collections and the views
+func (d *Database) ListCollections() ([]string, error) {
+	views, err := d.ListViews()
+	if err != nil {
+		return nil, err
+	}
+
+	names := append(d.collectionNames(), views...)
+	sort.Strings(names)
+
+	return names, nil
+}
+
+func (d *Database) ListViews() ([]string, error) {
+	d.store.mu.RLock()
+	defer d.store.mu.RUnlock()
+
+	var names []string
+	for name, specification := range d.store.specifications[d.name] {
+		if specification.kind == "view" {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	return names, nil
+}
+
+// collectionNames returns the names of the collections, the views have no
+// documents of their own
+func (d *Database) collectionNames() []string {
+	d.store.mu.RLock()
+	defer d.store.mu.RUnlock()
+
+	var names []string
+	for name := range d.store.databases[d.name] {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+func (d *Database) Drop() error {
+	d.store.mu.Lock()
+	defer d.store.mu.Unlock()
+
+	delete(d.store.databases, d.name)
+	delete(d.store.specifications, d.name)
+
+	return nil
+}
+
+func (d *Database) Name() string {
+	return d.name
+}
+
+// WithContext returns the database as is, the in-memory operations take no context
+func (d *Database) WithContext(ctx context.Context) contracts.Database {
+	return d
+}
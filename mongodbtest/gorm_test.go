@@ -0,0 +1,186 @@
+package mongodbtest
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/bson"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/logger"
+
+	mongodb "github.com/portofolio-mager/goravel-mongodb"
+)
+
+type gormUser struct {
+	ID        uint
+	Name      string
+	Age       int
+	CreatedAt time.Time
+	DeletedAt gorm.DeletedAt
+}
+
+type gormPost struct {
+	ID    string
+	Title string
+}
+
+type gormNullableDocument struct {
+	ID   sql.NullString `gorm:"primaryKey"`
+	Name string
+}
+
+type gormPointerDocument struct {
+	ID   *string `gorm:"primaryKey"`
+	Name string
+}
+
+type GormTestSuite struct {
+	suite.Suite
+	server *Server
+	db     *gorm.DB
+}
+
+func TestGormTestSuite(t *testing.T) {
+	suite.Run(t, new(GormTestSuite))
+}
+
+func (s *GormTestSuite) SetupTest() {
+	server, err := NewServer()
+	s.Require().NoError(err)
+	s.server = server
+
+	db, err := gorm.Open(mongodb.Open(server.URI()+"/goravel"), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	s.Require().NoError(err)
+	s.db = db
+
+	s.Require().NoError(db.Create(&[]gormUser{
+		{Name: "Goravel", Age: 18},
+		{Name: "Laravel", Age: 30},
+		{Name: "Symfony", Age: 25},
+	}).Error)
+}
+
+func (s *GormTestSuite) TearDownTest() {
+	sqlDB, err := s.db.DB()
+	s.NoError(err)
+	s.NoError(sqlDB.Close())
+	s.NoError(s.server.Close())
+}
+
+func (s *GormTestSuite) TestCreate() {
+	user := gormUser{Name: "Rails", Age: 20}
+	s.NoError(s.db.Create(&user).Error)
+	s.Equal(uint(4), user.ID)
+
+	var stored bson.M
+	s.NoError(s.server.Client("goravel").Collection("gorm_users").FindOne(bson.M{"_id": int64(4)}, &stored))
+	s.Equal("Rails", stored["name"])
+
+	post := gormPost{Title: "Goravel"}
+	s.NoError(s.db.Create(&post).Error)
+	s.Len(post.ID, 24)
+
+	// Existing ids conflict unless the conflict is resolved
+	s.Error(s.db.Create(&gormUser{ID: 1, Name: "Duplicate"}).Error)
+	s.NoError(s.db.Clauses(clause.OnConflict{DoNothing: true}).Create(&gormUser{ID: 1, Name: "Duplicate"}).Error)
+	s.NoError(s.db.Clauses(clause.OnConflict{UpdateAll: true}).Create(&gormUser{ID: 2, Name: "Upserted"}).Error)
+
+	var names []string
+	s.NoError(s.db.Model(&gormUser{}).Order("id").Pluck("name", &names).Error)
+	s.Equal([]string{"Goravel", "Upserted", "Symfony", "Rails"}, names)
+}
+
+func (s *GormTestSuite) TestCreateWithNullableKey() {
+	nullable := gormNullableDocument{Name: "Goravel"}
+	s.NoError(s.db.Create(&nullable).Error)
+	s.True(nullable.ID.Valid)
+	s.Len(nullable.ID.String, 24)
+
+	pointer := gormPointerDocument{Name: "Goravel"}
+	s.NoError(s.db.Create(&pointer).Error)
+	s.Require().NotNil(pointer.ID)
+	s.Len(*pointer.ID, 24)
+
+	var stored gormPointerDocument
+	s.NoError(s.db.First(&stored, "id = ?", *pointer.ID).Error)
+	s.Equal("Goravel", stored.Name)
+}
+
+func (s *GormTestSuite) TestQuery() {
+	var user gormUser
+	s.NoError(s.db.First(&user, 2).Error)
+	s.Equal("Laravel", user.Name)
+	s.False(user.CreatedAt.IsZero())
+
+	s.ErrorIs(s.db.First(&user, 10).Error, gorm.ErrRecordNotFound)
+
+	var users []gormUser
+	s.NoError(s.db.Where("age >= ? AND name <> ?", 20, "Symfony").Find(&users).Error)
+	s.Len(users, 1)
+	s.Equal("Laravel", users[0].Name)
+
+	s.NoError(s.db.Where("name IN ?", []string{"Goravel", "Symfony"}).Or("age", 30).Order("age desc").Find(&users).Error)
+	s.Equal([]string{"Laravel", "Symfony", "Goravel"}, []string{users[0].Name, users[1].Name, users[2].Name})
+
+	s.NoError(s.db.Where(&gormUser{Name: "Goravel"}).Find(&users).Error)
+	s.Len(users, 1)
+
+	s.NoError(s.db.Where("name LIKE ?", "%vel").Order("name").Offset(1).Limit(1).Find(&users).Error)
+	s.Len(users, 1)
+	s.Equal("Laravel", users[0].Name)
+
+	var count int64
+	s.NoError(s.db.Model(&gormUser{}).Where("age > ?", 18).Count(&count).Error)
+	s.Equal(int64(2), count)
+
+	var result map[string]interface{}
+	s.NoError(s.db.Model(&gormUser{}).Select("name").Where("id = ?", 3).Take(&result).Error)
+	s.Equal(map[string]interface{}{"id": int64(3), "name": "Symfony"}, result)
+
+	s.ErrorIs(s.db.Joins("Posts").Find(&users).Error, mongodb.UnsupportedStatement)
+	s.ErrorIs(s.db.Where("age > ? OR age < ?", 20, 10).Find(&users).Error, mongodb.UnsupportedStatement)
+}
+
+func (s *GormTestSuite) TestUpdate() {
+	result := s.db.Model(&gormUser{}).Where("age < ?", 30).Update("age", 40)
+	s.NoError(result.Error)
+	s.Equal(int64(2), result.RowsAffected)
+
+	var user gormUser
+	s.NoError(s.db.First(&user, 1).Error)
+	s.Equal(40, user.Age)
+
+	user.Name = "Goravel 2"
+	s.NoError(s.db.Save(&user).Error)
+	s.NoError(s.db.Model(&user).Updates(map[string]interface{}{"age": 50}).Error)
+
+	var updated gormUser
+	s.NoError(s.db.First(&updated, 1).Error)
+	s.Equal("Goravel 2", updated.Name)
+	s.Equal(50, updated.Age)
+
+	s.ErrorIs(s.db.Model(&gormUser{}).Update("age", 1).Error, gorm.ErrMissingWhereClause)
+}
+
+func (s *GormTestSuite) TestDelete() {
+	s.NoError(s.db.Delete(&gormUser{}, 1).Error)
+
+	var users []gormUser
+	s.NoError(s.db.Find(&users).Error)
+	s.Len(users, 2)
+	s.NoError(s.db.Unscoped().Find(&users).Error)
+	s.Len(users, 3)
+
+	result := s.db.Unscoped().Where("age >= ?", 25).Delete(&gormUser{})
+	s.NoError(result.Error)
+	s.Equal(int64(2), result.RowsAffected)
+
+	var count int64
+	s.NoError(s.db.Unscoped().Model(&gormUser{}).Count(&count).Error)
+	s.Equal(int64(1), count)
+
+	s.ErrorIs(s.db.Delete(&gormUser{}).Error, gorm.ErrMissingWhereClause)
+}
@@ -0,0 +1,281 @@
+package mongodbtest
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// matches reports whether the document matches the filter. The supported
+// operators are $and, $or, $nor, $eq, $ne, $gt, $gte, $lt, $lte, $in, $nin,
+// $exists, $regex, $not, $size, $all and $elemMatch.
+func matches(document bson.D, filter bson.D) (bool, error) {
+	for _, element := range filter {
+		matched, err := matchElement(document, element)
+		if err != nil || !matched {
+			return false, err
+		}
+	}
+
+	return true, nil
+}
+
+func matchElement(document bson.D, element bson.E) (bool, error) {
+	switch element.Key {
+	case "$and", "$or", "$nor":
+		filters, ok := element.Value.(bson.A)
+		if !ok || len(filters) == 0 {
+			return false, fmt.Errorf("%s must be a nonempty array", element.Key)
+		}
+
+		for _, filter := range filters {
+			filter, ok := filter.(bson.D)
+			if !ok {
+				return false, fmt.Errorf("%s entries need to be full objects", element.Key)
+			}
+
+			matched, err := matches(document, filter)
+			if err != nil {
+				return false, err
+			}
+			switch {
+			case element.Key == "$and" && !matched:
+				return false, nil
+			case element.Key == "$or" && matched:
+				return true, nil
+			case element.Key == "$nor" && matched:
+				return false, nil
+			}
+		}
+
+		return element.Key != "$or", nil
+	case "$comment":
+		return true, nil
+	}
+
+	if isOperator(element.Key) {
+		return false, fmt.Errorf("unknown top level operator: %s", element.Key)
+	}
+
+	return matchCondition(lookup(document, strings.Split(element.Key, ".")), element.Value)
+}
+
+// matchCondition matches the values found at a path against a value or a
+// document of operators
+func matchCondition(values []interface{}, condition interface{}) (bool, error) {
+	if !isOperatorDocument(condition) {
+		if regex, ok := condition.(primitive.Regex); ok {
+			return matchRegex(values, regex.Pattern, regex.Options)
+		}
+
+		return matchEqual(values, condition), nil
+	}
+
+	operators := condition.(bson.D)
+	for _, operator := range operators {
+		matched, err := matchOperator(values, operator, operators)
+		if err != nil || !matched {
+			return false, err
+		}
+	}
+
+	return true, nil
+}
+
+func matchOperator(values []interface{}, operator bson.E, operators bson.D) (bool, error) {
+	switch operator.Key {
+	case "$eq":
+		return matchEqual(values, operator.Value), nil
+	case "$ne":
+		return !matchEqual(values, operator.Value), nil
+	case "$gt", "$gte", "$lt", "$lte":
+		for _, value := range expand(values) {
+			if typeOrder(value) != typeOrder(operator.Value) {
+				continue
+			}
+
+			result := compare(value, operator.Value)
+			if (operator.Key == "$gt" && result > 0) || (operator.Key == "$gte" && result >= 0) ||
+				(operator.Key == "$lt" && result < 0) || (operator.Key == "$lte" && result <= 0) {
+				return true, nil
+			}
+		}
+		return false, nil
+	case "$in", "$nin":
+		candidates, ok := operator.Value.(bson.A)
+		if !ok {
+			return false, fmt.Errorf("%s needs an array", operator.Key)
+		}
+
+		matched, err := matchIn(values, candidates)
+		if err != nil {
+			return false, err
+		}
+		return matched == (operator.Key == "$in"), nil
+	case "$exists":
+		return truthy(operator.Value) == (len(values) > 0), nil
+	case "$regex":
+		var pattern, options string
+		switch regex := operator.Value.(type) {
+		case string:
+			pattern = regex
+		case primitive.Regex:
+			pattern, options = regex.Pattern, regex.Options
+		default:
+			return false, fmt.Errorf("$regex has to be a string")
+		}
+		if value, ok := lookupKey(operators, "$options"); ok {
+			options = stringOf(value)
+		}
+		return matchRegex(values, pattern, options)
+	case "$options":
+		if _, ok := lookupKey(operators, "$regex"); !ok {
+			return false, fmt.Errorf("$options needs a $regex")
+		}
+		return true, nil
+	case "$not":
+		if _, ok := operator.Value.(primitive.Regex); !ok && !isOperatorDocument(operator.Value) {
+			return false, fmt.Errorf("$not needs a regex or a document")
+		}
+		matched, err := matchCondition(values, operator.Value)
+		return !matched, err
+	case "$size":
+		size, ok := toFloat(operator.Value)
+		if !ok {
+			return false, fmt.Errorf("$size needs a number")
+		}
+		for _, value := range values {
+			if array, ok := value.(bson.A); ok && float64(len(array)) == size {
+				return true, nil
+			}
+		}
+		return false, nil
+	case "$all":
+		candidates, ok := operator.Value.(bson.A)
+		if !ok {
+			return false, fmt.Errorf("$all needs an array")
+		}
+		for _, candidate := range candidates {
+			if !matchEqual(values, candidate) {
+				return false, nil
+			}
+		}
+		return len(candidates) > 0, nil
+	case "$elemMatch":
+		condition, ok := operator.Value.(bson.D)
+		if !ok {
+			return false, fmt.Errorf("$elemMatch needs an Object")
+		}
+		for _, value := range values {
+			array, ok := value.(bson.A)
+			if !ok {
+				continue
+			}
+			for _, element := range array {
+				matched, err := matchElementCondition(element, condition)
+				if err != nil {
+					return false, err
+				}
+				if matched {
+					return true, nil
+				}
+			}
+		}
+		return false, nil
+	}
+
+	return false, fmt.Errorf("unknown operator: %s", operator.Key)
+}
+
+// matchElementCondition matches an array element against a condition of
+// $elemMatch or $pull, either a document filter or operators
+func matchElementCondition(element interface{}, condition interface{}) (bool, error) {
+	if document, ok := condition.(bson.D); ok && !isOperatorDocument(condition) {
+		elementDocument, ok := element.(bson.D)
+		if !ok {
+			return false, nil
+		}
+		return matches(elementDocument, document)
+	}
+
+	return matchCondition([]interface{}{element}, condition)
+}
+
+// matchEqual implements equality, a null condition matches missing fields and
+// a field holding an array matches when one of its elements is equal
+func matchEqual(values []interface{}, condition interface{}) bool {
+	if condition == nil {
+		if len(values) == 0 {
+			return true
+		}
+	}
+
+	for _, value := range expand(values) {
+		if equal(value, condition) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func matchIn(values []interface{}, candidates bson.A) (bool, error) {
+	for _, candidate := range candidates {
+		if regex, ok := candidate.(primitive.Regex); ok {
+			matched, err := matchRegex(values, regex.Pattern, regex.Options)
+			if err != nil || matched {
+				return matched, err
+			}
+			continue
+		}
+
+		if matchEqual(values, candidate) {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+func matchRegex(values []interface{}, pattern, options string) (bool, error) {
+	expression, err := compileRegex(pattern, options)
+	if err != nil {
+		return false, err
+	}
+
+	for _, value := range expand(values) {
+		switch value := value.(type) {
+		case string:
+			if expression.MatchString(value) {
+				return true, nil
+			}
+		case primitive.Regex:
+			if value.Pattern == pattern && value.Options == options {
+				return true, nil
+			}
+		}
+	}
+
+	return false, nil
+}
+
+// compileRegex translates the i, m and s options, Go regexps have no x flag
+func compileRegex(pattern, options string) (*regexp.Regexp, error) {
+	var flags string
+	for _, option := range options {
+		switch option {
+		case 'i', 'm', 's':
+			flags += string(option)
+		default:
+			return nil, fmt.Errorf("invalid regex option: %c", option)
+		}
+	}
+	if flags != "" {
+		pattern = "(?" + flags + ")" + pattern
+	}
+
+	return regexp.Compile(pattern)
+}
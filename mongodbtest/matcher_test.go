@@ -0,0 +1,142 @@
+package mongodbtest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type MatcherTestSuite struct {
+	suite.Suite
+	document bson.D
+}
+
+func TestMatcherTestSuite(t *testing.T) {
+	suite.Run(t, new(MatcherTestSuite))
+}
+
+func (s *MatcherTestSuite) SetupTest() {
+	document, err := toDocument(bson.M{
+		"name":       "Goravel",
+		"age":        18,
+		"score":      9.5,
+		"tags":       []string{"go", "mongodb"},
+		"address":    bson.D{{Key: "city", Value: "Shanghai"}, {Key: "zip", Value: "200000"}},
+		"posts":      []bson.M{{"title": "Hello", "views": 10}, {"title": "World", "views": 20}},
+		"deleted_at": nil,
+		"created_at": time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+	})
+	s.Require().NoError(err)
+	s.document = document
+}
+
+func (s *MatcherTestSuite) TestMatches() {
+	tests := []struct {
+		name     string
+		filter   interface{}
+		expected bool
+	}{
+		{name: "empty", filter: bson.M{}, expected: true},
+		{name: "equal", filter: bson.M{"name": "Goravel"}, expected: true},
+		{name: "not equal", filter: bson.M{"name": "Laravel"}, expected: false},
+		{name: "numbers of different types", filter: bson.M{"age": int64(18)}, expected: true},
+		{name: "float and int", filter: bson.M{"age": 18.0}, expected: true},
+		{name: "nested field", filter: bson.M{"address.city": "Shanghai"}, expected: true},
+		{name: "nested document", filter: bson.M{"address": bson.D{{Key: "city", Value: "Shanghai"}, {Key: "zip", Value: "200000"}}}, expected: true},
+		{name: "array element", filter: bson.M{"tags": "go"}, expected: true},
+		{name: "whole array", filter: bson.M{"tags": bson.A{"go", "mongodb"}}, expected: true},
+		{name: "field of array documents", filter: bson.M{"posts.title": "World"}, expected: true},
+		{name: "array index", filter: bson.M{"posts.1.title": "World"}, expected: true},
+		{name: "null matches null", filter: bson.M{"deleted_at": nil}, expected: true},
+		{name: "null matches missing", filter: bson.M{"missing": nil}, expected: true},
+		{name: "date", filter: bson.M{"created_at": time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)}, expected: true},
+		{name: "$eq", filter: bson.M{"name": bson.M{"$eq": "Goravel"}}, expected: true},
+		{name: "$ne", filter: bson.M{"name": bson.M{"$ne": "Goravel"}}, expected: false},
+		{name: "$ne null on null", filter: bson.M{"deleted_at": bson.M{"$ne": nil}}, expected: false},
+		{name: "$ne null on missing", filter: bson.M{"missing": bson.M{"$ne": nil}}, expected: false},
+		{name: "$gt", filter: bson.M{"age": bson.M{"$gt": 17}}, expected: true},
+		{name: "$gt equal", filter: bson.M{"age": bson.M{"$gt": 18}}, expected: false},
+		{name: "$gte", filter: bson.M{"age": bson.M{"$gte": 18}}, expected: true},
+		{name: "$lt", filter: bson.M{"score": bson.M{"$lt": 10}}, expected: true},
+		{name: "$lte", filter: bson.M{"score": bson.M{"$lte": 9}}, expected: false},
+		{name: "$gt and $lt", filter: bson.M{"age": bson.M{"$gt": 10, "$lt": 20}}, expected: true},
+		{name: "$gt does not compare types", filter: bson.M{"name": bson.M{"$gt": 1}}, expected: false},
+		{name: "$gt on array documents", filter: bson.M{"posts.views": bson.M{"$gt": 15}}, expected: true},
+		{name: "$gt date", filter: bson.M{"created_at": bson.M{"$gt": time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}}, expected: true},
+		{name: "$in", filter: bson.M{"name": bson.M{"$in": bson.A{"Laravel", "Goravel"}}}, expected: true},
+		{name: "$in array field", filter: bson.M{"tags": bson.M{"$in": bson.A{"rust", "go"}}}, expected: true},
+		{name: "$in regex", filter: bson.M{"name": bson.M{"$in": bson.A{primitive.Regex{Pattern: "^Go"}}}}, expected: true},
+		{name: "$in null matches missing", filter: bson.M{"missing": bson.M{"$in": bson.A{nil}}}, expected: true},
+		{name: "$nin", filter: bson.M{"name": bson.M{"$nin": bson.A{"Goravel"}}}, expected: false},
+		{name: "$exists", filter: bson.M{"address.zip": bson.M{"$exists": true}}, expected: true},
+		{name: "$exists null field", filter: bson.M{"deleted_at": bson.M{"$exists": true}}, expected: true},
+		{name: "$exists false", filter: bson.M{"missing": bson.M{"$exists": false}}, expected: true},
+		{name: "$regex", filter: bson.M{"name": bson.M{"$regex": "^gor", "$options": "i"}}, expected: true},
+		{name: "$regex case sensitive", filter: bson.M{"name": bson.M{"$regex": "^gor"}}, expected: false},
+		{name: "$regex primitive", filter: bson.M{"name": bson.M{"$regex": primitive.Regex{Pattern: "vel$"}}}, expected: true},
+		{name: "regex value", filter: bson.M{"tags": primitive.Regex{Pattern: "^mongo"}}, expected: true},
+		{name: "$not", filter: bson.M{"age": bson.M{"$not": bson.M{"$gt": 20}}}, expected: true},
+		{name: "$size", filter: bson.M{"tags": bson.M{"$size": 2}}, expected: true},
+		{name: "$all", filter: bson.M{"tags": bson.M{"$all": bson.A{"mongodb", "go"}}}, expected: true},
+		{name: "$all missing", filter: bson.M{"tags": bson.M{"$all": bson.A{"go", "rust"}}}, expected: false},
+		{name: "$elemMatch", filter: bson.M{"posts": bson.M{"$elemMatch": bson.M{"title": "Hello", "views": bson.M{"$gte": 10}}}}, expected: true},
+		{name: "$elemMatch no element", filter: bson.M{"posts": bson.M{"$elemMatch": bson.M{"title": "Hello", "views": 20}}}, expected: false},
+		{name: "$or", filter: bson.M{"$or": bson.A{bson.M{"name": "Laravel"}, bson.M{"age": 18}}}, expected: true},
+		{name: "$or none", filter: bson.M{"$or": bson.A{bson.M{"name": "Laravel"}, bson.M{"age": 20}}}, expected: false},
+		{name: "$and", filter: bson.M{"$and": bson.A{bson.M{"name": "Goravel"}, bson.M{"age": 20}}}, expected: false},
+		{name: "$nor", filter: bson.M{"$nor": bson.A{bson.M{"name": "Laravel"}}}, expected: true},
+	}
+
+	for _, test := range tests {
+		s.Run(test.name, func() {
+			filter, err := toDocument(test.filter)
+			s.Require().NoError(err)
+
+			matched, err := matches(s.document, filter)
+			s.NoError(err)
+			s.Equal(test.expected, matched)
+		})
+	}
+}
+
+func (s *MatcherTestSuite) TestMatchesError() {
+	tests := []struct {
+		name   string
+		filter interface{}
+		err    string
+	}{
+		{name: "unknown operator", filter: bson.M{"age": bson.M{"$mod": bson.A{2, 0}}}, err: "unknown operator: $mod"},
+		{name: "unknown top level operator", filter: bson.M{"$where": "true"}, err: "unknown top level operator: $where"},
+		{name: "$in without array", filter: bson.M{"age": bson.M{"$in": 18}}, err: "$in needs an array"},
+		{name: "$or without array", filter: bson.M{"$or": bson.M{"age": 18}}, err: "$or must be a nonempty array"},
+		{name: "invalid regex option", filter: bson.M{"name": bson.M{"$regex": "go", "$options": "u"}}, err: "invalid regex option: u"},
+	}
+
+	for _, test := range tests {
+		s.Run(test.name, func() {
+			filter, err := toDocument(test.filter)
+			s.Require().NoError(err)
+
+			_, err = matches(s.document, filter)
+			s.EqualError(err, test.err)
+		})
+	}
+}
+
+func (s *MatcherTestSuite) TestCompare() {
+	id := primitive.NewObjectID()
+
+	s.Equal(0, compare(int32(1), 1.0))
+	s.Equal(-1, compare(nil, int32(1)))
+	s.Equal(-1, compare(int64(1), "1"))
+	s.Equal(1, compare("b", "a"))
+	s.Equal(-1, compare(false, true))
+	s.Equal(0, compare(id, id))
+	s.Equal(-1, compare(bson.A{int32(1)}, bson.A{int32(1), int32(2)}))
+	s.Equal(1, compare(primitive.DateTime(2), primitive.DateTime(1)))
+	s.True(equal(bson.D{{Key: "a", Value: int32(1)}}, bson.D{{Key: "a", Value: int64(1)}}))
+	s.False(equal(bson.D{{Key: "a", Value: int32(1)}}, bson.D{{Key: "b", Value: int32(1)}}))
+}
@@ -0,0 +1,368 @@
+package mongodbtest
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	mongodb "github.com/portofolio-mager/goravel-mongodb"
+	"github.com/portofolio-mager/goravel-mongodb/contracts"
+)
+
+var _ contracts.QueryBuilder = &QueryBuilder{}
+
+type trashedScope int
+
+const (
+	withoutTrashed trashedScope = iota
+	withTrashed
+	onlyTrashed
+)
+
+type QueryBuilder struct {
+	collection *Collection
+	filter     bson.M
+	sort       bson.D
+	skip       int64
+	limit      int64
+	projection bson.D
+	trashed    trashedScope
+	// withoutScopes holds the disabled global scopes, withoutAll disables every one
+	withoutScopes map[string]bool
+	withoutAll    bool
+	with          []string
+}
+
+func NewQueryBuilder(collection *Collection) *QueryBuilder {
+	return &QueryBuilder{
+		collection: collection,
+		filter:     bson.M{},
+	}
+}
+
+// Where conditions
+func (q *QueryBuilder) Where(field string, value interface{}) contracts.QueryBuilder {
+	q.filter[field] = value
+	return q
+}
+
+func (q *QueryBuilder) WhereIn(field string, values []interface{}) contracts.QueryBuilder {
+	q.filter[field] = bson.M{"$in": values}
+	return q
+}
+
+func (q *QueryBuilder) WhereNotIn(field string, values []interface{}) contracts.QueryBuilder {
+	q.filter[field] = bson.M{"$nin": values}
+	return q
+}
+
+func (q *QueryBuilder) WhereExists(field string) contracts.QueryBuilder {
+	q.filter[field] = bson.M{"$exists": true}
+	return q
+}
+
+func (q *QueryBuilder) WhereNotExists(field string) contracts.QueryBuilder {
+	q.filter[field] = bson.M{"$exists": false}
+	return q
+}
+
+func (q *QueryBuilder) WhereGt(field string, value interface{}) contracts.QueryBuilder {
+	q.filter[field] = bson.M{"$gt": value}
+	return q
+}
+
+func (q *QueryBuilder) WhereGte(field string, value interface{}) contracts.QueryBuilder {
+	q.filter[field] = bson.M{"$gte": value}
+	return q
+}
+
+func (q *QueryBuilder) WhereLt(field string, value interface{}) contracts.QueryBuilder {
+	q.filter[field] = bson.M{"$lt": value}
+	return q
+}
+
+func (q *QueryBuilder) WhereLte(field string, value interface{}) contracts.QueryBuilder {
+	q.filter[field] = bson.M{"$lte": value}
+	return q
+}
+
+func (q *QueryBuilder) WhereNe(field string, value interface{}) contracts.QueryBuilder {
+	q.filter[field] = bson.M{"$ne": value}
+	return q
+}
+
+func (q *QueryBuilder) WhereRegex(field string, pattern string, options ...string) contracts.QueryBuilder {
+	regexFilter := bson.M{"$regex": primitive.Regex{Pattern: pattern}}
+	if len(options) > 0 {
+		regexFilter["$options"] = options[0]
+	}
+	q.filter[field] = regexFilter
+	return q
+}
+
+// Query modifiers
+func (q *QueryBuilder) Limit(limit int64) contracts.QueryBuilder {
+	q.limit = limit
+	return q
+}
+
+func (q *QueryBuilder) Skip(skip int64) contracts.QueryBuilder {
+	q.skip = skip
+	return q
+}
+
+func (q *QueryBuilder) Sort(field string, order int) contracts.QueryBuilder {
+	for i := range q.sort {
+		if q.sort[i].Key == field {
+			q.sort[i].Value = order
+			return q
+		}
+	}
+
+	q.sort = append(q.sort, bson.E{Key: field, Value: order})
+	return q
+}
+
+func (q *QueryBuilder) Select(fields ...string) contracts.QueryBuilder {
+	for _, field := range fields {
+		q.projection = append(q.projection, bson.E{Key: field, Value: 1})
+	}
+	return q
+}
+
+// With is recorded but relations cannot be loaded, Find and First fail when
+// it is used
+func (q *QueryBuilder) With(relations ...string) contracts.QueryBuilder {
+	q.with = append(q.with, relations...)
+	return q
+}
+
+func (q *QueryBuilder) Scopes(scopes ...func(contracts.QueryBuilder)) contracts.QueryBuilder {
+	for _, scope := range scopes {
+		scope(q)
+	}
+	return q
+}
+
+func (q *QueryBuilder) WithoutGlobalScope(names ...string) contracts.QueryBuilder {
+	if len(names) == 0 {
+		q.withoutAll = true
+		return q
+	}
+
+	if q.withoutScopes == nil {
+		q.withoutScopes = make(map[string]bool)
+	}
+	for _, name := range names {
+		q.withoutScopes[name] = true
+	}
+	return q
+}
+
+// Soft delete modifiers
+func (q *QueryBuilder) WithTrashed() contracts.QueryBuilder {
+	q.trashed = withTrashed
+	return q
+}
+
+func (q *QueryBuilder) OnlyTrashed() contracts.QueryBuilder {
+	q.trashed = onlyTrashed
+	return q
+}
+
+// WithContext returns the query as is, the in-memory operations take no context
+func (q *QueryBuilder) WithContext(ctx context.Context) contracts.QueryBuilder {
+	return q
+}
+
+// Result methods
+func (q *QueryBuilder) Find(results interface{}) error {
+	documents, err := q.find(q.limit)
+	if err != nil {
+		return err
+	}
+
+	return decodeAll(documents, results)
+}
+
+func (q *QueryBuilder) First(result interface{}) error {
+	documents, err := q.find(1)
+	if err != nil {
+		return err
+	}
+	if len(documents) == 0 {
+		return mongo.ErrNoDocuments
+	}
+
+	return decode(documents[0], result)
+}
+
+func (q *QueryBuilder) Count() (int64, error) {
+	filter, err := toDocument(q.filterFor(q.trashed))
+	if err != nil {
+		return 0, err
+	}
+
+	documents, err := q.collection.find(filter, findOptions{skip: q.skip, limit: q.limit})
+	if err != nil {
+		return 0, fmt.Errorf("failed to count documents: %w", err)
+	}
+
+	return int64(len(documents)), nil
+}
+
+// Write methods
+func (q *QueryBuilder) Update(update interface{}) (int64, error) {
+	result, err := q.collection.UpdateMany(q.filterFor(q.trashed), update)
+	if err != nil {
+		return 0, fmt.Errorf("failed to update documents: %w", err)
+	}
+
+	return result.ModifiedCount, nil
+}
+
+func (q *QueryBuilder) Delete() (int64, error) {
+	if !q.collection.softDeletes {
+		return q.ForceDelete()
+	}
+
+	filter, err := q.limited(q.filterFor(withoutTrashed))
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete documents: %w", err)
+	}
+
+	update := bson.M{"$set": bson.M{mongodb.DeletedAtField: time.Now()}}
+	result, err := q.collection.UpdateMany(filter, update)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete documents: %w", err)
+	}
+
+	return result.ModifiedCount, nil
+}
+
+func (q *QueryBuilder) ForceDelete() (int64, error) {
+	trashed := q.trashed
+	if trashed == withoutTrashed {
+		trashed = withTrashed
+	}
+
+	filter, err := q.limited(q.filterFor(trashed))
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete documents: %w", err)
+	}
+
+	result, err := q.collection.DeleteMany(filter)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete documents: %w", err)
+	}
+
+	return result.DeletedCount, nil
+}
+
+func (q *QueryBuilder) Restore() (int64, error) {
+	filter, err := q.limited(q.filterFor(onlyTrashed))
+	if err != nil {
+		return 0, fmt.Errorf("failed to restore documents: %w", err)
+	}
+
+	update := bson.M{"$unset": bson.M{mongodb.DeletedAtField: ""}}
+	result, err := q.collection.UpdateMany(filter, update)
+	if err != nil {
+		return 0, fmt.Errorf("failed to restore documents: %w", err)
+	}
+
+	return result.ModifiedCount, nil
+}
+
+func (q *QueryBuilder) find(limit int64) ([]bson.D, error) {
+	if len(q.with) > 0 {
+		return nil, fmt.Errorf("relations are %w", ErrNotSupported)
+	}
+
+	filter, err := toDocument(q.filterFor(q.trashed))
+	if err != nil {
+		return nil, err
+	}
+
+	opts, err := toFindOptions(q.sort, &q.skip, &limit, q.projection)
+	if err != nil {
+		return nil, err
+	}
+
+	return q.collection.find(filter, opts)
+}
+
+// limited restricts the filter of a write to the documents selected by the
+// sort, skip and limit of the query, like the real query builder
+func (q *QueryBuilder) limited(filter bson.M) (bson.M, error) {
+	if q.skip == 0 && q.limit == 0 {
+		return filter, nil
+	}
+
+	document, err := toDocument(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	opts, err := toFindOptions(q.sort, &q.skip, &q.limit, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	documents, err := q.collection.find(document, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make(bson.A, 0, len(documents))
+	for _, document := range documents {
+		if id, ok := lookupKey(document, "_id"); ok {
+			ids = append(ids, id)
+		}
+	}
+
+	return bson.M{"$and": bson.A{filter, bson.M{"_id": bson.M{"$in": ids}}}}, nil
+}
+
+// filterFor combines the conditions, the enabled global scopes and the soft
+// delete condition with $and
+func (q *QueryBuilder) filterFor(trashed trashedScope) bson.M {
+	var conditions bson.A
+	if len(q.filter) > 0 {
+		conditions = append(conditions, q.filter)
+	}
+
+	if !q.withoutAll {
+		for _, scope := range q.collection.scopes {
+			if q.withoutScopes[scope.name] {
+				continue
+			}
+
+			scoped := NewQueryBuilder(q.collection)
+			scope.scope(scoped)
+			if len(scoped.filter) > 0 {
+				conditions = append(conditions, scoped.filter)
+			}
+		}
+	}
+
+	switch {
+	case trashed == onlyTrashed:
+		conditions = append(conditions, bson.M{mongodb.DeletedAtField: bson.M{"$ne": nil}})
+	case trashed == withoutTrashed && q.collection.softDeletes:
+		conditions = append(conditions, bson.M{mongodb.DeletedAtField: nil})
+	}
+
+	switch len(conditions) {
+	case 0:
+		return bson.M{}
+	case 1:
+		return conditions[0].(bson.M)
+	default:
+		return bson.M{"$and": conditions}
+	}
+}
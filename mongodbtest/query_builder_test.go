@@ -0,0 +1,164 @@
+package mongodbtest
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	mongodb "github.com/portofolio-mager/goravel-mongodb"
+	"github.com/portofolio-mager/goravel-mongodb/contracts"
+)
+
+type queryUser struct {
+	ID                  int    `bson:"_id"`
+	Name                string `bson:"name"`
+	Age                 int    `bson:"age"`
+	Active              bool   `bson:"active"`
+	mongodb.SoftDeletes `bson:",inline"`
+}
+
+type QueryBuilderTestSuite struct {
+	suite.Suite
+	collection contracts.Collection
+}
+
+func TestQueryBuilderTestSuite(t *testing.T) {
+	suite.Run(t, new(QueryBuilderTestSuite))
+}
+
+func (s *QueryBuilderTestSuite) SetupTest() {
+	s.collection = NewClient("goravel").Collection("users")
+	_, err := s.collection.InsertMany([]interface{}{
+		queryUser{ID: 1, Name: "Goravel", Age: 18, Active: true},
+		queryUser{ID: 2, Name: "Laravel", Age: 30, Active: true},
+		queryUser{ID: 3, Name: "Rails", Age: 25},
+		queryUser{ID: 4, Name: "Django", Age: 25, Active: true},
+	})
+	s.Require().NoError(err)
+}
+
+func (s *QueryBuilderTestSuite) TestFind() {
+	var users []queryUser
+	s.NoError(s.collection.Where("active", true).WhereGte("age", 20).Sort("age", -1).Find(&users))
+	s.Equal([]int{2, 4}, ids(users))
+
+	users = nil
+	s.NoError(s.collection.Query().WhereIn("name", []interface{}{"Goravel", "Rails", "Django"}).Sort("age", 1).Sort("name", 1).Skip(1).Limit(2).Find(&users))
+	s.Equal([]int{4, 3}, ids(users))
+
+	users = nil
+	s.NoError(s.collection.Query().WhereRegex("name", "^.a", "i").WhereNotIn("_id", []interface{}{3}).Select("name").Find(&users))
+	s.Equal([]queryUser{{ID: 2, Name: "Laravel"}}, users)
+
+	var user queryUser
+	s.NoError(s.collection.Query().WhereLt("age", 25).First(&user))
+	s.Equal(1, user.ID)
+	s.ErrorIs(s.collection.Where("name", "Symfony").First(&user), mongo.ErrNoDocuments)
+
+	count, err := s.collection.Query().WhereNe("age", 25).Count()
+	s.NoError(err)
+	s.Equal(int64(2), count)
+
+	count, err = s.collection.Query().WhereExists("deleted_at").Count()
+	s.NoError(err)
+	s.Equal(int64(0), count)
+
+	s.ErrorIs(s.collection.Query().With("posts").Find(&users), ErrNotSupported)
+}
+
+func (s *QueryBuilderTestSuite) TestUpdate() {
+	updated, err := s.collection.Where("age", 25).Update(bson.M{"$set": bson.M{"active": false}})
+	s.NoError(err)
+	s.Equal(int64(1), updated)
+
+	count, err := s.collection.Where("active", false).Count()
+	s.NoError(err)
+	s.Equal(int64(2), count)
+}
+
+func (s *QueryBuilderTestSuite) TestScopes() {
+	active := func(query contracts.QueryBuilder) {
+		query.Where("active", true)
+	}
+	s.collection.WithScope("active", active)
+
+	var users []queryUser
+	s.NoError(s.collection.Query().WhereGt("age", 20).Sort("_id", 1).Find(&users))
+	s.Equal([]int{2, 4}, ids(users))
+
+	users = nil
+	s.NoError(s.collection.Query().WithoutGlobalScope("active").WhereGt("age", 20).Sort("_id", 1).Find(&users))
+	s.Equal([]int{2, 3, 4}, ids(users))
+
+	users = nil
+	s.NoError(s.collection.Query().WithoutGlobalScope().Scopes(func(query contracts.QueryBuilder) {
+		query.WhereLt("age", 20)
+	}).Find(&users))
+	s.Equal([]int{1}, ids(users))
+}
+
+func (s *QueryBuilderTestSuite) TestSoftDeletes() {
+	s.collection.WithSoftDeletes()
+
+	deleted, err := s.collection.Where("age", 25).Delete()
+	s.NoError(err)
+	s.Equal(int64(2), deleted)
+
+	count, err := s.collection.Query().Count()
+	s.NoError(err)
+	s.Equal(int64(2), count)
+
+	var users []queryUser
+	s.NoError(s.collection.Query().OnlyTrashed().Sort("_id", 1).Find(&users))
+	s.Equal([]int{3, 4}, ids(users))
+	s.True(users[0].Trashed())
+
+	count, err = s.collection.Query().WithTrashed().Count()
+	s.NoError(err)
+	s.Equal(int64(4), count)
+
+	restored, err := s.collection.Where("_id", 3).Restore()
+	s.NoError(err)
+	s.Equal(int64(1), restored)
+
+	forceDeleted, err := s.collection.Query().ForceDelete()
+	s.NoError(err)
+	s.Equal(int64(4), forceDeleted)
+}
+
+func (s *QueryBuilderTestSuite) TestSoftDeletesWithLimit() {
+	s.collection.WithSoftDeletes()
+
+	// The writes are limited to the documents the query selects
+	deleted, err := s.collection.Query().Sort("age", -1).Sort("_id", 1).Limit(2).Delete()
+	s.NoError(err)
+	s.Equal(int64(2), deleted)
+
+	var users []queryUser
+	s.NoError(s.collection.Query().OnlyTrashed().Sort("_id", 1).Find(&users))
+	s.Equal([]int{2, 3}, ids(users))
+
+	restored, err := s.collection.Query().Sort("_id", 1).Skip(1).Restore()
+	s.NoError(err)
+	s.Equal(int64(1), restored)
+
+	forceDeleted, err := s.collection.Query().WithTrashed().Sort("_id", 1).Skip(1).Limit(1).ForceDelete()
+	s.NoError(err)
+	s.Equal(int64(1), forceDeleted)
+
+	users = nil
+	s.NoError(s.collection.Query().WithTrashed().Sort("_id", 1).Find(&users))
+	s.Equal([]int{1, 3, 4}, ids(users))
+	s.False(users[1].Trashed())
+}
+
+func ids(users []queryUser) []int {
+	var ids []int
+	for _, user := range users {
+		ids = append(ids, user.ID)
+	}
+
+	return ids
+}
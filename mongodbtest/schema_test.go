@@ -0,0 +1,144 @@
+package mongodbtest
+
+import (
+	"testing"
+
+	"github.com/goravel/framework/contracts/database/driver"
+	"github.com/goravel/framework/database/schema"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+
+	mongodb "github.com/portofolio-mager/goravel-mongodb"
+)
+
+type SchemaTestSuite struct {
+	suite.Suite
+	server  *Server
+	db      *gorm.DB
+	grammar *mongodb.Grammar
+}
+
+func TestSchemaTestSuite(t *testing.T) {
+	suite.Run(t, new(SchemaTestSuite))
+}
+
+func (s *SchemaTestSuite) SetupTest() {
+	server, err := NewServer()
+	s.Require().NoError(err)
+	s.server = server
+
+	db, err := gorm.Open(mongodb.Open(server.URI()+"/goravel"), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	s.Require().NoError(err)
+	s.db = db
+	s.grammar = mongodb.NewGrammar(nil)
+}
+
+func (s *SchemaTestSuite) TearDownTest() {
+	sqlDB, err := s.db.DB()
+	s.NoError(err)
+	s.NoError(sqlDB.Close())
+	s.NoError(s.server.Close())
+}
+
+// build runs the statements of the blueprint like Goravel's schema facade
+func (s *SchemaTestSuite) build(table string, callback func(blueprint *schema.Blueprint)) {
+	blueprint := schema.NewBlueprint(nil, "", table)
+	callback(blueprint)
+
+	statements, err := blueprint.ToSql(s.grammar)
+	s.Require().NoError(err)
+	for _, statement := range statements {
+		s.Require().NoError(s.db.Exec(statement).Error)
+	}
+}
+
+func (s *SchemaTestSuite) tables() []string {
+	var tables []driver.Table
+	s.Require().NoError(s.db.Raw(s.grammar.CompileTables("")).Scan(&tables).Error)
+
+	var names []string
+	for _, table := range tables {
+		names = append(names, table.Name)
+	}
+
+	return names
+}
+
+func (s *SchemaTestSuite) indexes(table string) []driver.Index {
+	var indexes []driver.DBIndex
+	s.Require().NoError(s.db.Raw(s.grammar.CompileIndexes("", table)).Scan(&indexes).Error)
+
+	return mongodb.NewProcessor().ProcessIndexes(indexes)
+}
+
+func (s *SchemaTestSuite) TestCreateAndDrop() {
+	s.build("users", func(blueprint *schema.Blueprint) {
+		blueprint.Create()
+		blueprint.ID()
+		blueprint.String("name")
+		blueprint.String("email")
+		blueprint.Unique("email")
+		blueprint.Index("name")
+	})
+	s.Equal([]string{"users"}, s.tables())
+
+	s.Equal([]driver.Index{
+		{Columns: []string{"_id"}, Name: "_id_", Type: "btree", Primary: true, Unique: true},
+		{Columns: []string{"email"}, Name: "users_email_unique", Type: "btree", Unique: true},
+		{Columns: []string{"name"}, Name: "users_name_index", Type: "btree"},
+	}, s.indexes("users"))
+
+	s.build("users", func(blueprint *schema.Blueprint) {
+		blueprint.DropIndex("name")
+	})
+	s.Len(s.indexes("users"), 2)
+
+	s.build("users", func(blueprint *schema.Blueprint) {
+		blueprint.Drop()
+	})
+	s.Empty(s.tables())
+	s.Empty(s.indexes("users"))
+
+	// Dropping a missing collection succeeds
+	s.build("users", func(blueprint *schema.Blueprint) {
+		blueprint.DropIfExists()
+	})
+}
+
+func (s *SchemaTestSuite) TestIndexCreatesCollection() {
+	s.build("posts", func(blueprint *schema.Blueprint) {
+		blueprint.FullText("title")
+	})
+
+	s.Equal([]string{"posts"}, s.tables())
+	s.Len(s.indexes("posts"), 2)
+}
+
+func (s *SchemaTestSuite) TestOtherCommandsNeverRun() {
+	s.build("users", func(blueprint *schema.Blueprint) {
+		blueprint.Create()
+	})
+
+	// Only the commands of the Grammar run, the others are ignored like SQL
+	s.NoError(s.db.Exec(`{"dropDatabase": 1}`).Error)
+	s.NoError(s.db.Exec(`{"renameCollection": "goravel.users", "to": "goravel.accounts"}`).Error)
+	s.Equal([]string{"users"}, s.tables())
+}
+
+func (s *SchemaTestSuite) TestStrict() {
+	db, err := gorm.Open(&mongodb.Dialector{DSN: s.server.URI() + "/goravel", Strict: true}, &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	s.Require().NoError(err)
+	sqlDB, err := s.db.DB()
+	s.Require().NoError(err)
+	s.NoError(sqlDB.Close())
+	s.db = db
+
+	// The commands of the Grammar still run, the others fail like SQL
+	s.build("users", func(blueprint *schema.Blueprint) {
+		blueprint.Create()
+	})
+	s.ErrorIs(s.db.Exec(`{"dropDatabase": 1}`).Error, mongodb.UnsupportedStatement)
+	s.ErrorIs(s.db.Exec("DROP DATABASE goravel").Error, mongodb.UnsupportedStatement)
+	s.Equal([]string{"users"}, s.tables())
+}
@@ -0,0 +1,158 @@
+package mongodbtest
+
+import (
+	"bufio"
+	"fmt"
+	"net"
+	"sync"
+	"sync/atomic"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// Server is a MongoDB stand-in speaking enough of the wire protocol for the
+// official driver, so code creating a real mongo.Client can be tested end to
+// end without a MongoDB binary. The documents are kept in memory like the
+// Client of this package, with the same query and update support.
+//
+//	server, err := mongodbtest.NewServer()
+//	defer server.Close()
+//
+//	// database.connections.mongodb.uri
+//	uri := server.URI()
+//
+// Indexes are recorded for listIndexes but neither used nor enforced.
+// Authentication, TLS, sessions with transactions and most aggregation stages
+// are not available.
+type Server struct {
+	listener     net.Listener
+	store        *store
+	mu           sync.Mutex
+	modifyMu     sync.Mutex
+	connections  map[net.Conn]struct{}
+	cursors      map[int64]*serverCursor
+	indexes      map[string][]bson.D
+	closed       bool
+	wg           sync.WaitGroup
+	requestID    atomic.Int32
+	connectionID atomic.Int32
+	cursorID     atomic.Int64
+}
+
+// serverCursor holds the documents a getMore still has to return
+type serverCursor struct {
+	namespace string
+	documents []bson.D
+}
+
+// NewServer starts a server listening on a random local port
+func NewServer() (*Server, error) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, fmt.Errorf("failed to start MongoDB server: %w", err)
+	}
+
+	server := &Server{
+		listener:    listener,
+		store:       newStore(),
+		connections: make(map[net.Conn]struct{}),
+		cursors:     make(map[int64]*serverCursor),
+		indexes:     make(map[string][]bson.D),
+	}
+
+	server.wg.Add(1)
+	go server.serve()
+
+	return server, nil
+}
+
+// Addr returns the host:port the server listens on
+func (s *Server) Addr() string {
+	return s.listener.Addr().String()
+}
+
+// URI returns the connection string of the server
+func (s *Server) URI() string {
+	return "mongodb://" + s.Addr()
+}
+
+// Client returns an in-memory client sharing the documents of the server, to
+// arrange and inspect the data without going through the driver
+func (s *Server) Client(database string) *Client {
+	return &Client{
+		store:    s.store,
+		database: database,
+	}
+}
+
+// Close stops listening and closes the open connections
+func (s *Server) Close() error {
+	s.mu.Lock()
+	if s.closed {
+		s.mu.Unlock()
+		return nil
+	}
+	s.closed = true
+	err := s.listener.Close()
+	for conn := range s.connections {
+		_ = conn.Close()
+	}
+	s.mu.Unlock()
+
+	s.wg.Wait()
+
+	return err
+}
+
+func (s *Server) serve() {
+	defer s.wg.Done()
+
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+
+		s.mu.Lock()
+		if s.closed {
+			s.mu.Unlock()
+			_ = conn.Close()
+			return
+		}
+		s.connections[conn] = struct{}{}
+		s.wg.Add(1)
+		s.mu.Unlock()
+
+		go s.handle(conn)
+	}
+}
+
+// handle answers the requests of a connection until it is closed or sends a
+// malformed message
+func (s *Server) handle(conn net.Conn) {
+	defer func() {
+		s.mu.Lock()
+		delete(s.connections, conn)
+		s.mu.Unlock()
+		_ = conn.Close()
+		s.wg.Done()
+	}()
+
+	connectionID := s.connectionID.Add(1)
+	reader := bufio.NewReader(conn)
+	for {
+		request, err := readRequest(reader)
+		if err != nil {
+			return
+		}
+		request.connectionID = connectionID
+
+		reply := s.run(request)
+		if request.moreToCome {
+			continue
+		}
+		if err := writeReply(conn, s.requestID.Add(1), request, reply); err != nil {
+			return
+		}
+	}
+}
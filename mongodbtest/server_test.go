@@ -0,0 +1,858 @@
+package mongodbtest
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	mocksconfig "github.com/goravel/framework/mocks/config"
+	mockslog "github.com/goravel/framework/mocks/log"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	mongodb "github.com/portofolio-mager/goravel-mongodb"
+	"github.com/portofolio-mager/goravel-mongodb/contracts"
+	mockscontracts "github.com/portofolio-mager/goravel-mongodb/mocks"
+)
+
+type relationTag struct {
+	ID   int    `bson:"_id"`
+	Name string `bson:"name"`
+}
+
+type relationPost struct {
+	ID     int           `bson:"_id"`
+	UserID int           `bson:"user_id"`
+	Title  string        `bson:"title"`
+	Author *relationUser `bson:"-"`
+}
+
+func (r *relationPost) Relations() map[string]contracts.Relation {
+	return map[string]contracts.Relation{
+		"author": mongodb.BelongsTo("users", "user_id"),
+	}
+}
+
+type relationUser struct {
+	ID      int            `bson:"_id"`
+	Name    string         `bson:"name"`
+	TagIDs  []int          `bson:"tag_ids"`
+	Posts   []relationPost `bson:"-"`
+	Profile *relationPost  `bson:"-"`
+	Tags    []relationTag  `bson:"-"`
+}
+
+func (r *relationUser) Relations() map[string]contracts.Relation {
+	return map[string]contracts.Relation{
+		"posts":   mongodb.HasMany("posts", "user_id"),
+		"profile": mongodb.HasOne("profiles", "user_id"),
+		"tags":    mongodb.ManyToMany("tags", "tag_ids"),
+	}
+}
+
+type ServerTestSuite struct {
+	suite.Suite
+	server *Server
+	client *mongo.Client
+}
+
+func TestServerTestSuite(t *testing.T) {
+	suite.Run(t, new(ServerTestSuite))
+}
+
+func (s *ServerTestSuite) SetupTest() {
+	server, err := NewServer()
+	s.Require().NoError(err)
+	s.server = server
+
+	client, err := mongo.Connect(context.Background(), options.Client().ApplyURI(server.URI()).SetServerSelectionTimeout(5*time.Second))
+	s.Require().NoError(err)
+	s.client = client
+}
+
+func (s *ServerTestSuite) TearDownTest() {
+	s.NoError(s.client.Disconnect(context.Background()))
+	s.NoError(s.server.Close())
+	s.NoError(s.server.Close())
+}
+
+// mockConfig configures the mongodb connection on the server, without metrics
+func (s *ServerTestSuite) mockConfig() *mocksconfig.Config {
+	mockConfig := mocksconfig.NewConfig(s.T())
+	mockConfig.EXPECT().Get("database.connections.mongodb.write").Return([]contracts.Config{
+		{URI: s.server.URI(), Database: "goravel"},
+	}).Maybe()
+	mockConfig.EXPECT().Get("database.connections.mongodb.metrics").Return(nil).Maybe()
+	mockConfig.EXPECT().GetBool("database.connections.mongodb.tracing", false).Return(false).Maybe()
+
+	return mockConfig
+}
+
+func (s *ServerTestSuite) TestPing() {
+	s.NoError(s.client.Ping(context.Background(), nil))
+
+	var buildInfo bson.M
+	s.NoError(s.client.Database("admin").RunCommand(context.Background(), bson.D{{Key: "buildInfo", Value: 1}}).Decode(&buildInfo))
+	s.Equal("6.0.0", buildInfo["version"])
+
+	err := s.client.Database("admin").RunCommand(context.Background(), bson.D{{Key: "serverStatus", Value: 1}}).Err()
+	var commandError mongo.CommandError
+	s.ErrorAs(err, &commandError)
+	s.Equal(int32(59), commandError.Code)
+	s.Equal("no such command: 'serverStatus'", commandError.Message)
+}
+
+func (s *ServerTestSuite) TestCRUD() {
+	ctx := context.Background()
+	users := s.client.Database("goravel").Collection("users")
+
+	result, err := users.InsertMany(ctx, []interface{}{
+		bson.M{"_id": 1, "name": "Goravel", "age": 18},
+		bson.M{"_id": 2, "name": "Laravel", "age": 30},
+		bson.M{"_id": 3, "name": "Symfony", "age": 25},
+	})
+	s.NoError(err)
+	s.Len(result.InsertedIDs, 3)
+
+	_, err = users.InsertOne(ctx, bson.M{"_id": 1, "name": "Duplicate"})
+	s.True(mongo.IsDuplicateKeyError(err))
+
+	var user bson.M
+	s.NoError(users.FindOne(ctx, bson.M{"name": "Laravel"}).Decode(&user))
+	s.Equal(int32(30), user["age"])
+	s.ErrorIs(users.FindOne(ctx, bson.M{"name": "Rails"}).Err(), mongo.ErrNoDocuments)
+
+	cursor, err := users.Find(ctx, bson.M{"age": bson.M{"$gte": 20}}, options.Find().SetSort(bson.D{{Key: "age", Value: -1}}).SetProjection(bson.M{"name": 1, "_id": 0}))
+	s.NoError(err)
+	var names []bson.M
+	s.NoError(cursor.All(ctx, &names))
+	s.Equal([]bson.M{{"name": "Laravel"}, {"name": "Symfony"}}, names)
+
+	count, err := users.CountDocuments(ctx, bson.M{"age": bson.M{"$lt": 30}})
+	s.NoError(err)
+	s.Equal(int64(2), count)
+
+	count, err = users.CountDocuments(ctx, bson.M{"name": "Rails"})
+	s.NoError(err)
+	s.Equal(int64(0), count)
+
+	count, err = users.EstimatedDocumentCount(ctx)
+	s.NoError(err)
+	s.Equal(int64(3), count)
+
+	updated, err := users.UpdateMany(ctx, bson.M{"age": bson.M{"$lt": 30}}, bson.M{"$inc": bson.M{"age": 1}})
+	s.NoError(err)
+	s.Equal(int64(2), updated.MatchedCount)
+	s.Equal(int64(2), updated.ModifiedCount)
+
+	updated, err = users.UpdateOne(ctx, bson.M{"name": "Rails"}, bson.M{"$set": bson.M{"age": 20}}, options.Update().SetUpsert(true))
+	s.NoError(err)
+	s.Equal(int64(1), updated.UpsertedCount)
+	s.NotNil(updated.UpsertedID)
+
+	_, err = users.UpdateOne(ctx, bson.M{"_id": 1}, bson.M{"$unknown": bson.M{"age": 1}})
+	var writeException mongo.WriteException
+	s.ErrorAs(err, &writeException)
+
+	deleted, err := users.DeleteMany(ctx, bson.M{"age": bson.M{"$gte": 25}})
+	s.NoError(err)
+	s.Equal(int64(2), deleted.DeletedCount)
+
+	// The documents are shared with the in-memory client
+	var rails bson.M
+	s.NoError(s.server.Client("goravel").Collection("users").FindOne(bson.M{"name": "Rails"}, &rails))
+	s.Equal(int32(20), rails["age"])
+}
+
+func (s *ServerTestSuite) TestGetMore() {
+	ctx := context.Background()
+	posts := s.client.Database("goravel").Collection("posts")
+
+	documents := make([]interface{}, 250)
+	for i := range documents {
+		documents[i] = bson.M{"_id": i}
+	}
+	_, err := posts.InsertMany(ctx, documents)
+	s.NoError(err)
+
+	cursor, err := posts.Find(ctx, bson.M{}, options.Find().SetSort(bson.M{"_id": 1}).SetBatchSize(40))
+	s.NoError(err)
+	var results []bson.M
+	s.NoError(cursor.All(ctx, &results))
+	s.Len(results, 250)
+	s.Equal(int32(249), results[249]["_id"])
+
+	// A closed cursor is killed on the server
+	cursor, err = posts.Find(ctx, bson.M{}, options.Find().SetBatchSize(10))
+	s.NoError(err)
+	s.True(cursor.Next(ctx))
+	s.NoError(cursor.Close(ctx))
+	s.server.mu.Lock()
+	s.Empty(s.server.cursors)
+	s.server.mu.Unlock()
+}
+
+func (s *ServerTestSuite) TestCollections() {
+	ctx := context.Background()
+	database := s.client.Database("goravel")
+
+	s.NoError(database.CreateCollection(ctx, "users"))
+	var commandError mongo.CommandError
+	s.ErrorAs(database.CreateCollection(ctx, "users"), &commandError)
+	s.Equal("NamespaceExists", commandError.Name)
+
+	_, err := database.Collection("posts").InsertOne(ctx, bson.M{"title": "Goravel"})
+	s.NoError(err)
+
+	names, err := database.ListCollectionNames(ctx, bson.M{})
+	s.NoError(err)
+	s.Equal([]string{"posts", "users"}, names)
+
+	names, err = database.ListCollectionNames(ctx, bson.M{"name": "posts"})
+	s.NoError(err)
+	s.Equal([]string{"posts"}, names)
+
+	s.NoError(database.Collection("users").Drop(ctx))
+	names, err = database.ListCollectionNames(ctx, bson.M{})
+	s.NoError(err)
+	s.Equal([]string{"posts"}, names)
+
+	s.NoError(database.Drop(ctx))
+	names, err = database.ListCollectionNames(ctx, bson.M{})
+	s.NoError(err)
+	s.Empty(names)
+}
+
+func (s *ServerTestSuite) TestMongoDB() {
+	mockConfig := s.mockConfig()
+
+	client := mongodb.NewMongoDB(mockConfig, nil, "mongodb")
+	defer func() {
+		s.NoError(client.Close())
+	}()
+	s.NoError(client.Ping())
+
+	users := client.Collection("users").WithSoftDeletes()
+	s.NoError(users.Create(&queryUser{ID: 1, Name: "Goravel", Age: 18}))
+	s.NoError(users.Create(&queryUser{ID: 2, Name: "Laravel", Age: 30}))
+
+	deleted, err := users.Where("name", "Laravel").Delete()
+	s.NoError(err)
+	s.Equal(int64(1), deleted)
+
+	var results []queryUser
+	s.NoError(users.Query().Sort("age", -1).Find(&results))
+	s.Len(results, 1)
+	s.Equal("Goravel", results[0].Name)
+
+	count, err := users.Query().WithTrashed().Count()
+	s.NoError(err)
+	s.Equal(int64(2), count)
+
+	names, err := client.Database().ListCollections()
+	s.NoError(err)
+	s.Equal([]string{"users"}, names)
+
+	// The client is shared by the connection, it connects again once closed
+	s.NoError(client.Close())
+	s.NoError(client.Ping())
+}
+
+func (s *ServerTestSuite) TestCloseConcurrently() {
+	mockConfig := s.mockConfig()
+
+	client := mongodb.NewMongoDB(mockConfig, nil, "mongodb")
+	defer func() {
+		s.NoError(client.Close())
+	}()
+
+	// The client may be closed while it is used, the commands of a closed
+	// client fail but never read a reset client
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			client.HealthCheck()
+			_ = client.Ping()
+			_, _ = client.ListDatabases()
+		}()
+		go func() {
+			defer wg.Done()
+			_ = client.Close()
+		}()
+	}
+	wg.Wait()
+
+	s.NoError(client.Ping())
+	s.Equal(mongodb.HealthUp, client.HealthCheck().Status)
+}
+
+func (s *ServerTestSuite) TestObservers() {
+	mockConfig := s.mockConfig()
+
+	client := mongodb.NewMongoDB(mockConfig, nil, "mongodb")
+	other := mongodb.NewMongoDB(mockConfig, nil, "mongodb")
+	defer func() {
+		s.NoError(client.Close())
+		s.NoError(other.Close())
+	}()
+
+	// The observers are shared by the collections of a client only
+	observer := mockscontracts.NewObserver(s.T())
+	client.Collection("users").Observe(observer)
+	observer.EXPECT().Created(mock.Anything).Return(nil).Once()
+
+	s.NoError(client.Collection("users").Create(&queryUser{ID: 1, Name: "Goravel", Age: 18}))
+	s.NoError(other.Collection("users").Create(&queryUser{ID: 2, Name: "Laravel", Age: 30}))
+	s.NoError(client.Collection("posts").Create(&queryUser{ID: 3, Name: "Post", Age: 1}))
+}
+
+func (s *ServerTestSuite) TestSoftDeletes() {
+	mockConfig := s.mockConfig()
+
+	client := mongodb.NewMongoDB(mockConfig, nil, "mongodb")
+	defer func() {
+		s.NoError(client.Close())
+	}()
+
+	client.Collection("users").WithSoftDeletes()
+
+	// The soft deletes are shared by the collections of the client and
+	// applied by the collection methods too
+	users := client.Collection("users")
+	s.NoError(users.Create(&queryUser{ID: 1, Name: "Goravel", Age: 18}))
+	s.NoError(users.Create(&queryUser{ID: 2, Name: "Laravel", Age: 30}))
+	s.NoError(users.Create(&queryUser{ID: 3, Name: "Symfony", Age: 25}))
+
+	deleted, err := users.DeleteMany(bson.M{"age": bson.M{"$gte": 25}})
+	s.NoError(err)
+	s.Equal(int64(2), deleted.DeletedCount)
+
+	cursor, err := users.Find(bson.M{})
+	s.NoError(err)
+	var results []queryUser
+	s.NoError(cursor.All(context.Background(), &results))
+	s.Len(results, 1)
+	s.Equal("Goravel", results[0].Name)
+
+	var user queryUser
+	s.ErrorIs(users.FindOne(bson.D{{Key: "_id", Value: 2}}, &user), mongo.ErrNoDocuments)
+
+	count, err := users.CountDocuments(nil)
+	s.NoError(err)
+	s.Equal(int64(1), count)
+
+	updated, err := users.UpdateMany(bson.M{}, bson.M{"$set": bson.M{"active": true}})
+	s.NoError(err)
+	s.Equal(int64(1), updated.ModifiedCount)
+
+	deleted, err = users.DeleteOne(bson.M{"_id": 1})
+	s.NoError(err)
+	s.Equal(int64(1), deleted.DeletedCount)
+
+	count, err = users.Query().WithTrashed().Count()
+	s.NoError(err)
+	s.Equal(int64(3), count)
+	count, err = client.Collection("users").Query().Count()
+	s.NoError(err)
+	s.Zero(count)
+}
+
+func (s *ServerTestSuite) TestSoftDeletesWithLimit() {
+	mockConfig := s.mockConfig()
+
+	client := mongodb.NewMongoDB(mockConfig, nil, "mongodb")
+	defer func() {
+		s.NoError(client.Close())
+	}()
+
+	users := client.Collection("users").WithSoftDeletes()
+	s.NoError(users.Create(&queryUser{ID: 1, Name: "Goravel", Age: 18}))
+	s.NoError(users.Create(&queryUser{ID: 2, Name: "Laravel", Age: 30}))
+	s.NoError(users.Create(&queryUser{ID: 3, Name: "Symfony", Age: 25}))
+
+	// The writes are limited to the documents the query selects
+	deleted, err := users.Query().Sort("age", -1).Limit(2).Delete()
+	s.NoError(err)
+	s.Equal(int64(2), deleted)
+
+	restored, err := users.Query().Sort("_id", 1).Skip(1).Restore()
+	s.NoError(err)
+	s.Equal(int64(1), restored)
+
+	forceDeleted, err := users.Query().WithTrashed().Sort("_id", 1).Limit(1).ForceDelete()
+	s.NoError(err)
+	s.Equal(int64(1), forceDeleted)
+
+	var results []queryUser
+	s.NoError(users.Query().WithTrashed().Sort("_id", 1).Find(&results))
+	s.Require().Len(results, 2)
+	s.Equal("Laravel", results[0].Name)
+	s.True(results[0].Trashed())
+	s.Equal("Symfony", results[1].Name)
+	s.False(results[1].Trashed())
+}
+
+func (s *ServerTestSuite) TestGlobalScopes() {
+	mockConfig := s.mockConfig()
+
+	client := mongodb.NewMongoDB(mockConfig, nil, "mongodb")
+	defer func() {
+		s.NoError(client.Close())
+	}()
+
+	s.NoError(client.Collection("users").Create(&queryUser{ID: 1, Name: "Goravel", Age: 18, Active: true}))
+	s.NoError(client.Collection("users").Create(&queryUser{ID: 2, Name: "Laravel", Age: 30}))
+
+	// The scopes are shared by the collections of the client and applied by
+	// the collection methods too
+	client.Collection("users").WithScope("active", func(query contracts.QueryBuilder) {
+		query.Where("active", true)
+	})
+	users := client.Collection("users")
+
+	var user queryUser
+	s.ErrorIs(users.FindOne(bson.M{"name": "Laravel"}, &user), mongo.ErrNoDocuments)
+	s.NoError(users.First(&user))
+	s.Equal("Goravel", user.Name)
+
+	count, err := users.CountDocuments(bson.M{})
+	s.NoError(err)
+	s.Equal(int64(1), count)
+
+	updated, err := users.UpdateMany(bson.M{}, bson.M{"$inc": bson.M{"age": 1}})
+	s.NoError(err)
+	s.Equal(int64(1), updated.ModifiedCount)
+
+	deleted, err := users.DeleteMany(bson.M{})
+	s.NoError(err)
+	s.Equal(int64(1), deleted.DeletedCount)
+
+	count, err = users.Query().WithoutGlobalScope().Count()
+	s.NoError(err)
+	s.Equal(int64(1), count)
+}
+
+func (s *ServerTestSuite) TestRelations() {
+	mockConfig := s.mockConfig()
+
+	client := mongodb.NewMongoDB(mockConfig, nil, "mongodb")
+	defer func() {
+		s.NoError(client.Close())
+	}()
+
+	_, err := client.Collection("users").InsertMany([]interface{}{
+		relationUser{ID: 1, Name: "Goravel", TagIDs: []int{1, 2}},
+		relationUser{ID: 2, Name: "Laravel", TagIDs: []int{2}},
+		relationUser{ID: 3, Name: "Symfony"},
+	})
+	s.Require().NoError(err)
+	_, err = client.Collection("posts").InsertMany([]interface{}{
+		relationPost{ID: 1, UserID: 1, Title: "Routing"},
+		relationPost{ID: 2, UserID: 2, Title: "Eloquent"},
+		relationPost{ID: 3, UserID: 1, Title: "Queues"},
+	})
+	s.Require().NoError(err)
+	_, err = client.Collection("profiles").InsertOne(relationPost{ID: 1, UserID: 2, Title: "Laravel profile"})
+	s.Require().NoError(err)
+	_, err = client.Collection("tags").InsertMany([]interface{}{
+		relationTag{ID: 1, Name: "go"},
+		relationTag{ID: 2, Name: "framework"},
+		relationTag{ID: 3, Name: "php"},
+	})
+	s.Require().NoError(err)
+
+	var users []relationUser
+	s.NoError(client.Collection("users").Query().With("posts", "profile", "tags").Sort("_id", 1).Find(&users))
+	s.Equal([]relationUser{
+		{
+			ID: 1, Name: "Goravel", TagIDs: []int{1, 2},
+			Posts: []relationPost{{ID: 1, UserID: 1, Title: "Routing"}, {ID: 3, UserID: 1, Title: "Queues"}},
+			Tags:  []relationTag{{ID: 1, Name: "go"}, {ID: 2, Name: "framework"}},
+		},
+		{
+			ID: 2, Name: "Laravel", TagIDs: []int{2},
+			Posts:   []relationPost{{ID: 2, UserID: 2, Title: "Eloquent"}},
+			Profile: &relationPost{ID: 1, UserID: 2, Title: "Laravel profile"},
+			Tags:    []relationTag{{ID: 2, Name: "framework"}},
+		},
+		{ID: 3, Name: "Symfony", Posts: []relationPost{}, Tags: []relationTag{}},
+	}, users)
+
+	var post relationPost
+	s.NoError(client.Collection("posts").Query().With("author").Where("_id", 2).First(&post))
+	s.Equal(&relationUser{ID: 2, Name: "Laravel", TagIDs: []int{2}}, post.Author)
+}
+
+func (s *ServerTestSuite) TestGridFSWithContext() {
+	mockConfig := s.mockConfig()
+
+	client := mongodb.NewMongoDB(mockConfig, nil, "mongodb")
+	defer func() {
+		s.NoError(client.Close())
+	}()
+
+	bucket, err := client.Database().GridFS()
+	s.Require().NoError(err)
+	_, err = bucket.Find(bson.M{})
+	s.NoError(err)
+
+	// The operations run with the context given to WithContext
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = bucket.WithContext(ctx).Find(bson.M{})
+	s.ErrorIs(err, context.Canceled)
+	s.ErrorIs(bucket.WithContext(ctx).Delete(primitive.NewObjectID()), context.Canceled)
+}
+
+func (s *ServerTestSuite) TestValidators() {
+	mockConfig := s.mockConfig()
+
+	client := mongodb.NewMongoDB(mockConfig, nil, "mongodb")
+	defer func() {
+		s.NoError(client.Close())
+	}()
+
+	database := client.Database()
+	s.NoError(database.CreateCollection("users", queryUser{}, options.CreateCollection()))
+	s.NoError(client.Collection("users").SetValidator(&queryUser{}, mongodb.ValidationLevelModerate, mongodb.ValidationActionWarn))
+	s.NoError(client.Collection("users").SetValidator(nil, "", ""))
+
+	var commandError mongo.CommandError
+	s.ErrorAs(client.Collection("posts").SetValidator(bson.M{"title": bson.M{"$type": "string"}}, "", ""), &commandError)
+	s.Equal("NamespaceNotFound", commandError.Name)
+
+	s.ErrorContains(database.CreateCollection("tasks", struct{ Run func() }{}), "field Run: type func() cannot be stored in MongoDB")
+}
+
+func (s *ServerTestSuite) TestCollectionHelpers() {
+	mockConfig := s.mockConfig()
+
+	client := mongodb.NewMongoDB(mockConfig, nil, "mongodb")
+	defer func() {
+		s.NoError(client.Close())
+	}()
+
+	database := client.Database()
+	s.NoError(database.CreateTimeSeries("metrics", "timestamp", "host", mongodb.TimeSeriesGranularityMinutes, 24*time.Hour))
+	s.NoError(database.CreateCapped("logs", 1024, 100))
+	s.NoError(database.CreateClustered("events"))
+	s.NoError(database.CreateCollection("users"))
+
+	info, err := database.CollectionInfo("metrics")
+	s.NoError(err)
+	s.Equal(&contracts.CollectionInfo{
+		Name: "metrics",
+		Type: "timeseries",
+		Options: bson.M{
+			"timeseries":         bson.M{"timeField": "timestamp", "metaField": "host", "granularity": "minutes"},
+			"expireAfterSeconds": int64(86400),
+		},
+	}, info)
+
+	info, err = database.CollectionInfo("logs")
+	s.NoError(err)
+	s.Equal("collection", info.Type)
+	s.Equal(bson.M{"capped": true, "size": int64(1024), "max": int64(100)}, info.Options)
+
+	info, err = database.CollectionInfo("events")
+	s.NoError(err)
+	s.Equal(bson.M{"key": bson.M{"_id": int32(1)}, "unique": true}, info.Options["clusteredIndex"])
+
+	info, err = database.CollectionInfo("users")
+	s.NoError(err)
+	s.Equal(&contracts.CollectionInfo{Name: "users", Type: "collection", Options: bson.M{}}, info)
+
+	_, err = database.CollectionInfo("posts")
+	s.ErrorIs(err, mongodb.CollectionNotFound)
+
+	s.NoError(database.Collection("metrics").Drop())
+	s.NoError(database.CreateCollection("metrics"))
+	info, err = database.CollectionInfo("metrics")
+	s.NoError(err)
+	s.Equal("collection", info.Type)
+}
+
+func (s *ServerTestSuite) TestViews() {
+	mockConfig := s.mockConfig()
+
+	client := mongodb.NewMongoDB(mockConfig, nil, "mongodb")
+	defer func() {
+		s.NoError(client.Close())
+	}()
+
+	database := client.Database()
+	users := database.Collection("users")
+	_, err := users.InsertMany([]interface{}{
+		queryUser{ID: 1, Name: "Goravel", Age: 18},
+		queryUser{ID: 2, Name: "Laravel", Age: 30},
+		queryUser{ID: 3, Name: "Symfony", Age: 25},
+	})
+	s.NoError(err)
+
+	s.NoError(database.CreateView("adults", "users", mongo.Pipeline{
+		{{Key: "$match", Value: bson.D{{Key: "age", Value: bson.D{{Key: "$gt", Value: 20}}}}}},
+		{{Key: "$project", Value: bson.D{{Key: "name", Value: 1}}}},
+	}))
+
+	var adults []bson.M
+	s.NoError(database.Collection("adults").Query().Sort("name", 1).Find(&adults))
+	s.Equal([]bson.M{{"_id": int32(2), "name": "Laravel"}, {"_id": int32(3), "name": "Symfony"}}, adults)
+
+	info, err := database.CollectionInfo("adults")
+	s.NoError(err)
+	s.Equal("view", info.Type)
+	s.True(info.ReadOnly)
+	s.Equal("users", info.Options["viewOn"])
+
+	var results []bson.M
+	s.NoError(users.Aggregate([]bson.M{{"$match": bson.M{"age": bson.M{"$lt": 30}}}, {"$sort": bson.M{"age": -1}}}).All(&results))
+	s.Len(results, 2)
+	s.Equal("Symfony", results[0]["name"])
+
+	// Refresh a materialised view
+	s.NoError(users.Aggregate(mongo.Pipeline{{{Key: "$project", Value: bson.D{{Key: "name", Value: 1}}}}}).Merge("names", nil, ""))
+	s.NoError(users.Aggregate(mongo.Pipeline{
+		{{Key: "$match", Value: bson.D{{Key: "_id", Value: 1}}}},
+		{{Key: "$project", Value: bson.D{{Key: "_id", Value: 0}, {Key: "name", Value: 1}, {Key: "age", Value: 1}}}},
+	}).Merge("names", []string{"name"}, mongodb.MergeWhenMatchedReplace))
+
+	var names []bson.M
+	s.NoError(database.Collection("names").Query().Sort("_id", 1).Find(&names))
+	s.Equal([]bson.M{
+		{"_id": int32(1), "name": "Goravel", "age": int32(18)},
+		{"_id": int32(2), "name": "Laravel"},
+		{"_id": int32(3), "name": "Symfony"},
+	}, names)
+
+	collections, err := database.ListCollections()
+	s.NoError(err)
+	s.ElementsMatch([]string{"adults", "names", "users"}, collections)
+
+	views, err := database.ListViews()
+	s.NoError(err)
+	s.Equal([]string{"adults"}, views)
+}
+
+func (s *ServerTestSuite) TestListSpecs() {
+	mockConfig := s.mockConfig()
+
+	client := mongodb.NewMongoDB(mockConfig, nil, "mongodb")
+	defer func() {
+		s.NoError(client.Close())
+	}()
+
+	database := client.Database()
+	s.NoError(database.CreateCollection("users", queryUser{}))
+	s.NoError(database.CreateCapped("user_logs", 1024, 0))
+	s.NoError(database.CreateView("user_names", "users", nil))
+	_, err := client.Collection("posts", "blog").InsertOne(bson.M{"title": "Goravel"})
+	s.NoError(err)
+
+	infos, err := database.ListCollectionSpecs("user_*")
+	s.NoError(err)
+	s.Len(infos, 2)
+	s.Equal("user_logs", infos[0].Name)
+	s.Nil(infos[0].Validator)
+	s.Equal("user_names", infos[1].Name)
+	s.Equal("view", infos[1].Type)
+
+	infos, err = database.ListCollectionSpecs(bson.M{"type": "collection"})
+	s.NoError(err)
+	s.Len(infos, 2)
+
+	infos, err = database.ListCollectionSpecs(nil)
+	s.NoError(err)
+	s.Len(infos, 3)
+	s.Equal("users", infos[1].Name)
+	s.Contains(infos[1].Validator, "$jsonSchema")
+
+	databases, err := client.ListDatabases()
+	s.NoError(err)
+	s.Len(databases, 2)
+	s.Equal("blog", databases[0].Name)
+	s.Positive(databases[0].SizeOnDisk)
+	s.False(databases[0].Empty)
+	s.Equal(contracts.DatabaseInfo{Name: "goravel", Empty: true}, databases[1])
+}
+
+func (s *ServerTestSuite) TestHealthCheck() {
+	mockConfig := s.mockConfig()
+	mockConfig.EXPECT().Get("database.connections.offline.write").Return([]contracts.Config{
+		{URI: "mongodb://127.0.0.1:1/?serverSelectionTimeoutMS=100", Database: "goravel"},
+	}).Maybe()
+	mockConfig.EXPECT().Get("database.connections.offline.metrics").Return(nil).Maybe()
+	mockConfig.EXPECT().GetBool("database.connections.offline.tracing", false).Return(false).Maybe()
+
+	client := mongodb.NewMongoDB(mockConfig, nil, "mongodb")
+	defer func() {
+		s.NoError(client.Close())
+	}()
+
+	health := client.HealthCheck()
+	s.Equal("mongodb", health.Connection)
+	s.Equal(mongodb.HealthUp, health.Status)
+	s.Positive(health.Latency)
+	s.Equal(mongodb.TopologyStandalone, health.Topology)
+	s.Equal("6.0.0", health.ServerVersion)
+	s.Positive(health.Pool.Open)
+	s.Equal(uint64(100), health.Pool.Max)
+	s.Empty(health.Error)
+
+	offline := mongodb.NewMongoDB(mockConfig, nil, "offline")
+	health = offline.HealthCheck()
+	s.Equal("offline", health.Connection)
+	s.Equal(mongodb.HealthDown, health.Status)
+	s.Equal(mongodb.TopologyUnknown, health.Topology)
+	s.Contains(health.Error, "failed to ping MongoDB")
+
+	report := mongodb.NewHealthHandler(mockConfig, func(connection string) (contracts.Client, error) {
+		if connection == "offline" {
+			return offline, nil
+		}
+		return client, nil
+	}, "mongodb", "offline").Check()
+	s.Equal(mongodb.HealthDown, report.Status)
+	s.Len(report.Connections, 2)
+	s.Equal(mongodb.HealthUp, report.Connections[0].Status)
+}
+
+func (s *ServerTestSuite) TestMetrics() {
+	metrics := mongodb.NewMemoryMetrics()
+	mockConfig := mocksconfig.NewConfig(s.T())
+	mockConfig.EXPECT().Get("database.connections.mongodb.write").Return([]contracts.Config{
+		{URI: s.server.URI(), Database: "goravel"},
+	}).Maybe()
+	mockConfig.EXPECT().Get("database.connections.mongodb.metrics").Return(metrics).Maybe()
+	mockConfig.EXPECT().GetBool("database.connections.mongodb.tracing", false).Return(false).Maybe()
+
+	client := mongodb.NewMongoDB(mockConfig, nil, "mongodb")
+	users := client.Collection("users")
+	_, err := users.InsertOne(bson.M{"name": "Goravel"})
+	s.NoError(err)
+	_, err = users.InsertOne(bson.M{"_id": 1})
+	s.NoError(err)
+	_, err = users.InsertOne(bson.M{"_id": 1})
+	s.Error(err)
+	var result bson.M
+	s.NoError(users.FindOne(bson.M{"name": "Goravel"}, &result))
+
+	insert := map[string]string{"connection": "mongodb", "command": "insert", "collection": "users"}
+	find := map[string]string{"connection": "mongodb", "command": "find", "collection": "users"}
+	s.Len(metrics.Histogram(mongodb.MetricCommandDurationSeconds, insert), 3)
+	s.Len(metrics.Histogram(mongodb.MetricCommandDurationSeconds, find), 1)
+	s.Zero(metrics.Counter(mongodb.MetricCommandFailures, find))
+
+	pool := map[string]string{"connection": "mongodb"}
+	s.Positive(metrics.Gauge(mongodb.MetricPoolConnectionsOpen, pool))
+	s.Zero(metrics.Gauge(mongodb.MetricPoolConnectionsCheckedOut, pool))
+	s.NotEmpty(metrics.Histogram(mongodb.MetricPoolWaitSeconds, pool))
+
+	s.NoError(client.Close())
+	s.Zero(metrics.Gauge(mongodb.MetricPoolConnectionsOpen, pool))
+}
+
+func (s *ServerTestSuite) TestCommandLogging() {
+	mockConfig := s.mockConfig()
+	mockConfig.EXPECT().GetBool("app.debug").Return(true).Once()
+	mockConfig.EXPECT().GetInt("database.slow_threshold", 200).Return(200).Once()
+	mockConfig.EXPECT().GetInt("database.connections.mongodb.slow_threshold", 200).Return(200).Once()
+	mockConfig.EXPECT().Get("database.connections.mongodb.redact").Return(nil).Once()
+
+	mockLog := mockslog.NewLog(s.T())
+	mockLog.EXPECT().Debugf("[%.3fms] %s", mock.Anything, "goravel.users insert").Once()
+	mockLog.EXPECT().Debugf("[%.3fms] %s", mock.Anything, `goravel.users find {"name":"Goravel","password":"[REDACTED]"}`).Once()
+	mockLog.EXPECT().Errorf("[%.3fms] %s\t%s", mock.Anything, "goravel.posts listIndexes", "(NamespaceNotFound) ns does not exist: goravel.posts").Once()
+	mockLog.EXPECT().Debugf("[%.3fms] %s", mock.Anything, mock.Anything).Maybe()
+
+	client := mongodb.NewMongoDB(mockConfig, mockLog, "mongodb")
+	defer func() {
+		s.NoError(client.Close())
+	}()
+
+	users := client.Collection("users")
+	_, err := users.InsertOne(bson.M{"name": "Goravel", "password": "secret"})
+	s.NoError(err)
+	var result bson.M
+	s.NoError(users.FindOne(bson.D{{Key: "name", Value: "Goravel"}, {Key: "password", Value: "secret"}}, &result))
+
+	// The driver hides the missing collection, the failed command is logged
+	_, err = client.Collection("posts").Native().Indexes().List(context.Background())
+	s.NoError(err)
+}
+
+func (s *ServerTestSuite) TestTracing() {
+	exporter := tracetest.NewInMemoryExporter()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	defer func() {
+		s.NoError(provider.Shutdown(context.Background()))
+	}()
+
+	mockConfig := mocksconfig.NewConfig(s.T())
+	mockConfig.EXPECT().Get("database.connections.mongodb.write").Return([]contracts.Config{
+		{URI: s.server.URI(), Database: "goravel", TracerProvider: provider},
+	}).Maybe()
+	mockConfig.EXPECT().Get("database.connections.mongodb.metrics").Return(nil).Maybe()
+	mockConfig.EXPECT().GetBool("database.connections.mongodb.tracing", false).Return(true).Maybe()
+
+	client := mongodb.NewMongoDB(mockConfig, nil, "mongodb")
+	defer func() {
+		s.NoError(client.Close())
+	}()
+	s.NoError(client.Ping())
+	exporter.Reset()
+
+	// The spans are children of the span of the context of the native client
+	ctx, parent := provider.Tracer("test").Start(context.Background(), "request")
+	users := client.Native().Database("goravel").Collection("users")
+	_, err := users.InsertOne(ctx, bson.M{"name": "Goravel"})
+	s.NoError(err)
+	s.NoError(users.FindOne(ctx, bson.M{"name": "Goravel"}).Err())
+	parent.End()
+
+	spans := exporter.GetSpans()
+	s.Require().Len(spans, 3)
+	s.Equal("insert goravel.users", spans[0].Name)
+	s.Equal("find goravel.users", spans[1].Name)
+	s.Equal(parent.SpanContext().SpanID(), spans[1].Parent.SpanID())
+	s.Contains(spans[1].Attributes, attribute.String("db.statement", `{"name":"?"}`))
+	s.Contains(spans[1].Attributes, attribute.String("db.mongodb.collection", "users"))
+
+	// The spans of the client, database, collection and query builder methods
+	// are children of the span of the context given to WithContext
+	exporter.Reset()
+	ctx, parent = provider.Tracer("test").Start(context.Background(), "request")
+	s.NoError(client.WithContext(ctx).Collection("users").Create(&queryUser{ID: 1, Name: "Laravel"}))
+	var user queryUser
+	s.NoError(client.Database().WithContext(ctx).Collection("users").FindOne(bson.M{"_id": 1}, &user))
+	count, err := client.Collection("users").WithContext(ctx).Query().Count()
+	s.NoError(err)
+	s.Equal(int64(2), count)
+	_, err = client.Collection("users").Where("_id", 1).WithContext(ctx).Update(bson.M{"$set": bson.M{"age": 20}})
+	s.NoError(err)
+	s.NoError(client.WithContext(ctx).Ping())
+	parent.End()
+
+	spans = exporter.GetSpans()
+	s.Require().Len(spans, 6)
+	for i, name := range []string{"insert goravel.users", "find goravel.users", "aggregate goravel.users", "update goravel.users", "ping admin"} {
+		s.Equal(name, spans[i].Name)
+		s.Equal(parent.SpanContext().SpanID(), spans[i].Parent.SpanID())
+	}
+
+	// Without a context the spans are root spans
+	exporter.Reset()
+	s.NoError(client.Ping())
+	spans = exporter.GetSpans()
+	s.Require().Len(spans, 1)
+	s.False(spans[0].Parent.IsValid())
+}
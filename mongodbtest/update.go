@@ -0,0 +1,249 @@
+package mongodbtest
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// applyUpdate returns the document modified by the update operators. The
+// supported operators are $set, $unset, $setOnInsert, $inc, $mul, $min, $max,
+// $rename, $currentDate, $push, $addToSet, $pull and $pop.
+func applyUpdate(document bson.D, update bson.D, insert bool) (bson.D, error) {
+	if len(update) == 0 {
+		return nil, fmt.Errorf("update document must not be empty")
+	}
+
+	updated := cloneDocument(document)
+	for _, operator := range update {
+		if !isOperator(operator.Key) {
+			return nil, fmt.Errorf("update document requires atomic operators")
+		}
+
+		fields, ok := operator.Value.(bson.D)
+		if !ok {
+			return nil, fmt.Errorf("modifiers operate on fields but we found type %T instead", operator.Value)
+		}
+
+		for _, field := range fields {
+			var err error
+			updated, err = applyOperator(updated, operator.Key, field, insert)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if id, ok := lookupKey(document, "_id"); ok && !insert {
+		if updatedID, ok := lookupKey(updated, "_id"); !ok || !equal(id, updatedID) {
+			return nil, fmt.Errorf("performing an update on the path '_id' would modify the immutable field '_id'")
+		}
+	}
+
+	return updated, nil
+}
+
+func applyOperator(document bson.D, operator string, field bson.E, insert bool) (bson.D, error) {
+	current, exists := getPath(document, field.Key)
+
+	switch operator {
+	case "$set":
+		return setPath(document, field.Key, field.Value)
+	case "$setOnInsert":
+		if !insert {
+			return document, nil
+		}
+		return setPath(document, field.Key, field.Value)
+	case "$unset":
+		return removePath(document, field.Key), nil
+	case "$inc", "$mul":
+		if exists {
+			if _, ok := toFloat(current); !ok {
+				return nil, fmt.Errorf("cannot apply %s to a value of non-numeric type %T", operator, current)
+			}
+		} else {
+			current = int32(0)
+		}
+		if _, ok := toFloat(field.Value); !ok {
+			return nil, fmt.Errorf("cannot %s with non-numeric argument", operator)
+		}
+		return setPath(document, field.Key, arithmetic(current, field.Value, operator))
+	case "$min", "$max":
+		result := compare(field.Value, current)
+		if !exists || (operator == "$min" && result < 0) || (operator == "$max" && result > 0) {
+			return setPath(document, field.Key, field.Value)
+		}
+		return document, nil
+	case "$rename":
+		name := stringOf(field.Value)
+		if name == "" {
+			return nil, fmt.Errorf("the 'to' field for $rename must be a string")
+		}
+		if !exists {
+			return document, nil
+		}
+		return setPath(removePath(document, field.Key), name, current)
+	case "$currentDate":
+		var now interface{} = primitive.NewDateTimeFromTime(time.Now())
+		if specification, ok := field.Value.(bson.D); ok {
+			if kind, _ := lookupKey(specification, "$type"); kind == "timestamp" {
+				now = primitive.Timestamp{T: uint32(time.Now().Unix())}
+			}
+		}
+		return setPath(document, field.Key, now)
+	case "$push", "$addToSet":
+		array, err := arrayAt(current, exists, operator, field.Key)
+		if err != nil {
+			return nil, err
+		}
+
+		values := bson.A{field.Value}
+		if specification, ok := field.Value.(bson.D); ok {
+			if each, ok := lookupKey(specification, "$each"); ok {
+				if values, ok = each.(bson.A); !ok {
+					return nil, fmt.Errorf("the argument to $each in %s must be an array", operator)
+				}
+			}
+		}
+
+		for _, value := range values {
+			if operator == "$addToSet" && containsEqual(array, value) {
+				continue
+			}
+			array = append(array, value)
+		}
+		return setPath(document, field.Key, array)
+	case "$pull":
+		if !exists {
+			return document, nil
+		}
+		array, err := arrayAt(current, exists, operator, field.Key)
+		if err != nil {
+			return nil, err
+		}
+
+		kept := bson.A{}
+		for _, element := range array {
+			matched, err := matchElementCondition(element, field.Value)
+			if err != nil {
+				return nil, err
+			}
+			if !matched {
+				kept = append(kept, element)
+			}
+		}
+		return setPath(document, field.Key, kept)
+	case "$pop":
+		if !exists {
+			return document, nil
+		}
+		array, err := arrayAt(current, exists, operator, field.Key)
+		if err != nil {
+			return nil, err
+		}
+		if len(array) == 0 {
+			return document, nil
+		}
+
+		if position, _ := toFloat(field.Value); position < 0 {
+			array = array[1:]
+		} else {
+			array = array[:len(array)-1]
+		}
+		return setPath(document, field.Key, array)
+	}
+
+	return nil, fmt.Errorf("unknown modifier: %s", operator)
+}
+
+func arrayAt(current interface{}, exists bool, operator, path string) (bson.A, error) {
+	if !exists {
+		return bson.A{}, nil
+	}
+
+	array, ok := current.(bson.A)
+	if !ok {
+		return nil, fmt.Errorf("the field '%s' must be an array to apply %s", path, operator)
+	}
+
+	return append(bson.A{}, array...), nil
+}
+
+func containsEqual(array bson.A, value interface{}) bool {
+	for _, element := range array {
+		if equal(element, value) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// arithmetic adds or multiplies numbers keeping the widest type, like MongoDB
+func arithmetic(a, b interface{}, operator string) interface{} {
+	_, floatA := a.(float64)
+	_, floatB := b.(float64)
+	x, _ := toFloat(a)
+	y, _ := toFloat(b)
+	result := x + y
+	if operator == "$mul" {
+		result = x * y
+	}
+
+	switch {
+	case floatA || floatB:
+		return result
+	case isInt32(a) && isInt32(b) && result >= math.MinInt32 && result <= math.MaxInt32:
+		return int32(result)
+	default:
+		return int64(result)
+	}
+}
+
+func isInt32(value interface{}) bool {
+	_, ok := value.(int32)
+
+	return ok
+}
+
+// upsertDocument builds the document inserted by an upsert from the equality
+// conditions of the filter
+func upsertDocument(filter bson.D) (bson.D, error) {
+	document := bson.D{}
+	for _, element := range filter {
+		var err error
+		switch {
+		case element.Key == "$and":
+			conditions, _ := element.Value.(bson.A)
+			for _, condition := range conditions {
+				if condition, ok := condition.(bson.D); ok {
+					var nested bson.D
+					if nested, err = upsertDocument(condition); err != nil {
+						return nil, err
+					}
+					for _, field := range nested {
+						if document, err = setPath(document, field.Key, field.Value); err != nil {
+							return nil, err
+						}
+					}
+				}
+			}
+		case isOperator(element.Key):
+			// $and, $or and the other top level operators add no fields
+		case isOperatorDocument(element.Value):
+			if value, ok := lookupKey(element.Value.(bson.D), "$eq"); ok {
+				document, err = setPath(document, element.Key, value)
+			}
+		default:
+			document, err = setPath(document, element.Key, element.Value)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return document, nil
+}
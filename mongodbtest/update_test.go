@@ -0,0 +1,193 @@
+package mongodbtest
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type UpdateTestSuite struct {
+	suite.Suite
+	document bson.D
+}
+
+func TestUpdateTestSuite(t *testing.T) {
+	suite.Run(t, new(UpdateTestSuite))
+}
+
+func (s *UpdateTestSuite) SetupTest() {
+	s.document = bson.D{
+		{Key: "_id", Value: int32(1)},
+		{Key: "name", Value: "Goravel"},
+		{Key: "age", Value: int32(18)},
+		{Key: "score", Value: 9.5},
+		{Key: "tags", Value: bson.A{"go", "mongodb"}},
+		{Key: "address", Value: bson.D{{Key: "city", Value: "Shanghai"}}},
+	}
+}
+
+func (s *UpdateTestSuite) TestApplyUpdate() {
+	tests := []struct {
+		name     string
+		update   interface{}
+		expected bson.D
+	}{
+		{
+			name:   "$set",
+			update: bson.M{"$set": bson.M{"name": "Framework", "address.zip": "200000", "active": true}},
+			expected: bson.D{
+				{Key: "_id", Value: int32(1)},
+				{Key: "name", Value: "Framework"},
+				{Key: "age", Value: int32(18)},
+				{Key: "score", Value: 9.5},
+				{Key: "tags", Value: bson.A{"go", "mongodb"}},
+				{Key: "address", Value: bson.D{{Key: "city", Value: "Shanghai"}, {Key: "zip", Value: "200000"}}},
+				{Key: "active", Value: true},
+			},
+		},
+		{
+			name:   "$set array element",
+			update: bson.M{"$set": bson.M{"tags.1": "redis"}},
+			expected: bson.D{
+				{Key: "_id", Value: int32(1)},
+				{Key: "name", Value: "Goravel"},
+				{Key: "age", Value: int32(18)},
+				{Key: "score", Value: 9.5},
+				{Key: "tags", Value: bson.A{"go", "redis"}},
+				{Key: "address", Value: bson.D{{Key: "city", Value: "Shanghai"}}},
+			},
+		},
+		{
+			name:   "$unset and $rename",
+			update: bson.D{{Key: "$unset", Value: bson.M{"score": "", "address.city": ""}}, {Key: "$rename", Value: bson.M{"name": "title"}}},
+			expected: bson.D{
+				{Key: "_id", Value: int32(1)},
+				{Key: "age", Value: int32(18)},
+				{Key: "tags", Value: bson.A{"go", "mongodb"}},
+				{Key: "address", Value: bson.D{}},
+				{Key: "title", Value: "Goravel"},
+			},
+		},
+		{
+			name:   "$inc, $mul, $min and $max",
+			update: bson.D{{Key: "$inc", Value: bson.D{{Key: "age", Value: int32(2)}, {Key: "views", Value: int64(1)}}}, {Key: "$mul", Value: bson.M{"score": 2}}, {Key: "$max", Value: bson.M{"name": "Zebra"}}},
+			expected: bson.D{
+				{Key: "_id", Value: int32(1)},
+				{Key: "name", Value: "Zebra"},
+				{Key: "age", Value: int32(20)},
+				{Key: "score", Value: 19.0},
+				{Key: "tags", Value: bson.A{"go", "mongodb"}},
+				{Key: "address", Value: bson.D{{Key: "city", Value: "Shanghai"}}},
+				{Key: "views", Value: int64(1)},
+			},
+		},
+		{
+			name:   "$push, $addToSet and $pull",
+			update: bson.D{{Key: "$push", Value: bson.M{"tags": bson.M{"$each": bson.A{"redis", "go"}}}}, {Key: "$addToSet", Value: bson.M{"tags": "mongodb", "labels": "new"}}, {Key: "$pull", Value: bson.M{"tags": "go"}}},
+			expected: bson.D{
+				{Key: "_id", Value: int32(1)},
+				{Key: "name", Value: "Goravel"},
+				{Key: "age", Value: int32(18)},
+				{Key: "score", Value: 9.5},
+				{Key: "tags", Value: bson.A{"mongodb", "redis"}},
+				{Key: "address", Value: bson.D{{Key: "city", Value: "Shanghai"}}},
+				{Key: "labels", Value: bson.A{"new"}},
+			},
+		},
+		{
+			name:   "$pop",
+			update: bson.M{"$pop": bson.M{"tags": -1}},
+			expected: bson.D{
+				{Key: "_id", Value: int32(1)},
+				{Key: "name", Value: "Goravel"},
+				{Key: "age", Value: int32(18)},
+				{Key: "score", Value: 9.5},
+				{Key: "tags", Value: bson.A{"mongodb"}},
+				{Key: "address", Value: bson.D{{Key: "city", Value: "Shanghai"}}},
+			},
+		},
+		{
+			name:   "$setOnInsert is ignored on update",
+			update: bson.M{"$setOnInsert": bson.M{"name": "Framework"}},
+			expected: bson.D{
+				{Key: "_id", Value: int32(1)},
+				{Key: "name", Value: "Goravel"},
+				{Key: "age", Value: int32(18)},
+				{Key: "score", Value: 9.5},
+				{Key: "tags", Value: bson.A{"go", "mongodb"}},
+				{Key: "address", Value: bson.D{{Key: "city", Value: "Shanghai"}}},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		s.Run(test.name, func() {
+			update, err := toDocument(test.update)
+			s.Require().NoError(err)
+
+			updated, err := applyUpdate(s.document, update, false)
+			s.NoError(err)
+			s.Equal(test.expected, updated)
+			s.Equal("Goravel", s.document[1].Value)
+		})
+	}
+}
+
+func (s *UpdateTestSuite) TestApplyUpdateCurrentDate() {
+	update, err := toDocument(bson.M{"$currentDate": bson.M{"updated_at": true, "synced_at": bson.M{"$type": "timestamp"}}})
+	s.Require().NoError(err)
+
+	updated, err := applyUpdate(s.document, update, false)
+	s.NoError(err)
+
+	updatedAt, _ := getPath(updated, "updated_at")
+	s.IsType(primitive.DateTime(0), updatedAt)
+	syncedAt, _ := getPath(updated, "synced_at")
+	s.IsType(primitive.Timestamp{}, syncedAt)
+}
+
+func (s *UpdateTestSuite) TestApplyUpdateError() {
+	tests := []struct {
+		name   string
+		update interface{}
+		err    string
+	}{
+		{name: "replacement", update: bson.M{"name": "Framework"}, err: "update document requires atomic operators"},
+		{name: "empty", update: bson.M{}, err: "update document must not be empty"},
+		{name: "unknown", update: bson.M{"$bit": bson.M{"age": bson.M{"and": 1}}}, err: "unknown modifier: $bit"},
+		{name: "$inc on string", update: bson.M{"$inc": bson.M{"name": 1}}, err: "cannot apply $inc to a value of non-numeric type string"},
+		{name: "$push on string", update: bson.M{"$push": bson.M{"name": "go"}}, err: "the field 'name' must be an array to apply $push"},
+		{name: "_id", update: bson.M{"$set": bson.M{"_id": 2}}, err: "performing an update on the path '_id' would modify the immutable field '_id'"},
+	}
+
+	for _, test := range tests {
+		s.Run(test.name, func() {
+			update, err := toDocument(test.update)
+			s.Require().NoError(err)
+
+			_, err = applyUpdate(s.document, update, false)
+			s.EqualError(err, test.err)
+		})
+	}
+}
+
+func (s *UpdateTestSuite) TestUpsertDocument() {
+	filter, err := toDocument(bson.D{
+		{Key: "name", Value: "Goravel"},
+		{Key: "age", Value: bson.M{"$gt": 18}},
+		{Key: "address.city", Value: bson.M{"$eq": "Shanghai"}},
+		{Key: "$and", Value: bson.A{bson.M{"active": true}}},
+		{Key: "$or", Value: bson.A{bson.M{"role": "admin"}}},
+	})
+	s.Require().NoError(err)
+
+	document, err := upsertDocument(filter)
+	s.NoError(err)
+	s.Equal(bson.D{
+		{Key: "name", Value: "Goravel"},
+		{Key: "address", Value: bson.D{{Key: "city", Value: "Shanghai"}}},
+		{Key: "active", Value: true},
+	}, document)
+}
@@ -0,0 +1,388 @@
+package mongodbtest
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// toDocument converts filters, updates, options and models to a bson.D with
+// the types MongoDB would store: int32/int64/float64, primitive.DateTime,
+// nested bson.D and bson.A
+func toDocument(value interface{}) (bson.D, error) {
+	switch value := value.(type) {
+	case nil:
+		return bson.D{}, nil
+	case bson.Raw:
+		var document bson.D
+		err := bson.Unmarshal(value, &document)
+		return document, err
+	}
+
+	switch reflected := reflect.ValueOf(value); reflected.Kind() {
+	case reflect.Map, reflect.Slice, reflect.Ptr:
+		if reflected.IsNil() {
+			return bson.D{}, nil
+		}
+	}
+
+	data, err := bson.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+
+	var document bson.D
+	if err := bson.Unmarshal(data, &document); err != nil {
+		return nil, err
+	}
+
+	return document, nil
+}
+
+func cloneDocument(document bson.D) bson.D {
+	clone, err := toDocument(document)
+	if err != nil {
+		// a stored document always marshals
+		panic(err)
+	}
+
+	return clone
+}
+
+func isOperator(key string) bool {
+	return strings.HasPrefix(key, "$")
+}
+
+// isOperatorDocument reports whether the value is a document of operators,
+// like {$gt: 1}, rather than a document to compare with
+func isOperatorDocument(value interface{}) bool {
+	document, ok := value.(bson.D)
+
+	return ok && len(document) > 0 && isOperator(document[0].Key)
+}
+
+func lookupKey(document bson.D, key string) (interface{}, bool) {
+	for _, element := range document {
+		if element.Key == key {
+			return element.Value, true
+		}
+	}
+
+	return nil, false
+}
+
+// lookup returns the values at the dotted path, arrays found on the way are
+// traversed like MongoDB does, a missing field returns no value
+func lookup(value interface{}, path []string) []interface{} {
+	if len(path) == 0 {
+		return []interface{}{value}
+	}
+
+	switch value := value.(type) {
+	case bson.D:
+		if field, ok := lookupKey(value, path[0]); ok {
+			return lookup(field, path[1:])
+		}
+	case bson.A:
+		if index, err := strconv.Atoi(path[0]); err == nil {
+			if index >= 0 && index < len(value) {
+				return lookup(value[index], path[1:])
+			}
+			return nil
+		}
+
+		var values []interface{}
+		for _, element := range value {
+			if _, ok := element.(bson.D); ok {
+				values = append(values, lookup(element, path)...)
+			}
+		}
+		return values
+	}
+
+	return nil
+}
+
+// expand adds the elements of the array values to the values
+func expand(values []interface{}) []interface{} {
+	expanded := append([]interface{}{}, values...)
+	for _, value := range values {
+		if array, ok := value.(bson.A); ok {
+			expanded = append(expanded, array...)
+		}
+	}
+
+	return expanded
+}
+
+// getPath returns the value at the dotted path without traversing arrays
+func getPath(document bson.D, path string) (interface{}, bool) {
+	keys := strings.Split(path, ".")
+	var value interface{} = document
+	for _, key := range keys {
+		switch current := value.(type) {
+		case bson.D:
+			field, ok := lookupKey(current, key)
+			if !ok {
+				return nil, false
+			}
+			value = field
+		case bson.A:
+			index, err := strconv.Atoi(key)
+			if err != nil || index < 0 || index >= len(current) {
+				return nil, false
+			}
+			value = current[index]
+		default:
+			return nil, false
+		}
+	}
+
+	return value, true
+}
+
+// setPath sets the value at the dotted path, creating the missing documents
+func setPath(document bson.D, path string, value interface{}) (bson.D, error) {
+	key, rest, nested := strings.Cut(path, ".")
+	for i, element := range document {
+		if element.Key != key {
+			continue
+		}
+		if !nested {
+			document[i].Value = value
+			return document, nil
+		}
+
+		updated, err := setNestedPath(element.Value, rest, value, path)
+		if err != nil {
+			return nil, err
+		}
+		document[i].Value = updated
+		return document, nil
+	}
+
+	if !nested {
+		return append(document, bson.E{Key: key, Value: value}), nil
+	}
+
+	child, err := setPath(bson.D{}, rest, value)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(document, bson.E{Key: key, Value: child}), nil
+}
+
+func setNestedPath(current interface{}, rest string, value interface{}, path string) (interface{}, error) {
+	switch current := current.(type) {
+	case bson.D:
+		return setPath(current, rest, value)
+	case bson.A:
+		key, remaining, nested := strings.Cut(rest, ".")
+		index, err := strconv.Atoi(key)
+		if err != nil || index < 0 {
+			return nil, fmt.Errorf("cannot create field '%s' in element {%s}", key, path)
+		}
+		for len(current) <= index {
+			current = append(current, nil)
+		}
+		if !nested {
+			current[index] = value
+			return current, nil
+		}
+
+		element, ok := current[index].(bson.D)
+		if !ok {
+			element = bson.D{}
+		}
+		updated, err := setPath(element, remaining, value)
+		if err != nil {
+			return nil, err
+		}
+		current[index] = updated
+		return current, nil
+	default:
+		return nil, fmt.Errorf("cannot create field in element {%s}", path)
+	}
+}
+
+// removePath removes the value at the dotted path
+func removePath(document bson.D, path string) bson.D {
+	key, rest, nested := strings.Cut(path, ".")
+	for i, element := range document {
+		if element.Key != key {
+			continue
+		}
+		if !nested {
+			return append(document[:i], document[i+1:]...)
+		}
+		if child, ok := element.Value.(bson.D); ok {
+			document[i].Value = removePath(child, rest)
+		}
+		return document
+	}
+
+	return document
+}
+
+// typeOrder is MongoDB's comparison order of the BSON types
+func typeOrder(value interface{}) int {
+	switch value.(type) {
+	case nil, primitive.Null, primitive.Undefined:
+		return 1
+	case int32, int64, float64, primitive.Decimal128:
+		return 2
+	case string, primitive.Symbol:
+		return 3
+	case bson.D:
+		return 4
+	case bson.A:
+		return 5
+	case primitive.Binary:
+		return 6
+	case primitive.ObjectID:
+		return 7
+	case bool:
+		return 8
+	case primitive.DateTime:
+		return 9
+	case primitive.Timestamp:
+		return 10
+	case primitive.Regex:
+		return 11
+	default:
+		return 12
+	}
+}
+
+func toFloat(value interface{}) (float64, bool) {
+	switch value := value.(type) {
+	case int32:
+		return float64(value), true
+	case int64:
+		return float64(value), true
+	case float64:
+		return value, true
+	case primitive.Decimal128:
+		float, err := strconv.ParseFloat(value.String(), 64)
+		return float, err == nil
+	}
+
+	return 0, false
+}
+
+// compare orders two values like MongoDB sorts them
+func compare(a, b interface{}) int {
+	if orderA, orderB := typeOrder(a), typeOrder(b); orderA != orderB {
+		return sign(orderA - orderB)
+	}
+
+	switch a := a.(type) {
+	case int32, int64, float64, primitive.Decimal128:
+		floatA, _ := toFloat(a)
+		floatB, _ := toFloat(b)
+		switch {
+		case floatA < floatB:
+			return -1
+		case floatA > floatB:
+			return 1
+		}
+		return 0
+	case string:
+		return strings.Compare(a, stringOf(b))
+	case primitive.Symbol:
+		return strings.Compare(string(a), stringOf(b))
+	case bson.D:
+		b := b.(bson.D)
+		for i := 0; i < len(a) && i < len(b); i++ {
+			if result := strings.Compare(a[i].Key, b[i].Key); result != 0 {
+				return result
+			}
+			if result := compare(a[i].Value, b[i].Value); result != 0 {
+				return result
+			}
+		}
+		return sign(len(a) - len(b))
+	case bson.A:
+		b := b.(bson.A)
+		for i := 0; i < len(a) && i < len(b); i++ {
+			if result := compare(a[i], b[i]); result != 0 {
+				return result
+			}
+		}
+		return sign(len(a) - len(b))
+	case primitive.Binary:
+		return bytes.Compare(a.Data, b.(primitive.Binary).Data)
+	case primitive.ObjectID:
+		id := b.(primitive.ObjectID)
+		return bytes.Compare(a[:], id[:])
+	case bool:
+		if a == b.(bool) {
+			return 0
+		}
+		if !a {
+			return -1
+		}
+		return 1
+	case primitive.DateTime:
+		return sign64(int64(a) - int64(b.(primitive.DateTime)))
+	case primitive.Timestamp:
+		return primitive.CompareTimestamp(a, b.(primitive.Timestamp))
+	case primitive.Regex:
+		return strings.Compare(a.String(), b.(primitive.Regex).String())
+	}
+
+	return strings.Compare(fmt.Sprint(a), fmt.Sprint(b))
+}
+
+func equal(a, b interface{}) bool {
+	return typeOrder(a) == typeOrder(b) && compare(a, b) == 0
+}
+
+func stringOf(value interface{}) string {
+	switch value := value.(type) {
+	case string:
+		return value
+	case primitive.Symbol:
+		return string(value)
+	}
+
+	return ""
+}
+
+func sign(value int) int {
+	return sign64(int64(value))
+}
+
+func sign64(value int64) int {
+	switch {
+	case value < 0:
+		return -1
+	case value > 0:
+		return 1
+	}
+
+	return 0
+}
+
+// truthy reports whether a projection or $exists value is enabled
+func truthy(value interface{}) bool {
+	switch value := value.(type) {
+	case bool:
+		return value
+	case nil:
+		return false
+	}
+
+	if float, ok := toFloat(value); ok {
+		return float != 0 && !math.IsNaN(float)
+	}
+
+	return true
+}
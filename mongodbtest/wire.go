@@ -0,0 +1,246 @@
+package mongodbtest
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// The op codes of the wire protocol, the driver sends its first handshake with
+// OP_QUERY and every other command with OP_MSG
+const (
+	opReply int32 = 1
+	opQuery int32 = 2004
+	opMsg   int32 = 2013
+)
+
+const (
+	msgChecksumPresent uint32 = 1 << 0
+	msgMoreToCome      uint32 = 1 << 1
+)
+
+const (
+	headerSize     = 16
+	maxMessageSize = 48000000
+)
+
+// request is a command read from a connection
+type request struct {
+	requestID    int32
+	opCode       int32
+	database     string
+	command      bson.D
+	moreToCome   bool
+	connectionID int32
+}
+
+func readRequest(reader io.Reader) (*request, error) {
+	var header [headerSize]byte
+	if _, err := io.ReadFull(reader, header[:]); err != nil {
+		return nil, err
+	}
+
+	length := int32(binary.LittleEndian.Uint32(header[0:]))
+	if length < headerSize || length > maxMessageSize {
+		return nil, fmt.Errorf("invalid message length %d", length)
+	}
+
+	body := make([]byte, length-headerSize)
+	if _, err := io.ReadFull(reader, body); err != nil {
+		return nil, err
+	}
+
+	request := &request{
+		requestID: int32(binary.LittleEndian.Uint32(header[4:])),
+		opCode:    int32(binary.LittleEndian.Uint32(header[12:])),
+	}
+
+	var err error
+	switch request.opCode {
+	case opMsg:
+		err = request.parseMsg(body)
+	case opQuery:
+		err = request.parseQuery(body)
+	default:
+		err = fmt.Errorf("op code %d is %w", request.opCode, ErrNotSupported)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return request, nil
+}
+
+// parseMsg reads an OP_MSG, the document sequences are added to the command
+// as arrays named by their identifier
+func (r *request) parseMsg(body []byte) error {
+	if len(body) < 4 {
+		return errors.New("invalid OP_MSG")
+	}
+
+	flags := binary.LittleEndian.Uint32(body)
+	sections := body[4:]
+	if flags&msgChecksumPresent != 0 {
+		if len(sections) < 4 {
+			return errors.New("invalid OP_MSG checksum")
+		}
+		sections = sections[:len(sections)-4]
+	}
+	r.moreToCome = flags&msgMoreToCome != 0
+
+	var sequences bson.D
+	for len(sections) > 0 {
+		kind := sections[0]
+		sections = sections[1:]
+
+		switch kind {
+		case 0:
+			document, rest, err := readDocument(sections)
+			if err != nil {
+				return err
+			}
+			if err := bson.Unmarshal(document, &r.command); err != nil {
+				return err
+			}
+			sections = rest
+		case 1:
+			if len(sections) < 4 {
+				return errors.New("invalid OP_MSG document sequence")
+			}
+			size := int(int32(binary.LittleEndian.Uint32(sections)))
+			if size < 4 || size > len(sections) {
+				return errors.New("invalid OP_MSG document sequence")
+			}
+
+			identifier, sequence, err := readCString(sections[4:size])
+			if err != nil {
+				return err
+			}
+			documents := bson.A{}
+			for len(sequence) > 0 {
+				var raw bson.Raw
+				if raw, sequence, err = readDocument(sequence); err != nil {
+					return err
+				}
+				var document bson.D
+				if err := bson.Unmarshal(raw, &document); err != nil {
+					return err
+				}
+				documents = append(documents, document)
+			}
+			sequences = append(sequences, bson.E{Key: identifier, Value: documents})
+			sections = sections[size:]
+		default:
+			return fmt.Errorf("invalid OP_MSG section kind %d", kind)
+		}
+	}
+
+	r.command = append(r.command, sequences...)
+	database, _ := lookupKey(r.command, "$db")
+	r.database, _ = database.(string)
+	if r.database == "" {
+		return errors.New("OP_MSG has no $db")
+	}
+
+	return nil
+}
+
+// parseQuery reads the OP_QUERY of a handshake sent to <database>.$cmd
+func (r *request) parseQuery(body []byte) error {
+	if len(body) < 4 {
+		return errors.New("invalid OP_QUERY")
+	}
+
+	namespace, rest, err := readCString(body[4:])
+	if err != nil {
+		return err
+	}
+	if len(rest) < 8 {
+		return errors.New("invalid OP_QUERY")
+	}
+
+	document, _, err := readDocument(rest[8:])
+	if err != nil {
+		return err
+	}
+	if err := bson.Unmarshal(document, &r.command); err != nil {
+		return err
+	}
+
+	// The read preference wraps the command in $query
+	if query, ok := lookupKey(r.command, "$query"); ok {
+		if r.command, ok = query.(bson.D); !ok {
+			return errors.New("invalid OP_QUERY $query")
+		}
+	}
+
+	database, found := strings.CutSuffix(namespace, ".$cmd")
+	if !found {
+		return fmt.Errorf("queries on %s are %w", namespace, ErrNotSupported)
+	}
+	r.database = database
+
+	return nil
+}
+
+// writeReply answers the request with an OP_MSG, or an OP_REPLY for an OP_QUERY
+func writeReply(writer io.Writer, requestID int32, request *request, reply bson.D) error {
+	document, err := bson.Marshal(reply)
+	if err != nil {
+		return err
+	}
+
+	opCode := opMsg
+	if request.opCode == opQuery {
+		opCode = opReply
+	}
+
+	message := make([]byte, headerSize, headerSize+20+len(document))
+	binary.LittleEndian.PutUint32(message[4:], uint32(requestID))
+	binary.LittleEndian.PutUint32(message[8:], uint32(request.requestID))
+	binary.LittleEndian.PutUint32(message[12:], uint32(opCode))
+
+	if opCode == opMsg {
+		message = binary.LittleEndian.AppendUint32(message, 0)
+		message = append(message, 0)
+	} else {
+		// The response flags, cursor id, starting from and number returned
+		message = binary.LittleEndian.AppendUint32(message, 0)
+		message = binary.LittleEndian.AppendUint64(message, 0)
+		message = binary.LittleEndian.AppendUint32(message, 0)
+		message = binary.LittleEndian.AppendUint32(message, 1)
+	}
+	message = append(message, document...)
+	binary.LittleEndian.PutUint32(message[0:], uint32(len(message)))
+
+	_, err = writer.Write(message)
+
+	return err
+}
+
+func readDocument(data []byte) (bson.Raw, []byte, error) {
+	if len(data) < 5 {
+		return nil, nil, errors.New("invalid BSON document")
+	}
+
+	size := int(int32(binary.LittleEndian.Uint32(data)))
+	if size < 5 || size > len(data) {
+		return nil, nil, errors.New("invalid BSON document length")
+	}
+
+	return data[:size], data[size:], nil
+}
+
+func readCString(data []byte) (string, []byte, error) {
+	end := bytes.IndexByte(data, 0)
+	if end < 0 {
+		return "", nil, errors.New("invalid cstring")
+	}
+
+	return string(data[:end]), data[end+1:], nil
+}
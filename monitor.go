@@ -0,0 +1,66 @@
+package mongodb
+
+import (
+	"context"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/event"
+)
+
+// combineCommandMonitors calls the monitors in order, nil when there is none
+func combineCommandMonitors(monitors ...*event.CommandMonitor) *event.CommandMonitor {
+	if len(monitors) == 0 {
+		return nil
+	}
+	if len(monitors) == 1 {
+		return monitors[0]
+	}
+
+	return &event.CommandMonitor{
+		Started: func(ctx context.Context, started *event.CommandStartedEvent) {
+			for _, monitor := range monitors {
+				if monitor.Started != nil {
+					monitor.Started(ctx, started)
+				}
+			}
+		},
+		Succeeded: func(ctx context.Context, succeeded *event.CommandSucceededEvent) {
+			for _, monitor := range monitors {
+				if monitor.Succeeded != nil {
+					monitor.Succeeded(ctx, succeeded)
+				}
+			}
+		},
+		Failed: func(ctx context.Context, failed *event.CommandFailedEvent) {
+			for _, monitor := range monitors {
+				if monitor.Failed != nil {
+					monitor.Failed(ctx, failed)
+				}
+			}
+		},
+	}
+}
+
+// commandKey identifies a command, the request ids are unique per connection
+func commandKey(connectionID string, requestID int64) string {
+	return fmt.Sprintf("%s/%d", connectionID, requestID)
+}
+
+// commandCollection returns the collection a command runs on, the commands
+// on the database or the deployment have none
+func commandCollection(command bson.Raw) string {
+	elements, err := command.Elements()
+	if err != nil || len(elements) == 0 {
+		return ""
+	}
+
+	// getMore names the collection apart from the cursor
+	if elements[0].Key() == "getMore" {
+		collection, _ := command.Lookup("collection").StringValueOK()
+		return collection
+	}
+
+	collection, _ := elements[0].Value().StringValueOK()
+	return collection
+}
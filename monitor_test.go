@@ -0,0 +1,64 @@
+package mongodb
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/event"
+)
+
+type MonitorTestSuite struct {
+	suite.Suite
+}
+
+func TestMonitorTestSuite(t *testing.T) {
+	suite.Run(t, new(MonitorTestSuite))
+}
+
+func (s *MonitorTestSuite) TestCombineCommandMonitors() {
+	s.Nil(combineCommandMonitors())
+
+	var calls []string
+	first := &event.CommandMonitor{
+		Started: func(context.Context, *event.CommandStartedEvent) {
+			calls = append(calls, "first started")
+		},
+		Succeeded: func(context.Context, *event.CommandSucceededEvent) {
+			calls = append(calls, "first succeeded")
+		},
+	}
+	second := &event.CommandMonitor{
+		Started: func(context.Context, *event.CommandStartedEvent) {
+			calls = append(calls, "second started")
+		},
+		Failed: func(context.Context, *event.CommandFailedEvent) {
+			calls = append(calls, "second failed")
+		},
+	}
+	s.Same(first, combineCommandMonitors(first))
+
+	monitor := combineCommandMonitors(first, second)
+	monitor.Started(context.Background(), &event.CommandStartedEvent{})
+	monitor.Succeeded(context.Background(), &event.CommandSucceededEvent{})
+	monitor.Failed(context.Background(), &event.CommandFailedEvent{})
+	s.Equal([]string{"first started", "second started", "first succeeded", "second failed"}, calls)
+}
+
+func (s *MonitorTestSuite) TestCommandCollection() {
+	s.Equal("users", commandCollection(mustMarshal(bson.D{{Key: "find", Value: "users"}})))
+	s.Equal("users", commandCollection(mustMarshal(bson.D{{Key: "getMore", Value: int64(1)}, {Key: "collection", Value: "users"}})))
+	s.Empty(commandCollection(mustMarshal(bson.D{{Key: "ping", Value: 1}})))
+	s.Empty(commandCollection(mustMarshal(bson.D{{Key: "aggregate", Value: 1}})))
+	s.Empty(commandCollection(nil))
+}
+
+func mustMarshal(document interface{}) bson.Raw {
+	raw, err := bson.Marshal(document)
+	if err != nil {
+		panic(err)
+	}
+
+	return raw
+}
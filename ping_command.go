@@ -0,0 +1,88 @@
+package mongodb
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/goravel/framework/contracts/console"
+	"github.com/goravel/framework/contracts/console/command"
+
+	"github.com/portofolio-mager/goravel-mongodb/contracts"
+)
+
+type PingCommand struct {
+	client func(connection string) (contracts.Client, error)
+}
+
+func NewPingCommand(client func(connection string) (contracts.Client, error)) *PingCommand {
+	return &PingCommand{
+		client: client,
+	}
+}
+
+// Signature The name and signature of the console command.
+func (r *PingCommand) Signature() string {
+	return "mongodb:ping"
+}
+
+// Description The console command description.
+func (r *PingCommand) Description() string {
+	return "Ping the MongoDB deployment and display its latency and topology"
+}
+
+// Extend The console command extend.
+func (r *PingCommand) Extend() command.Extend {
+	return command.Extend{
+		Category: "mongodb",
+		Flags: []command.Flag{
+			&command.StringFlag{
+				Name:  "connection",
+				Value: Name,
+				Usage: "the MongoDB connection",
+			},
+		},
+	}
+}
+
+// Handle Execute the console command.
+func (r *PingCommand) Handle(ctx console.Context) error {
+	connection := ctx.Option("connection")
+	client, err := r.client(connection)
+	if err != nil {
+		ctx.Error(err.Error())
+		return nil
+	}
+
+	start := time.Now()
+	if err := client.Ping(); err != nil {
+		ctx.Error(fmt.Sprintf("Failed to ping the %s connection: %v", connection, err))
+		return nil
+	}
+	latency := time.Since(start)
+
+	deployment, err := topologyOf(client.Native())
+	if err != nil {
+		ctx.Error(err.Error())
+		return nil
+	}
+
+	ctx.NewLine()
+	ctx.TwoColumnDetail("<fg=green;op=bold>MongoDB</>", "")
+	ctx.TwoColumnDetail("Connection", connection)
+	ctx.TwoColumnDetail("Latency", latency.Round(time.Microsecond).String())
+	ctx.TwoColumnDetail("Topology", deployment.Kind)
+	if deployment.SetName != "" {
+		ctx.TwoColumnDetail("Replica Set", deployment.SetName)
+	}
+	if deployment.Primary != "" {
+		ctx.TwoColumnDetail("Primary", deployment.Primary)
+	}
+	if len(deployment.Hosts) > 0 {
+		ctx.TwoColumnDetail("Hosts", strings.Join(deployment.Hosts, ", "))
+	}
+	ctx.NewLine()
+	ctx.Success(fmt.Sprintf("The %s connection is reachable.", connection))
+
+	return nil
+}
@@ -0,0 +1,82 @@
+package mongodb
+
+import (
+	"errors"
+	"testing"
+
+	mocksconsole "github.com/goravel/framework/mocks/console"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/portofolio-mager/goravel-mongodb/contracts"
+	mockscontracts "github.com/portofolio-mager/goravel-mongodb/mocks"
+)
+
+type PingCommandTestSuite struct {
+	suite.Suite
+	mockContext *mocksconsole.Context
+	mockClient  *mockscontracts.Client
+	clientErr   error
+	pingCommand *PingCommand
+}
+
+func TestPingCommandTestSuite(t *testing.T) {
+	suite.Run(t, new(PingCommandTestSuite))
+}
+
+func (s *PingCommandTestSuite) SetupTest() {
+	s.mockContext = mocksconsole.NewContext(s.T())
+	s.mockClient = mockscontracts.NewClient(s.T())
+	s.clientErr = nil
+	s.pingCommand = NewPingCommand(func(connection string) (contracts.Client, error) {
+		s.Equal("mongodb", connection)
+		return s.mockClient, s.clientErr
+	})
+}
+
+func (s *PingCommandTestSuite) TestHandle() {
+	tests := []struct {
+		name  string
+		setup func()
+	}{
+		{
+			name: "Success",
+			setup: func() {
+				s.mockContext.EXPECT().Option("connection").Return("mongodb").Once()
+				s.mockClient.EXPECT().Ping().Return(nil).Once()
+				s.mockClient.EXPECT().Native().Return(nil).Once()
+
+				s.mockContext.EXPECT().NewLine().Twice()
+				s.mockContext.EXPECT().TwoColumnDetail("<fg=green;op=bold>MongoDB</>", "").Once()
+				s.mockContext.EXPECT().TwoColumnDetail("Connection", "mongodb").Once()
+				s.mockContext.EXPECT().TwoColumnDetail("Latency", mock.AnythingOfType("string")).Once()
+				s.mockContext.EXPECT().TwoColumnDetail("Topology", TopologyUnknown).Once()
+				s.mockContext.EXPECT().Success("The mongodb connection is reachable.").Once()
+			},
+		},
+		{
+			name: "Failed to resolve the client",
+			setup: func() {
+				s.clientErr = errors.New("please register mongodb service provider")
+				s.mockContext.EXPECT().Option("connection").Return("mongodb").Once()
+				s.mockContext.EXPECT().Error("please register mongodb service provider").Once()
+			},
+		},
+		{
+			name: "Failed to ping",
+			setup: func() {
+				s.mockContext.EXPECT().Option("connection").Return("mongodb").Once()
+				s.mockClient.EXPECT().Ping().Return(errors.New("server selection timeout")).Once()
+				s.mockContext.EXPECT().Error("Failed to ping the mongodb connection: server selection timeout").Once()
+			},
+		},
+	}
+
+	for _, test := range tests {
+		s.Run(test.name, func() {
+			s.SetupTest()
+			test.setup()
+			s.NoError(s.pingCommand.Handle(s.mockContext))
+		})
+	}
+}
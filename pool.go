@@ -0,0 +1,94 @@
+package mongodb
+
+import (
+	"sync/atomic"
+
+	"go.mongodb.org/mongo-driver/event"
+
+	"github.com/portofolio-mager/goravel-mongodb/contracts"
+)
+
+// The size of the pool when max_pool_size is not set, like the driver
+const defaultMaxPoolSize = 100
+
+// poolMonitor counts the connections of the pool of a client, and sends them
+// to the sink when there is one
+type poolMonitor struct {
+	open  atomic.Int64
+	inUse atomic.Int64
+	max   uint64
+
+	sink   contracts.MetricsSink
+	labels map[string]string
+}
+
+func newPoolMonitor(max uint64, connection string, sink contracts.MetricsSink) *poolMonitor {
+	if max == 0 {
+		max = defaultMaxPoolSize
+	}
+
+	return &poolMonitor{
+		max:    max,
+		sink:   sink,
+		labels: map[string]string{"connection": connection},
+	}
+}
+
+// monitor returns the driver monitor updating the counters
+func (p *poolMonitor) monitor() *event.PoolMonitor {
+	return &event.PoolMonitor{
+		Event: func(poolEvent *event.PoolEvent) {
+			switch poolEvent.Type {
+			case event.ConnectionCreated:
+				p.open.Add(1)
+				p.gauge(MetricPoolConnectionsOpen, 1)
+			case event.ConnectionClosed:
+				p.open.Add(-1)
+				p.gauge(MetricPoolConnectionsOpen, -1)
+			case event.GetSucceeded:
+				p.inUse.Add(1)
+				p.gauge(MetricPoolConnectionsCheckedOut, 1)
+				p.wait(poolEvent)
+			case event.ConnectionReturned:
+				p.inUse.Add(-1)
+				p.gauge(MetricPoolConnectionsCheckedOut, -1)
+			case event.GetFailed:
+				p.wait(poolEvent)
+				if p.sink != nil {
+					p.sink.AddCounter(MetricPoolCheckoutFailures, map[string]string{
+						"connection": p.labels["connection"],
+						"reason":     poolEvent.Reason,
+					}, 1)
+				}
+			}
+		},
+	}
+}
+
+func (p *poolMonitor) gauge(name string, delta float64) {
+	if p.sink != nil {
+		p.sink.AddGauge(name, p.labels, delta)
+	}
+}
+
+// wait records the time a check out waited for a connection
+func (p *poolMonitor) wait(poolEvent *event.PoolEvent) {
+	if p.sink != nil {
+		p.sink.ObserveHistogram(MetricPoolWaitSeconds, p.labels, poolEvent.Duration.Seconds())
+	}
+}
+
+// stats returns the current counters, a nil monitor has no connections
+func (p *poolMonitor) stats() contracts.PoolStats {
+	if p == nil {
+		return contracts.PoolStats{}
+	}
+
+	open, inUse := p.open.Load(), p.inUse.Load()
+	return contracts.PoolStats{
+		Open:  open,
+		InUse: inUse,
+		Idle:  max(open-inUse, 0),
+		Max:   p.max,
+	}
+}
@@ -1,13 +1,15 @@
 package mongodb
 
 import (
+	"strings"
+
 	"github.com/goravel/framework/contracts/database/driver"
 )
 
 var _ driver.Processor = &Processor{}
 
-// Processor is a minimal implementation to satisfy Goravel's driver interface
-// MongoDB operations don't use SQL-based column/index processing
+// Processor converts the rows of the schema commands to Goravel's types,
+// MongoDB has no columns, foreign keys or types
 type Processor struct {
 }
 
@@ -25,9 +27,21 @@ func (r Processor) ProcessForeignKeys(dbForeignKeys []driver.DBForeignKey) []dri
 	return nil
 }
 
+// ProcessIndexes converts the rows the listIndexes command of the Grammar
+// returns, the columns of an index are joined with commas
 func (r Processor) ProcessIndexes(dbIndexes []driver.DBIndex) []driver.Index {
-	// MongoDB index management is handled through native MongoDB operations
-	return nil
+	var indexes []driver.Index
+	for _, dbIndex := range dbIndexes {
+		indexes = append(indexes, driver.Index{
+			Columns: strings.Split(dbIndex.Columns, ","),
+			Name:    dbIndex.Name,
+			Type:    strings.ToLower(dbIndex.Type),
+			Primary: dbIndex.Primary,
+			Unique:  dbIndex.Unique,
+		})
+	}
+
+	return indexes
 }
 
 func (r Processor) ProcessTypes(types []driver.Type) []driver.Type {
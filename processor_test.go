@@ -42,13 +42,18 @@ func (s *ProcessorTestSuite) TestProcessForeignKeys() {
 }
 
 func (s *ProcessorTestSuite) TestProcessIndexes() {
-	// MongoDB index management is handled through native MongoDB operations
 	dbIndexes := []driver.DBIndex{
-		{Name: "idx_name"},
+		{Name: "_id_", Columns: "_id", Type: "btree", Primary: true, Unique: true},
+		{Name: "users_name_age_index", Columns: "name,age", Type: "btree"},
+		{Name: "users_bio_fulltext", Columns: "_fts,_ftsx", Type: "TEXT"},
 	}
 
-	result := s.processor.ProcessIndexes(dbIndexes)
-	s.Nil(result)
+	s.Equal([]driver.Index{
+		{Name: "_id_", Columns: []string{"_id"}, Type: "btree", Primary: true, Unique: true},
+		{Name: "users_name_age_index", Columns: []string{"name", "age"}, Type: "btree"},
+		{Name: "users_bio_fulltext", Columns: []string{"_fts", "_ftsx"}, Type: "text"},
+	}, s.processor.ProcessIndexes(dbIndexes))
+	s.Nil(s.processor.ProcessIndexes(nil))
 }
 
 func (s *ProcessorTestSuite) TestProcessTypes() {
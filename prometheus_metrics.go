@@ -0,0 +1,199 @@
+package mongodb
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"math"
+	nethttp "net/http"
+	"sort"
+	"strconv"
+	"sync"
+
+	"github.com/goravel/framework/contracts/http"
+
+	"github.com/portofolio-mager/goravel-mongodb/contracts"
+)
+
+var _ contracts.MetricsSink = &PrometheusMetrics{}
+
+// DefaultBuckets are the upper bounds in seconds of the histogram buckets of
+// PrometheusMetrics, from 1ms to 10s
+var DefaultBuckets = []float64{.001, .005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10}
+
+// The content type of the Prometheus text exposition format
+const prometheusContentType = "text/plain; version=0.0.4; charset=utf-8"
+
+// PrometheusMetrics aggregates the metrics and writes them in the Prometheus
+// text exposition format, to be scraped without the Prometheus client:
+//
+//	facades.Route().Get("/metrics", metrics.Handle)
+type PrometheusMetrics struct {
+	mu      sync.Mutex
+	buckets []float64
+	// families are the metrics by name, their series by labels
+	families map[string]*family
+}
+
+type family struct {
+	kind   string
+	series map[string]*series
+}
+
+type series struct {
+	labels map[string]string
+	value  float64
+	// counts are the observations of each bucket, not cumulated
+	counts []uint64
+	count  uint64
+}
+
+// NewPrometheusMetrics counts the histogram observations in the buckets,
+// DefaultBuckets when none are given
+func NewPrometheusMetrics(buckets ...float64) *PrometheusMetrics {
+	if len(buckets) == 0 {
+		buckets = DefaultBuckets
+	}
+	buckets = append([]float64(nil), buckets...)
+	sort.Float64s(buckets)
+
+	return &PrometheusMetrics{
+		buckets:  buckets,
+		families: make(map[string]*family),
+	}
+}
+
+func (r *PrometheusMetrics) AddCounter(name string, labels map[string]string, delta float64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if series := r.series(name, "counter", labels); series != nil {
+		series.value += delta
+	}
+}
+
+func (r *PrometheusMetrics) AddGauge(name string, labels map[string]string, delta float64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if series := r.series(name, "gauge", labels); series != nil {
+		series.value += delta
+	}
+}
+
+func (r *PrometheusMetrics) ObserveHistogram(name string, labels map[string]string, value float64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	series := r.series(name, "histogram", labels)
+	if series == nil {
+		return
+	}
+	if bucket := sort.SearchFloat64s(r.buckets, value); bucket < len(r.buckets) {
+		series.counts[bucket]++
+	}
+	series.value += value
+	series.count++
+}
+
+// WriteTo writes the metrics in the text exposition format
+func (r *PrometheusMetrics) WriteTo(w io.Writer) (int64, error) {
+	written, err := w.Write(r.text())
+	return int64(written), err
+}
+
+// Handle responds with the metrics, for the Prometheus scrapes
+func (r *PrometheusMetrics) Handle(ctx http.Context) http.Response {
+	return ctx.Response().Data(nethttp.StatusOK, prometheusContentType, r.text())
+}
+
+// text formats the metrics in the text exposition format, sorted by name and
+// labels
+func (r *PrometheusMetrics) text() []byte {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var buffer bytes.Buffer
+	for _, name := range sortedKeys(r.families) {
+		family := r.families[name]
+		if help, ok := metricHelp[name]; ok {
+			fmt.Fprintf(&buffer, "# HELP %s %s\n", name, help)
+		}
+		fmt.Fprintf(&buffer, "# TYPE %s %s\n", name, family.kind)
+
+		for _, key := range sortedKeys(family.series) {
+			series := family.series[key]
+			if family.kind != "histogram" {
+				fmt.Fprintf(&buffer, "%s%s %s\n", name, key, formatFloat(series.value))
+				continue
+			}
+
+			var cumulative uint64
+			for i, bound := range r.buckets {
+				cumulative += series.counts[i]
+				fmt.Fprintf(&buffer, "%s_bucket%s %d\n", name, labelString(withLabel(series.labels, "le", formatFloat(bound))), cumulative)
+			}
+			fmt.Fprintf(&buffer, "%s_bucket%s %d\n", name, labelString(withLabel(series.labels, "le", "+Inf")), series.count)
+			fmt.Fprintf(&buffer, "%s_sum%s %s\n", name, key, formatFloat(series.value))
+			fmt.Fprintf(&buffer, "%s_count%s %d\n", name, key, series.count)
+		}
+	}
+
+	return buffer.Bytes()
+}
+
+// series returns the series of a metric, creating it. A name keeps the kind
+// it was first used with, nil is returned for the values of another kind.
+func (r *PrometheusMetrics) series(name, kind string, labels map[string]string) *series {
+	metric, ok := r.families[name]
+	if !ok {
+		metric = &family{kind: kind, series: make(map[string]*series)}
+		r.families[name] = metric
+	}
+	if metric.kind != kind {
+		return nil
+	}
+
+	key := labelString(labels)
+	current, ok := metric.series[key]
+	if !ok {
+		current = &series{labels: withLabel(labels, "", "")}
+		if metric.kind == "histogram" {
+			current.counts = make([]uint64, len(r.buckets))
+		}
+		metric.series[key] = current
+	}
+
+	return current
+}
+
+// withLabel copies the labels with another one, an empty name adds none
+func withLabel(labels map[string]string, name, value string) map[string]string {
+	copied := make(map[string]string, len(labels)+1)
+	for key, value := range labels {
+		copied[key] = value
+	}
+	if name != "" {
+		copied[name] = value
+	}
+
+	return copied
+}
+
+func formatFloat(value float64) string {
+	if math.IsInf(value, 1) {
+		return "+Inf"
+	}
+
+	return strconv.FormatFloat(value, 'g', -1, 64)
+}
+
+func sortedKeys[T any](values map[string]T) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
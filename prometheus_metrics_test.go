@@ -0,0 +1,93 @@
+package mongodb
+
+import (
+	"bytes"
+	nethttp "net/http"
+	"testing"
+
+	mockshttp "github.com/goravel/framework/mocks/http"
+	"github.com/stretchr/testify/suite"
+)
+
+type PrometheusMetricsTestSuite struct {
+	suite.Suite
+}
+
+func TestPrometheusMetricsTestSuite(t *testing.T) {
+	suite.Run(t, new(PrometheusMetricsTestSuite))
+}
+
+func (s *PrometheusMetricsTestSuite) TestWriteTo() {
+	metrics := NewPrometheusMetrics(1, 0.1)
+	labels := map[string]string{"connection": "mongodb", "command": "find", "collection": "users"}
+	metrics.ObserveHistogram(MetricCommandDurationSeconds, labels, 0.05)
+	metrics.ObserveHistogram(MetricCommandDurationSeconds, labels, 0.1)
+	metrics.ObserveHistogram(MetricCommandDurationSeconds, labels, 2)
+	metrics.AddCounter(MetricCommandFailures, labels, 1)
+	metrics.AddGauge(MetricPoolConnectionsCheckedOut, map[string]string{"connection": "mongodb"}, 2)
+	metrics.AddGauge(MetricPoolConnectionsCheckedOut, map[string]string{"connection": "mongodb"}, -1)
+	metrics.AddCounter("custom_total", nil, 1.5)
+
+	var buffer bytes.Buffer
+	written, err := metrics.WriteTo(&buffer)
+	s.NoError(err)
+	s.Equal(int64(buffer.Len()), written)
+	s.Equal(`# TYPE custom_total counter
+custom_total 1.5
+# HELP mongodb_command_duration_seconds The duration of the commands.
+# TYPE mongodb_command_duration_seconds histogram
+mongodb_command_duration_seconds_bucket{collection="users",command="find",connection="mongodb",le="0.1"} 2
+mongodb_command_duration_seconds_bucket{collection="users",command="find",connection="mongodb",le="1"} 2
+mongodb_command_duration_seconds_bucket{collection="users",command="find",connection="mongodb",le="+Inf"} 3
+mongodb_command_duration_seconds_sum{collection="users",command="find",connection="mongodb"} 2.15
+mongodb_command_duration_seconds_count{collection="users",command="find",connection="mongodb"} 3
+# HELP mongodb_command_failures_total The commands that failed.
+# TYPE mongodb_command_failures_total counter
+mongodb_command_failures_total{collection="users",command="find",connection="mongodb"} 1
+# HELP mongodb_pool_connections_checked_out The connections checked out of the pool.
+# TYPE mongodb_pool_connections_checked_out gauge
+mongodb_pool_connections_checked_out{connection="mongodb"} 1
+`, buffer.String())
+}
+
+func (s *PrometheusMetricsTestSuite) TestDefaultBuckets() {
+	metrics := NewPrometheusMetrics()
+	metrics.ObserveHistogram(MetricPoolWaitSeconds, nil, 0.003)
+
+	var buffer bytes.Buffer
+	_, err := metrics.WriteTo(&buffer)
+	s.NoError(err)
+	s.Contains(buffer.String(), "mongodb_pool_wait_seconds_bucket{le=\"0.001\"} 0\nmongodb_pool_wait_seconds_bucket{le=\"0.005\"} 1\n")
+	s.Contains(buffer.String(), "mongodb_pool_wait_seconds_bucket{le=\"10\"} 1\nmongodb_pool_wait_seconds_bucket{le=\"+Inf\"} 1\n")
+}
+
+func (s *PrometheusMetricsTestSuite) TestMixedKinds() {
+	metrics := NewPrometheusMetrics(1)
+	labels := map[string]string{"connection": "mongodb"}
+
+	// A name keeps its first kind, the values of the others are ignored
+	metrics.AddCounter("custom", labels, 1)
+	metrics.ObserveHistogram("custom", labels, 0.5)
+	metrics.ObserveHistogram("custom", map[string]string{"connection": "analytics"}, 0.5)
+	metrics.AddGauge("custom", labels, 2)
+
+	var buffer bytes.Buffer
+	_, err := metrics.WriteTo(&buffer)
+	s.NoError(err)
+	s.Equal(`# TYPE custom counter
+custom{connection="mongodb"} 1
+`, buffer.String())
+}
+
+func (s *PrometheusMetricsTestSuite) TestHandle() {
+	metrics := NewPrometheusMetrics()
+	metrics.AddCounter("custom_total", nil, 1)
+
+	mockContext := mockshttp.NewContext(s.T())
+	mockResponse := mockshttp.NewContextResponse(s.T())
+	mockAbortable := mockshttp.NewAbortableResponse(s.T())
+	mockContext.EXPECT().Response().Return(mockResponse).Once()
+	mockResponse.EXPECT().Data(nethttp.StatusOK, "text/plain; version=0.0.4; charset=utf-8", []byte("# TYPE custom_total counter\ncustom_total 1\n")).Return(mockAbortable).Once()
+
+	s.Equal(mockAbortable, metrics.Handle(mockContext))
+}
@@ -19,6 +19,14 @@ type QueryBuilder struct {
 	filter     bson.M
 	options    *options.FindOptions
 	projection bson.M
+	trashed    trashedScope
+	// withoutScopes holds the disabled global scopes, withoutAll disables every one
+	withoutScopes map[string]bool
+	withoutAll    bool
+	with          []string
+	// ctx is the parent of the contexts of the operations, the one of the
+	// collection unless WithContext is called
+	ctx context.Context
 }
 
 func NewQueryBuilder(collection *Collection) *QueryBuilder {
@@ -27,6 +35,7 @@ func NewQueryBuilder(collection *Collection) *QueryBuilder {
 		filter:     bson.M{},
 		options:    options.Find(),
 		projection: bson.M{},
+		ctx:        collection.ctx,
 	}
 }
 
@@ -142,22 +151,84 @@ func (q *QueryBuilder) Select(fields ...string) contracts.QueryBuilder {
 	return q
 }
 
+// With eager loads the relations defined by the model, see contracts.ModelWithRelations
+func (q *QueryBuilder) With(relations ...string) contracts.QueryBuilder {
+	q.with = append(q.with, relations...)
+	return q
+}
+
+// Scopes applies local scopes to the query
+func (q *QueryBuilder) Scopes(scopes ...func(contracts.QueryBuilder)) contracts.QueryBuilder {
+	for _, scope := range scopes {
+		scope(q)
+	}
+	return q
+}
+
+// WithoutGlobalScope disables the given global scopes, or all of them when
+// no name is given
+func (q *QueryBuilder) WithoutGlobalScope(names ...string) contracts.QueryBuilder {
+	if len(names) == 0 {
+		q.withoutAll = true
+		return q
+	}
+
+	if q.withoutScopes == nil {
+		q.withoutScopes = make(map[string]bool)
+	}
+	for _, name := range names {
+		q.withoutScopes[name] = true
+	}
+	return q
+}
+
+// WithContext runs the operations of the query with ctx
+func (q *QueryBuilder) WithContext(ctx context.Context) contracts.QueryBuilder {
+	q.ctx = ctx
+	return q
+}
+
+// Soft delete modifiers
+func (q *QueryBuilder) WithTrashed() contracts.QueryBuilder {
+	q.trashed = withTrashed
+	return q
+}
+
+func (q *QueryBuilder) OnlyTrashed() contracts.QueryBuilder {
+	q.trashed = onlyTrashed
+	return q
+}
+
 // Result methods
 func (q *QueryBuilder) Find(results interface{}) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	ctx, cancel := operationContext(q.ctx, 30*time.Second)
 	defer cancel()
 
-	cursor, err := q.collection.collection.Find(ctx, q.filter, q.options)
+	cursor, err := q.collection.collection.Find(ctx, q.buildFilter(), q.options)
 	if err != nil {
 		return err
 	}
 	defer cursor.Close(ctx)
 
-	return cursor.All(ctx, results)
+	if len(q.with) > 0 {
+		var documents []bson.Raw
+		if err := cursor.All(ctx, &documents); err != nil {
+			return err
+		}
+		if err := decodeWithRelations(ctx, q.collection.collection.Database(), documents, results, q.with); err != nil {
+			return err
+		}
+	} else if err := cursor.All(ctx, results); err != nil {
+		return err
+	}
+
+	return eachModel(results, func(model any) error {
+		return q.collection.dispatch(ctx, contracts.EventRetrieved, model, nil, nil)
+	})
 }
 
 func (q *QueryBuilder) First(result interface{}) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	ctx, cancel := operationContext(q.ctx, 30*time.Second)
 	defer cancel()
 
 	findOneOpts := options.FindOne()
@@ -171,11 +242,24 @@ func (q *QueryBuilder) First(result interface{}) error {
 		findOneOpts.SetSkip(*q.options.Skip)
 	}
 
-	return q.collection.collection.FindOne(ctx, q.filter, findOneOpts).Decode(result)
+	single := q.collection.collection.FindOne(ctx, q.buildFilter(), findOneOpts)
+	if len(q.with) > 0 {
+		document, err := single.Raw()
+		if err != nil {
+			return err
+		}
+		if err := decodeWithRelations(ctx, q.collection.collection.Database(), []bson.Raw{document}, result, q.with); err != nil {
+			return err
+		}
+	} else if err := single.Decode(result); err != nil {
+		return err
+	}
+
+	return q.collection.dispatch(ctx, contracts.EventRetrieved, result, nil, nil)
 }
 
 func (q *QueryBuilder) Count() (int64, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	ctx, cancel := operationContext(q.ctx, 30*time.Second)
 	defer cancel()
 
 	countOpts := options.Count()
@@ -186,10 +270,198 @@ func (q *QueryBuilder) Count() (int64, error) {
 		countOpts.SetLimit(*q.options.Limit)
 	}
 
-	count, err := q.collection.collection.CountDocuments(ctx, q.filter, countOpts)
+	count, err := q.collection.collection.CountDocuments(ctx, q.buildFilter(), countOpts)
 	if err != nil {
 		return 0, fmt.Errorf("failed to count documents: %w", err)
 	}
 
 	return count, nil
 }
+
+// Write methods
+func (q *QueryBuilder) Update(update interface{}) (int64, error) {
+	ctx, cancel := operationContext(q.ctx, 30*time.Second)
+	defer cancel()
+
+	filter := q.buildFilter()
+	if err := q.collection.dispatch(ctx, contracts.EventUpdating, nil, filter, update); err != nil {
+		return 0, err
+	}
+
+	result, err := q.collection.collection.UpdateMany(ctx, filter, update)
+	if err != nil {
+		return 0, fmt.Errorf("failed to update documents: %w", err)
+	}
+
+	return result.ModifiedCount, q.collection.dispatch(ctx, contracts.EventUpdated, nil, filter, update)
+}
+
+// Delete soft deletes the matching documents when the collection uses soft
+// deletes, otherwise the documents are removed
+func (q *QueryBuilder) Delete() (int64, error) {
+	if !q.collection.usesSoftDeletes() {
+		return q.ForceDelete()
+	}
+
+	ctx, cancel := operationContext(q.ctx, 30*time.Second)
+	defer cancel()
+
+	filter, err := q.limited(ctx, q.filterFor(withoutTrashed))
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete documents: %w", err)
+	}
+	if err := q.collection.dispatch(ctx, contracts.EventDeleting, nil, filter, nil); err != nil {
+		return 0, err
+	}
+
+	update := bson.M{"$set": bson.M{DeletedAtField: time.Now()}}
+	result, err := q.collection.collection.UpdateMany(ctx, filter, update)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete documents: %w", err)
+	}
+
+	return result.ModifiedCount, q.collection.dispatch(ctx, contracts.EventDeleted, nil, filter, nil)
+}
+
+// ForceDelete removes the matching documents, including soft deleted ones
+func (q *QueryBuilder) ForceDelete() (int64, error) {
+	ctx, cancel := operationContext(q.ctx, 30*time.Second)
+	defer cancel()
+
+	trashed := q.trashed
+	if trashed == withoutTrashed {
+		trashed = withTrashed
+	}
+
+	filter, err := q.limited(ctx, q.filterFor(trashed))
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete documents: %w", err)
+	}
+	if err := q.collection.dispatch(ctx, contracts.EventDeleting, nil, filter, nil); err != nil {
+		return 0, err
+	}
+
+	result, err := q.collection.collection.DeleteMany(ctx, filter)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete documents: %w", err)
+	}
+
+	return result.DeletedCount, q.collection.dispatch(ctx, contracts.EventDeleted, nil, filter, nil)
+}
+
+// Restore clears the soft delete timestamp of the matching documents
+func (q *QueryBuilder) Restore() (int64, error) {
+	ctx, cancel := operationContext(q.ctx, 30*time.Second)
+	defer cancel()
+
+	filter, err := q.limited(ctx, q.filterFor(onlyTrashed))
+	if err != nil {
+		return 0, fmt.Errorf("failed to restore documents: %w", err)
+	}
+	update := bson.M{"$unset": bson.M{DeletedAtField: ""}}
+	if err := q.collection.dispatch(ctx, contracts.EventUpdating, nil, filter, update); err != nil {
+		return 0, err
+	}
+
+	result, err := q.collection.collection.UpdateMany(ctx, filter, update)
+	if err != nil {
+		return 0, fmt.Errorf("failed to restore documents: %w", err)
+	}
+
+	return result.ModifiedCount, q.collection.dispatch(ctx, contracts.EventUpdated, nil, filter, update)
+}
+
+// limited restricts the filter of a write to the documents selected by the
+// sort, skip and limit of the query, as MongoDB cannot limit the updates and
+// deletes of many documents
+func (q *QueryBuilder) limited(ctx context.Context, filter bson.M) (bson.M, error) {
+	if q.options.Skip == nil && q.options.Limit == nil {
+		return filter, nil
+	}
+
+	findOpts := options.Find().SetProjection(bson.M{"_id": 1})
+	findOpts.Sort = q.options.Sort
+	findOpts.Skip = q.options.Skip
+	findOpts.Limit = q.options.Limit
+
+	cursor, err := q.collection.collection.Find(ctx, filter, findOpts)
+	if err != nil {
+		return nil, err
+	}
+
+	var documents []struct {
+		ID interface{} `bson:"_id"`
+	}
+	if err := cursor.All(ctx, &documents); err != nil {
+		return nil, err
+	}
+
+	ids := make(bson.A, len(documents))
+	for i, document := range documents {
+		ids[i] = document.ID
+	}
+
+	return mergeFilter(filter, bson.M{"_id": bson.M{"$in": ids}}), nil
+}
+
+// buildFilter returns the filter sent to MongoDB for read operations
+func (q *QueryBuilder) buildFilter() bson.M {
+	return q.filterFor(q.trashed)
+}
+
+func (q *QueryBuilder) filterFor(trashed trashedScope) bson.M {
+	filter := q.filter
+	for _, scope := range q.globalScopes() {
+		filter = mergeFilter(filter, scope)
+	}
+	if trashed != withoutTrashed || q.collection.usesSoftDeletes() {
+		filter = mergeFilter(filter, trashed.filter())
+	}
+
+	return filter
+}
+
+// globalScopes returns the filters of the enabled global scopes, each scope
+// runs on a builder of its own so its modifiers never change the query
+func (q *QueryBuilder) globalScopes() []bson.M {
+	if q.withoutAll {
+		return nil
+	}
+
+	var filters []bson.M
+	for _, scope := range q.collection.scopes.scopesOf(q.collection.namespace()) {
+		if q.withoutScopes[scope.name] {
+			continue
+		}
+
+		scoped := NewQueryBuilder(q.collection)
+		scope.scope(scoped)
+		filters = append(filters, scoped.filter)
+	}
+
+	return filters
+}
+
+// mergeFilter adds the condition to the filter, falling back to $and when
+// both constrain the same field
+func mergeFilter(filter bson.M, condition bson.M) bson.M {
+	if len(condition) == 0 {
+		return filter
+	}
+	if len(filter) == 0 {
+		return condition
+	}
+
+	merged := make(bson.M, len(filter)+len(condition))
+	for key, value := range filter {
+		merged[key] = value
+	}
+	for key, value := range condition {
+		if _, exists := merged[key]; exists {
+			return bson.M{"$and": bson.A{filter, condition}}
+		}
+		merged[key] = value
+	}
+
+	return merged
+}
@@ -0,0 +1,169 @@
+package mongodb
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/portofolio-mager/goravel-mongodb/contracts"
+)
+
+type QueryBuilderTestSuite struct {
+	suite.Suite
+	client     *mongo.Client
+	collection *Collection
+}
+
+func TestQueryBuilderTestSuite(t *testing.T) {
+	suite.Run(t, new(QueryBuilderTestSuite))
+}
+
+func (s *QueryBuilderTestSuite) SetupTest() {
+	// The client connects lazily, the filters are built without a server
+	client, err := mongo.Connect(context.Background(), options.Client().ApplyURI("mongodb://localhost:27017"))
+	s.Require().NoError(err)
+	s.client = client
+	s.collection = NewDatabase(client, nil, "goravel").Collection("users").(*Collection)
+}
+
+func (s *QueryBuilderTestSuite) TearDownTest() {
+	s.NoError(s.client.Disconnect(context.Background()))
+}
+
+func (s *QueryBuilderTestSuite) TestBuildFilter() {
+	query := NewQueryBuilder(s.collection)
+	query.Where("name", "goravel")
+	s.Equal(bson.M{"name": "goravel"}, query.buildFilter())
+
+	query.OnlyTrashed()
+	s.Equal(bson.M{"name": "goravel", DeletedAtField: bson.M{"$ne": nil}}, query.buildFilter())
+}
+
+func (s *QueryBuilderTestSuite) TestBuildFilter_SoftDeletes() {
+	s.collection.WithSoftDeletes()
+
+	query := NewQueryBuilder(s.collection)
+	query.Where("name", "goravel")
+	s.Equal(bson.M{"name": "goravel", DeletedAtField: nil}, query.buildFilter())
+
+	query.WithTrashed()
+	s.Equal(bson.M{"name": "goravel"}, query.buildFilter())
+
+	query.OnlyTrashed()
+	s.Equal(bson.M{"name": "goravel", DeletedAtField: bson.M{"$ne": nil}}, query.buildFilter())
+}
+
+func (s *QueryBuilderTestSuite) TestBuildFilter_SoftDeletesShared() {
+	database := NewDatabase(s.client, nil, "goravel")
+	database.Collection("users").WithSoftDeletes()
+
+	// Every instance of the collection uses soft deletes, the other collections do not
+	s.Equal(bson.M{DeletedAtField: nil}, NewQueryBuilder(database.Collection("users").(*Collection)).buildFilter())
+	s.Equal(bson.M{}, NewQueryBuilder(database.Collection("posts").(*Collection)).buildFilter())
+	s.Equal(bson.M{}, NewQueryBuilder(s.collection).buildFilter())
+}
+
+func (s *QueryBuilderTestSuite) TestBuildFilter_SoftDeletesConflict() {
+	s.collection.WithSoftDeletes()
+
+	query := NewQueryBuilder(s.collection)
+	query.WhereExists(DeletedAtField)
+	s.Equal(bson.M{"$and": bson.A{
+		bson.M{DeletedAtField: bson.M{"$exists": true}},
+		bson.M{DeletedAtField: nil},
+	}}, query.buildFilter())
+}
+
+func (s *QueryBuilderTestSuite) TestBuildFilter_GlobalScopes() {
+	s.collection.WithScope("tenant", func(query contracts.QueryBuilder) {
+		query.Where("tenant_id", 1)
+	}).WithScope("active", func(query contracts.QueryBuilder) {
+		query.Where("status", "active")
+	})
+
+	query := NewQueryBuilder(s.collection)
+	query.Where("name", "goravel")
+	s.Equal(bson.M{"name": "goravel", "tenant_id": 1, "status": "active"}, query.buildFilter())
+
+	// The scope with the same name is replaced
+	s.collection.WithScope("tenant", func(query contracts.QueryBuilder) {
+		query.Where("tenant_id", 2)
+	})
+	s.Equal(bson.M{"name": "goravel", "tenant_id": 2, "status": "active"}, query.buildFilter())
+
+	query.WithoutGlobalScope("active")
+	s.Equal(bson.M{"name": "goravel", "tenant_id": 2}, query.buildFilter())
+
+	query.WithoutGlobalScope()
+	s.Equal(bson.M{"name": "goravel"}, query.buildFilter())
+}
+
+func (s *QueryBuilderTestSuite) TestBuildFilter_GlobalScopesConflict() {
+	s.collection.WithScope("tenant", func(query contracts.QueryBuilder) {
+		query.Where("tenant_id", 1)
+	})
+
+	query := NewQueryBuilder(s.collection)
+	query.Where("tenant_id", 2)
+	s.Equal(bson.M{"$and": bson.A{
+		bson.M{"tenant_id": 2},
+		bson.M{"tenant_id": 1},
+	}}, query.buildFilter())
+}
+
+func (s *QueryBuilderTestSuite) TestBuildFilter_GlobalScopesModifiers() {
+	s.collection.WithScope("tenant", func(query contracts.QueryBuilder) {
+		query.Where("tenant_id", 1).Limit(1).Select("name").WithTrashed()
+	})
+	s.collection.WithSoftDeletes()
+
+	// Only the conditions of the scope are applied
+	query := NewQueryBuilder(s.collection)
+	query.Skip(5)
+	s.Equal(bson.M{"tenant_id": 1, DeletedAtField: nil}, query.buildFilter())
+	s.Nil(query.options.Limit)
+	s.Nil(query.options.Projection)
+	s.Empty(query.projection)
+	s.Equal(int64(5), *query.options.Skip)
+}
+
+func (s *QueryBuilderTestSuite) TestWithContext() {
+	type key struct{}
+	ctx := context.WithValue(context.Background(), key{}, "request")
+
+	// The query builders use the context of their collection, or their own
+	collection := s.collection.WithContext(ctx).(*Collection)
+	s.Equal(ctx, NewQueryBuilder(collection).ctx)
+	s.Nil(NewQueryBuilder(s.collection).ctx)
+	s.Equal(ctx, NewQueryBuilder(s.collection).WithContext(ctx).(*QueryBuilder).ctx)
+
+	database := NewDatabase(s.client, nil, "goravel").WithContext(ctx)
+	s.Equal(ctx, database.Collection("users").(*Collection).ctx)
+}
+
+func (s *QueryBuilderTestSuite) TestScopes() {
+	active := func(query contracts.QueryBuilder) {
+		query.Where("status", "active")
+	}
+	adult := func(query contracts.QueryBuilder) {
+		query.WhereGte("age", 18)
+	}
+
+	query := NewQueryBuilder(s.collection)
+	query.Scopes(active, adult)
+	s.Equal(bson.M{"status": "active", "age": bson.M{"$gte": 18}}, query.buildFilter())
+}
+
+func (s *QueryBuilderTestSuite) TestSoftDeletesTrashed() {
+	var model SoftDeletes
+	s.False(model.Trashed())
+
+	now := time.Now()
+	model.DeletedAt = &now
+	s.True(model.Trashed())
+}
@@ -0,0 +1,273 @@
+package mongodb
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	"github.com/portofolio-mager/goravel-mongodb/contracts"
+)
+
+// HasOne defines a relation to a single document whose foreignKey stores the
+// parent localKey, localKey defaults to _id
+func HasOne(collection, foreignKey string, localKey ...string) contracts.Relation {
+	return contracts.Relation{
+		Type:       contracts.RelationHasOne,
+		Collection: collection,
+		LocalKey:   keyOrID(localKey),
+		ForeignKey: foreignKey,
+	}
+}
+
+// HasMany defines a relation to the documents whose foreignKey stores the
+// parent localKey, localKey defaults to _id
+func HasMany(collection, foreignKey string, localKey ...string) contracts.Relation {
+	return contracts.Relation{
+		Type:       contracts.RelationHasMany,
+		Collection: collection,
+		LocalKey:   keyOrID(localKey),
+		ForeignKey: foreignKey,
+	}
+}
+
+// BelongsTo defines a relation to the document whose ownerKey is stored in the
+// parent foreignKey, ownerKey defaults to _id
+func BelongsTo(collection, foreignKey string, ownerKey ...string) contracts.Relation {
+	return contracts.Relation{
+		Type:       contracts.RelationBelongsTo,
+		Collection: collection,
+		LocalKey:   foreignKey,
+		ForeignKey: keyOrID(ownerKey),
+	}
+}
+
+// ManyToMany defines a relation to the documents whose foreignKey is contained
+// in the parent localKey array, foreignKey defaults to _id
+func ManyToMany(collection, localKey string, foreignKey ...string) contracts.Relation {
+	return contracts.Relation{
+		Type:       contracts.RelationManyToMany,
+		Collection: collection,
+		LocalKey:   localKey,
+		ForeignKey: keyOrID(foreignKey),
+	}
+}
+
+func keyOrID(key []string) string {
+	if len(key) > 0 && key[0] != "" {
+		return key[0]
+	}
+
+	return "_id"
+}
+
+// decodeWithRelations decodes the documents into results, a pointer to a slice
+// or to a single model, then loads the relations with one $in query each
+func decodeWithRelations(ctx context.Context, database *mongo.Database, documents []bson.Raw, results any, names []string) error {
+	value := reflect.ValueOf(results)
+	if value.Kind() != reflect.Ptr || value.IsNil() {
+		return fmt.Errorf("results argument must be a pointer, got %T", results)
+	}
+
+	var models []reflect.Value
+	if value.Elem().Kind() == reflect.Slice {
+		slice := reflect.MakeSlice(value.Elem().Type(), len(documents), len(documents))
+		for i, document := range documents {
+			model := newModel(slice.Index(i))
+			if err := bson.Unmarshal(document, model.Interface()); err != nil {
+				return err
+			}
+			models = append(models, model)
+		}
+		value.Elem().Set(slice)
+	} else if len(documents) > 0 {
+		if err := bson.Unmarshal(documents[0], results); err != nil {
+			return err
+		}
+		models = append(models, value)
+	}
+
+	if len(models) == 0 {
+		return nil
+	}
+
+	definer, ok := models[0].Interface().(contracts.ModelWithRelations)
+	if !ok {
+		return fmt.Errorf("%s does not define relations", models[0].Type())
+	}
+
+	relations := definer.Relations()
+	for _, name := range names {
+		relation, ok := relations[name]
+		if !ok {
+			return fmt.Errorf("relation %s is not defined on %s", name, models[0].Type())
+		}
+		if err := loadRelation(ctx, database, documents, models, name, relation); err != nil {
+			return fmt.Errorf("failed to load relation %s: %w", name, err)
+		}
+	}
+
+	return nil
+}
+
+func loadRelation(ctx context.Context, database *mongo.Database, documents []bson.Raw, models []reflect.Value, name string, relation contracts.Relation) error {
+	// Collect the distinct local keys of every parent document
+	localKeys := make([][]bson.RawValue, len(documents))
+	seen := make(map[string]bool)
+	var values bson.A
+	for i, document := range documents {
+		localKeys[i] = lookupKeys(document, relation)
+		for _, key := range localKeys[i] {
+			if id := relationKey(key); !seen[id] {
+				seen[id] = true
+				values = append(values, key)
+			}
+		}
+	}
+
+	related := make(map[string][]bson.Raw)
+	if len(values) > 0 {
+		cursor, err := database.Collection(relation.Collection).Find(ctx, bson.M{relation.ForeignKey: bson.M{"$in": values}})
+		if err != nil {
+			return err
+		}
+
+		var relatedDocuments []bson.Raw
+		if err := cursor.All(ctx, &relatedDocuments); err != nil {
+			return err
+		}
+		for _, document := range relatedDocuments {
+			key, err := document.LookupErr(strings.Split(relation.ForeignKey, ".")...)
+			if err != nil {
+				continue
+			}
+			id := relationKey(key)
+			related[id] = append(related[id], document)
+		}
+	}
+
+	for i, model := range models {
+		field, err := relationField(model, name)
+		if err != nil {
+			return err
+		}
+
+		var matches []bson.Raw
+		for _, key := range localKeys[i] {
+			matches = append(matches, related[relationKey(key)]...)
+		}
+
+		if err := assignRelation(field, relation.Type, matches); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func lookupKeys(document bson.Raw, relation contracts.Relation) []bson.RawValue {
+	value, err := document.LookupErr(strings.Split(relation.LocalKey, ".")...)
+	if err != nil || value.Type == bsontype.Null {
+		return nil
+	}
+
+	if relation.Type != contracts.RelationManyToMany {
+		return []bson.RawValue{value}
+	}
+
+	array, ok := value.ArrayOK()
+	if !ok {
+		return nil
+	}
+	elements, err := array.Values()
+	if err != nil {
+		return nil
+	}
+
+	return elements
+}
+
+// relationKey returns a comparable representation of a key, numbers of
+// different BSON types with the same value are considered equal
+func relationKey(value bson.RawValue) string {
+	switch value.Type {
+	case bsontype.Int32, bsontype.Int64:
+		return "n:" + strconv.FormatInt(value.AsInt64(), 10)
+	case bsontype.Double:
+		if number := value.Double(); number == math.Trunc(number) {
+			return "n:" + strconv.FormatInt(int64(number), 10)
+		}
+	}
+
+	return value.Type.String() + ":" + string(value.Value)
+}
+
+// relationField returns the struct field holding the relation, matched by its
+// bson tag or its name
+func relationField(model reflect.Value, name string) (reflect.Value, error) {
+	value := reflect.Indirect(model)
+	if value.Kind() != reflect.Struct {
+		return reflect.Value{}, fmt.Errorf("%s is not a struct", model.Type())
+	}
+
+	for i := 0; i < value.NumField(); i++ {
+		field := value.Type().Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		tag, _, _ := strings.Cut(field.Tag.Get("bson"), ",")
+		if tag == name || strings.EqualFold(field.Name, name) {
+			return value.Field(i), nil
+		}
+	}
+
+	return reflect.Value{}, fmt.Errorf("%s has no field for relation %s", value.Type(), name)
+}
+
+func assignRelation(field reflect.Value, relationType contracts.RelationType, documents []bson.Raw) error {
+	switch relationType {
+	case contracts.RelationHasMany, contracts.RelationManyToMany:
+		if field.Kind() != reflect.Slice {
+			return fmt.Errorf("field for %s relation must be a slice, got %s", relationType, field.Type())
+		}
+
+		slice := reflect.MakeSlice(field.Type(), len(documents), len(documents))
+		for i, document := range documents {
+			if err := bson.Unmarshal(document, newModel(slice.Index(i)).Interface()); err != nil {
+				return err
+			}
+		}
+		field.Set(slice)
+	default:
+		field.Set(reflect.Zero(field.Type()))
+		if len(documents) == 0 {
+			return nil
+		}
+
+		if err := bson.Unmarshal(documents[0], newModel(field).Interface()); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// newModel returns a pointer to the model held by value, allocating it when
+// value is a nil pointer
+func newModel(value reflect.Value) reflect.Value {
+	if value.Kind() == reflect.Ptr {
+		if value.IsNil() {
+			value.Set(reflect.New(value.Type().Elem()))
+		}
+		return value
+	}
+
+	return value.Addr()
+}
@@ -0,0 +1,123 @@
+package mongodb
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/portofolio-mager/goravel-mongodb/contracts"
+)
+
+type relationPost struct {
+	ID     int    `bson:"_id"`
+	UserID int    `bson:"user_id"`
+	Title  string `bson:"title"`
+}
+
+type relationUser struct {
+	ID      int            `bson:"_id"`
+	Name    string         `bson:"name"`
+	TagIDs  []int          `bson:"tag_ids"`
+	Posts   []relationPost `bson:"-"`
+	Profile *relationPost  `bson:"-"`
+}
+
+func (r *relationUser) Relations() map[string]contracts.Relation {
+	return map[string]contracts.Relation{
+		"posts":   HasMany("posts", "user_id"),
+		"profile": HasOne("profiles", "user_id"),
+		"tags":    ManyToMany("tags", "tag_ids"),
+	}
+}
+
+type RelationsTestSuite struct {
+	suite.Suite
+}
+
+func TestRelationsTestSuite(t *testing.T) {
+	suite.Run(t, new(RelationsTestSuite))
+}
+
+func (s *RelationsTestSuite) TestDefinitions() {
+	s.Equal(contracts.Relation{Type: contracts.RelationHasOne, Collection: "profiles", LocalKey: "_id", ForeignKey: "user_id"}, HasOne("profiles", "user_id"))
+	s.Equal(contracts.Relation{Type: contracts.RelationHasMany, Collection: "posts", LocalKey: "uuid", ForeignKey: "user_uuid"}, HasMany("posts", "user_uuid", "uuid"))
+	s.Equal(contracts.Relation{Type: contracts.RelationBelongsTo, Collection: "users", LocalKey: "user_id", ForeignKey: "_id"}, BelongsTo("users", "user_id"))
+	s.Equal(contracts.Relation{Type: contracts.RelationManyToMany, Collection: "tags", LocalKey: "tag_ids", ForeignKey: "slug"}, ManyToMany("tags", "tag_ids", "slug"))
+}
+
+func (s *RelationsTestSuite) TestRelationKey() {
+	int32Value := bson.RawValue{Type: bson.TypeInt32, Value: bsonValue(s, int32(1))}
+	int64Value := bson.RawValue{Type: bson.TypeInt64, Value: bsonValue(s, int64(1))}
+	doubleValue := bson.RawValue{Type: bson.TypeDouble, Value: bsonValue(s, float64(1))}
+	s.Equal(relationKey(int32Value), relationKey(int64Value))
+	s.Equal(relationKey(int32Value), relationKey(doubleValue))
+
+	id := primitive.NewObjectID()
+	idValue := bson.RawValue{Type: bson.TypeObjectID, Value: bsonValue(s, id)}
+	s.Equal(relationKey(idValue), relationKey(bson.RawValue{Type: bson.TypeObjectID, Value: bsonValue(s, id)}))
+	s.NotEqual(relationKey(idValue), relationKey(bson.RawValue{Type: bson.TypeObjectID, Value: bsonValue(s, primitive.NewObjectID())}))
+}
+
+func (s *RelationsTestSuite) TestLookupKeys() {
+	document, err := bson.Marshal(bson.M{"_id": 1, "tag_ids": bson.A{1, 2}, "profile": bson.M{"id": 3}})
+	s.Require().NoError(err)
+
+	s.Len(lookupKeys(document, HasMany("posts", "user_id")), 1)
+	s.Len(lookupKeys(document, ManyToMany("tags", "tag_ids")), 2)
+	s.Len(lookupKeys(document, BelongsTo("profiles", "profile.id")), 1)
+	s.Empty(lookupKeys(document, BelongsTo("users", "user_id")))
+}
+
+func (s *RelationsTestSuite) TestAssignRelation() {
+	post, err := bson.Marshal(relationPost{ID: 1, UserID: 1, Title: "Goravel"})
+	s.Require().NoError(err)
+
+	user := &relationUser{}
+	field, err := relationField(reflect.ValueOf(user), "posts")
+	s.Require().NoError(err)
+	s.NoError(assignRelation(field, contracts.RelationHasMany, []bson.Raw{post, post}))
+	s.Equal([]relationPost{{ID: 1, UserID: 1, Title: "Goravel"}, {ID: 1, UserID: 1, Title: "Goravel"}}, user.Posts)
+
+	field, err = relationField(reflect.ValueOf(user), "profile")
+	s.Require().NoError(err)
+	s.NoError(assignRelation(field, contracts.RelationHasOne, []bson.Raw{post}))
+	s.Equal(&relationPost{ID: 1, UserID: 1, Title: "Goravel"}, user.Profile)
+
+	s.NoError(assignRelation(field, contracts.RelationHasOne, nil))
+	s.Nil(user.Profile)
+
+	s.EqualError(assignRelation(field, contracts.RelationHasMany, nil), "field for has_many relation must be a slice, got *mongodb.relationPost")
+
+	_, err = relationField(reflect.ValueOf(user), "tags")
+	s.EqualError(err, "mongodb.relationUser has no field for relation tags")
+}
+
+func (s *RelationsTestSuite) TestDecodeWithRelations() {
+	document, err := bson.Marshal(bson.M{"_id": 1, "name": "goravel"})
+	s.Require().NoError(err)
+
+	// Documents without tag ids don't query the related collection
+	var users []relationUser
+	s.EqualError(decodeWithRelations(context.Background(), nil, []bson.Raw{document}, &users, []string{"tags"}),
+		"failed to load relation tags: mongodb.relationUser has no field for relation tags")
+	s.Equal([]relationUser{{ID: 1, Name: "goravel"}}, users)
+
+	var user relationUser
+	s.EqualError(decodeWithRelations(context.Background(), nil, []bson.Raw{document}, &user, []string{"comments"}),
+		"relation comments is not defined on *mongodb.relationUser")
+	s.Equal(relationUser{ID: 1, Name: "goravel"}, user)
+
+	s.EqualError(decodeWithRelations(context.Background(), nil, []bson.Raw{document}, user, []string{"posts"}),
+		"results argument must be a pointer, got mongodb.relationUser")
+}
+
+func bsonValue(s *RelationsTestSuite, value any) []byte {
+	_, data, err := bson.MarshalValue(value)
+	s.Require().NoError(err)
+
+	return data
+}
@@ -0,0 +1,91 @@
+package mongodb
+
+import (
+	"slices"
+	"sync"
+
+	"go.mongodb.org/mongo-driver/bson"
+
+	"github.com/portofolio-mager/goravel-mongodb/contracts"
+)
+
+type globalScope struct {
+	name  string
+	scope func(contracts.QueryBuilder)
+}
+
+// scopeRegistry holds the global scopes and the soft deletes of a client by
+// collection namespace, so that every Collection instance of the same
+// collection shares them
+type scopeRegistry struct {
+	mu           sync.RWMutex
+	globalScopes map[string][]globalScope
+	softDeletes  map[string]bool
+}
+
+func newScopeRegistry() *scopeRegistry {
+	return &scopeRegistry{
+		globalScopes: make(map[string][]globalScope),
+		softDeletes:  make(map[string]bool),
+	}
+}
+
+// withScope adds the global scope of the namespace, a scope with the same name
+// is replaced
+func (r *scopeRegistry) withScope(namespace, name string, scope func(contracts.QueryBuilder)) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	scopes := slices.Clone(r.globalScopes[namespace])
+	index := slices.IndexFunc(scopes, func(globalScope globalScope) bool {
+		return globalScope.name == name
+	})
+	if index >= 0 {
+		scopes[index].scope = scope
+	} else {
+		scopes = append(scopes, globalScope{name: name, scope: scope})
+	}
+	r.globalScopes[namespace] = scopes
+}
+
+// scopesOf returns the global scopes of the namespace, the slice is never
+// modified once returned
+func (r *scopeRegistry) scopesOf(namespace string) []globalScope {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.globalScopes[namespace]
+}
+
+func (r *scopeRegistry) withSoftDeletes(namespace string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.softDeletes[namespace] = true
+}
+
+func (r *scopeRegistry) usesSoftDeletes(namespace string) bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.softDeletes[namespace]
+}
+
+// scopeFilter adds a condition to a filter of any type, the bson.M filters are
+// merged and the other ones are combined with $and
+func scopeFilter(filter interface{}, condition bson.M) interface{} {
+	if len(condition) == 0 {
+		return filter
+	}
+
+	switch filter := filter.(type) {
+	case nil:
+		return condition
+	case bson.M:
+		return mergeFilter(filter, condition)
+	case map[string]interface{}:
+		return mergeFilter(filter, condition)
+	default:
+		return bson.M{"$and": bson.A{filter, condition}}
+	}
+}
@@ -0,0 +1,51 @@
+package mongodb
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/bson"
+
+	"github.com/portofolio-mager/goravel-mongodb/contracts"
+)
+
+type ScopesTestSuite struct {
+	suite.Suite
+}
+
+func TestScopesTestSuite(t *testing.T) {
+	suite.Run(t, new(ScopesTestSuite))
+}
+
+func (s *ScopesTestSuite) TestScopeFilter() {
+	condition := bson.M{DeletedAtField: nil}
+
+	s.Equal(bson.M{"name": "goravel"}, scopeFilter(bson.M{"name": "goravel"}, nil))
+	s.Equal(condition, scopeFilter(nil, condition))
+	s.Equal(bson.M{"name": "goravel", DeletedAtField: nil}, scopeFilter(bson.M{"name": "goravel"}, condition))
+	s.Equal(bson.M{"name": "goravel", DeletedAtField: nil}, scopeFilter(map[string]interface{}{"name": "goravel"}, condition))
+	s.Equal(bson.M{"$and": bson.A{bson.D{{Key: "name", Value: "goravel"}}, condition}}, scopeFilter(bson.D{{Key: "name", Value: "goravel"}}, condition))
+}
+
+func (s *ScopesTestSuite) TestScopeRegistry() {
+	registry := newScopeRegistry()
+	s.False(registry.usesSoftDeletes("goravel.users"))
+
+	registry.withSoftDeletes("goravel.users")
+	s.True(registry.usesSoftDeletes("goravel.users"))
+	s.False(registry.usesSoftDeletes("goravel.posts"))
+
+	registry.withScope("goravel.users", "tenant", func(query contracts.QueryBuilder) {})
+	registry.withScope("goravel.users", "active", func(query contracts.QueryBuilder) {})
+	scopes := registry.scopesOf("goravel.users")
+	s.Len(scopes, 2)
+	s.Empty(registry.scopesOf("goravel.posts"))
+
+	// Replacing a scope leaves the returned scopes as they are
+	replaced := func(query contracts.QueryBuilder) {}
+	registry.withScope("goravel.users", "tenant", replaced)
+	s.Len(registry.scopesOf("goravel.users"), 2)
+	s.NotEqual(reflect.ValueOf(replaced).Pointer(), reflect.ValueOf(scopes[0].scope).Pointer())
+	s.Equal(reflect.ValueOf(replaced).Pointer(), reflect.ValueOf(registry.scopesOf("goravel.users")[0].scope).Pointer())
+}
@@ -0,0 +1,126 @@
+package mongodb
+
+import (
+	"github.com/goravel/framework/contracts/config"
+	"github.com/goravel/framework/contracts/console"
+	"github.com/goravel/framework/contracts/console/command"
+	"github.com/goravel/framework/errors"
+
+	"github.com/portofolio-mager/goravel-mongodb/contracts"
+)
+
+type SeedCommand struct {
+	config config.Config
+	client func(connection string) (contracts.Client, error)
+}
+
+func NewSeedCommand(config config.Config, client func(connection string) (contracts.Client, error)) *SeedCommand {
+	return &SeedCommand{
+		config: config,
+		client: client,
+	}
+}
+
+// Signature The name and signature of the console command.
+func (r *SeedCommand) Signature() string {
+	return "mongodb:seed"
+}
+
+// Description The console command description.
+func (r *SeedCommand) Description() string {
+	return "Seed the MongoDB database with records"
+}
+
+// Extend The console command extend.
+func (r *SeedCommand) Extend() command.Extend {
+	return command.Extend{
+		Category: "mongodb",
+		Flags: []command.Flag{
+			&command.BoolFlag{
+				Name:    "force",
+				Aliases: []string{"f"},
+				Usage:   "force the operation to run when in production",
+			},
+			&command.StringSliceFlag{
+				Name:    "class",
+				Aliases: []string{"c"},
+				Usage:   "specify the seeder(s) to run",
+			},
+			&command.StringFlag{
+				Name:  "connection",
+				Value: Name,
+				Usage: "the MongoDB connection to seed",
+			},
+			&command.StringFlag{
+				Name:  "database",
+				Usage: "the database to seed, the database of the connection by default",
+			},
+		},
+	}
+}
+
+// Handle executes the console command.
+func (r *SeedCommand) Handle(ctx console.Context) error {
+	if err := r.ConfirmToProceed(ctx.OptionBool("force")); err != nil {
+		ctx.Error(err.Error())
+		return nil
+	}
+
+	client, err := r.client(ctx.Option("connection"))
+	if err != nil {
+		ctx.Error(err.Error())
+		return nil
+	}
+
+	seeders, err := r.GetSeeders(client, ctx.OptionSlice("class"))
+	if err != nil {
+		ctx.Error(err.Error())
+		return nil
+	}
+	if len(seeders) == 0 {
+		ctx.Success("no seeders found")
+		return nil
+	}
+
+	database := client.Database(ctx.Option("database"))
+	if database == nil {
+		ctx.Error(ConnectionFailed.Error())
+		return nil
+	}
+
+	if err := CallSeeders(database, seeders...); err != nil {
+		ctx.Error(errors.DatabaseFailToRunSeeder.Args(err).Error())
+		return nil
+	}
+	ctx.Success("Database seeding completed successfully.")
+
+	return nil
+}
+
+// ConfirmToProceed determines if the command should proceed based on user confirmation.
+func (r *SeedCommand) ConfirmToProceed(force bool) error {
+	if force || (r.config.GetString("app.env") != "production") {
+		return nil
+	}
+
+	return errors.DatabaseForceIsRequiredInProduction
+}
+
+// GetSeeders returns the seeders of the client to run, all the registered
+// ones when no signature is given
+func (r *SeedCommand) GetSeeders(client contracts.Client, signatures []string) ([]contracts.Seeder, error) {
+	if len(signatures) == 0 {
+		return client.Seeders(), nil
+	}
+
+	var seeders []contracts.Seeder
+	for _, signature := range signatures {
+		seeder := client.Seeder(signature)
+		if seeder == nil {
+			return nil, errors.DatabaseSeederNotFound.Args(signature)
+		}
+		seeders = append(seeders, seeder)
+	}
+
+	return seeders, nil
+}
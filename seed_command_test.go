@@ -0,0 +1,164 @@
+package mongodb
+
+import (
+	"errors"
+	"testing"
+
+	frameworkerrors "github.com/goravel/framework/errors"
+	mocksconfig "github.com/goravel/framework/mocks/config"
+	mocksconsole "github.com/goravel/framework/mocks/console"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/portofolio-mager/goravel-mongodb/contracts"
+	mockscontracts "github.com/portofolio-mager/goravel-mongodb/mocks"
+)
+
+type SeedCommandTestSuite struct {
+	suite.Suite
+	mockConfig  *mocksconfig.Config
+	mockContext *mocksconsole.Context
+	mockClient  *mockscontracts.Client
+	clientErr   error
+	seedCommand *SeedCommand
+}
+
+func TestSeedCommandTestSuite(t *testing.T) {
+	suite.Run(t, new(SeedCommandTestSuite))
+}
+
+func (s *SeedCommandTestSuite) SetupTest() {
+	s.mockConfig = mocksconfig.NewConfig(s.T())
+	s.mockContext = mocksconsole.NewContext(s.T())
+	s.mockClient = mockscontracts.NewClient(s.T())
+	s.clientErr = nil
+	s.seedCommand = NewSeedCommand(s.mockConfig, func(connection string) (contracts.Client, error) {
+		s.Equal("mongodb", connection)
+		return s.mockClient, s.clientErr
+	})
+}
+
+func (s *SeedCommandTestSuite) TestHandle() {
+	var users, posts *testSeeder
+	database := mockscontracts.NewDatabase(s.T())
+
+	tests := []struct {
+		name   string
+		setup  func()
+		assert func()
+	}{
+		{
+			name: "Success",
+			setup: func() {
+				s.mockContext.EXPECT().OptionBool("force").Return(false).Once()
+				s.mockConfig.EXPECT().GetString("app.env").Return("development").Once()
+				s.mockContext.EXPECT().Option("connection").Return("mongodb").Once()
+				s.mockContext.EXPECT().OptionSlice("class").Return([]string{"PostSeeder"}).Once()
+				s.mockClient.EXPECT().Seeder("PostSeeder").Return(posts).Once()
+				s.mockContext.EXPECT().Option("database").Return("goravel").Once()
+				s.mockClient.EXPECT().Database("goravel").Return(database).Once()
+				s.mockContext.EXPECT().Success("Database seeding completed successfully.").Once()
+			},
+			assert: func() {
+				s.Empty(users.run)
+				s.Equal([]contracts.Database{database}, posts.run)
+			},
+		},
+		{
+			name: "Run all the seeders",
+			setup: func() {
+				s.mockContext.EXPECT().OptionBool("force").Return(true).Once()
+				s.mockContext.EXPECT().Option("connection").Return("mongodb").Once()
+				s.mockContext.EXPECT().OptionSlice("class").Return(nil).Once()
+				s.mockClient.EXPECT().Seeders().Return([]contracts.Seeder{users, posts}).Once()
+				s.mockContext.EXPECT().Option("database").Return("").Once()
+				s.mockClient.EXPECT().Database("").Return(database).Once()
+				s.mockContext.EXPECT().Success("Database seeding completed successfully.").Once()
+			},
+			assert: func() {
+				s.Len(users.run, 1)
+				s.Len(posts.run, 1)
+			},
+		},
+		{
+			name: "Run in production without force",
+			setup: func() {
+				s.mockContext.EXPECT().OptionBool("force").Return(false).Once()
+				s.mockConfig.EXPECT().GetString("app.env").Return("production").Once()
+				s.mockContext.EXPECT().Error(frameworkerrors.DatabaseForceIsRequiredInProduction.Error()).Once()
+			},
+		},
+		{
+			name: "Seeder not found",
+			setup: func() {
+				s.mockContext.EXPECT().OptionBool("force").Return(false).Once()
+				s.mockConfig.EXPECT().GetString("app.env").Return("development").Once()
+				s.mockContext.EXPECT().Option("connection").Return("mongodb").Once()
+				s.mockContext.EXPECT().OptionSlice("class").Return([]string{"TagSeeder"}).Once()
+				s.mockClient.EXPECT().Seeder("TagSeeder").Return(nil).Once()
+				s.mockContext.EXPECT().Error(frameworkerrors.DatabaseSeederNotFound.Args("TagSeeder").Error()).Once()
+			},
+		},
+		{
+			name: "No seeders found",
+			setup: func() {
+				s.mockContext.EXPECT().OptionBool("force").Return(false).Once()
+				s.mockConfig.EXPECT().GetString("app.env").Return("development").Once()
+				s.mockContext.EXPECT().Option("connection").Return("mongodb").Once()
+				s.mockContext.EXPECT().OptionSlice("class").Return(nil).Once()
+				s.mockClient.EXPECT().Seeders().Return(nil).Once()
+				s.mockContext.EXPECT().Success("no seeders found").Once()
+			},
+		},
+		{
+			name: "Failed to resolve the client",
+			setup: func() {
+				s.clientErr = errors.New("please register mongodb service provider")
+				s.mockContext.EXPECT().OptionBool("force").Return(false).Once()
+				s.mockConfig.EXPECT().GetString("app.env").Return("development").Once()
+				s.mockContext.EXPECT().Option("connection").Return("mongodb").Once()
+				s.mockContext.EXPECT().Error("please register mongodb service provider").Once()
+			},
+		},
+		{
+			name: "Failed to connect",
+			setup: func() {
+				s.mockContext.EXPECT().OptionBool("force").Return(false).Once()
+				s.mockConfig.EXPECT().GetString("app.env").Return("development").Once()
+				s.mockContext.EXPECT().Option("connection").Return("mongodb").Once()
+				s.mockContext.EXPECT().OptionSlice("class").Return(nil).Once()
+				s.mockClient.EXPECT().Seeders().Return([]contracts.Seeder{users, posts}).Once()
+				s.mockContext.EXPECT().Option("database").Return("").Once()
+				s.mockClient.EXPECT().Database("").Return(nil).Once()
+				s.mockContext.EXPECT().Error(ConnectionFailed.Error()).Once()
+			},
+		},
+		{
+			name: "Seeder fails",
+			setup: func() {
+				posts.err = errors.New("duplicate key")
+				s.mockContext.EXPECT().OptionBool("force").Return(false).Once()
+				s.mockConfig.EXPECT().GetString("app.env").Return("development").Once()
+				s.mockContext.EXPECT().Option("connection").Return("mongodb").Once()
+				s.mockContext.EXPECT().OptionSlice("class").Return([]string{"PostSeeder"}).Once()
+				s.mockClient.EXPECT().Seeder("PostSeeder").Return(posts).Once()
+				s.mockContext.EXPECT().Option("database").Return("").Once()
+				s.mockClient.EXPECT().Database("").Return(database).Once()
+				s.mockContext.EXPECT().Error(frameworkerrors.DatabaseFailToRunSeeder.Args(errors.New("failed to run seeder PostSeeder: duplicate key")).Error()).Once()
+			},
+		},
+	}
+
+	for _, test := range tests {
+		s.Run(test.name, func() {
+			s.SetupTest()
+			users = &testSeeder{signature: "UserSeeder"}
+			posts = &testSeeder{signature: "PostSeeder"}
+
+			test.setup()
+			s.NoError(s.seedCommand.Handle(s.mockContext))
+			if test.assert != nil {
+				test.assert()
+			}
+		})
+	}
+}
@@ -0,0 +1,72 @@
+package mongodb
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/portofolio-mager/goravel-mongodb/contracts"
+)
+
+// seederRegistry holds the seeders registered on the clients of a service
+// provider, so that the mongodb:seed command runs them on any connection
+type seederRegistry struct {
+	mu      sync.RWMutex
+	seeders []contracts.Seeder
+}
+
+func newSeederRegistry() *seederRegistry {
+	return &seederRegistry{}
+}
+
+// register registers seeders, a seeder replaces the registered one with the
+// same signature
+func (r *seederRegistry) register(seeders ...contracts.Seeder) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, seeder := range seeders {
+		replaced := false
+		for i, registered := range r.seeders {
+			if registered.Signature() == seeder.Signature() {
+				r.seeders[i] = seeder
+				replaced = true
+				break
+			}
+		}
+		if !replaced {
+			r.seeders = append(r.seeders, seeder)
+		}
+	}
+}
+
+func (r *seederRegistry) get(signature string) contracts.Seeder {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, seeder := range r.seeders {
+		if seeder.Signature() == signature {
+			return seeder
+		}
+	}
+
+	return nil
+}
+
+func (r *seederRegistry) all() []contracts.Seeder {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return append([]contracts.Seeder(nil), r.seeders...)
+}
+
+// CallSeeders runs the seeders against the database in order, seeders can
+// use it to call other seeders
+func CallSeeders(database contracts.Database, seeders ...contracts.Seeder) error {
+	for _, seeder := range seeders {
+		if err := seeder.Run(database); err != nil {
+			return fmt.Errorf("failed to run seeder %s: %w", seeder.Signature(), err)
+		}
+	}
+
+	return nil
+}
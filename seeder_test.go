@@ -0,0 +1,64 @@
+package mongodb
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/portofolio-mager/goravel-mongodb/contracts"
+	mockscontracts "github.com/portofolio-mager/goravel-mongodb/mocks"
+)
+
+type testSeeder struct {
+	signature string
+	err       error
+	run       []contracts.Database
+}
+
+func (r *testSeeder) Signature() string {
+	return r.signature
+}
+
+func (r *testSeeder) Run(database contracts.Database) error {
+	r.run = append(r.run, database)
+	return r.err
+}
+
+type SeederTestSuite struct {
+	suite.Suite
+}
+
+func TestSeederTestSuite(t *testing.T) {
+	suite.Run(t, new(SeederTestSuite))
+}
+
+func (s *SeederTestSuite) TestRegister() {
+	seeders := newSeederRegistry()
+	users := &testSeeder{signature: "UserSeeder"}
+	posts := &testSeeder{signature: "PostSeeder"}
+	seeders.register(users, posts)
+
+	s.Equal([]contracts.Seeder{users, posts}, seeders.all())
+	s.Equal(posts, seeders.get("PostSeeder"))
+	s.Nil(seeders.get("TagSeeder"))
+
+	replaced := &testSeeder{signature: "UserSeeder"}
+	seeders.register(replaced)
+	s.Equal([]contracts.Seeder{replaced, posts}, seeders.all())
+
+	// The clients don't share their seeders unless the service provider does
+	s.Empty(NewMongoDB(nil, nil, "mongodb").Seeders())
+}
+
+func (s *SeederTestSuite) TestCallSeeders() {
+	database := mockscontracts.NewDatabase(s.T())
+	users := &testSeeder{signature: "UserSeeder"}
+	posts := &testSeeder{signature: "PostSeeder", err: errors.New("duplicate key")}
+	tags := &testSeeder{signature: "TagSeeder"}
+
+	s.EqualError(CallSeeders(database, users, posts, tags), "failed to run seeder PostSeeder: duplicate key")
+	s.Equal([]contracts.Database{database}, users.run)
+	s.Equal([]contracts.Database{database}, posts.run)
+	s.Empty(tags.run)
+}
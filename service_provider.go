@@ -1,9 +1,14 @@
 package mongodb
 
 import (
+	"sync"
+
 	"github.com/goravel/framework/contracts/binding"
+	"github.com/goravel/framework/contracts/console"
 	"github.com/goravel/framework/contracts/foundation"
 	"github.com/goravel/framework/errors"
+
+	"github.com/portofolio-mager/goravel-mongodb/contracts"
 )
 
 const (
@@ -14,6 +19,13 @@ const (
 var App foundation.Application
 
 type ServiceProvider struct {
+	// clients are the clients of the connections, so the facades, the ORM,
+	// the health checks and the commands share a connection pool
+	clients sync.Map
+	// seeders are shared by the clients, so a seeder registered on one
+	// connection seeds any of them
+	seeders     *seederRegistry
+	seedersOnce sync.Once
 }
 
 func (r *ServiceProvider) Relationship() binding.Relationship {
@@ -45,10 +57,46 @@ func (r *ServiceProvider) Register(app foundation.Application) {
 			return nil, errors.LogFacadeNotSet.SetModule(Name)
 		}
 
-		return NewMongoDB(config, log, parameters["connection"].(string)), nil
+		connection := parameters["connection"].(string)
+		if client, ok := r.clients.Load(connection); ok {
+			return client, nil
+		}
+
+		client := NewMongoDB(config, log, connection)
+		client.seeders = r.seederRegistry()
+		shared, _ := r.clients.LoadOrStore(connection, client)
+
+		return shared, nil
 	})
 }
 
 func (r *ServiceProvider) Boot(app foundation.Application) {
+	app.Commands([]console.Command{
+		NewSeedCommand(app.MakeConfig(), clientResolver(app)),
+		NewShowCommand(clientResolver(app)),
+		NewCollectionCommand(clientResolver(app)),
+		NewPingCommand(clientResolver(app)),
+	})
+}
 
+func (r *ServiceProvider) seederRegistry() *seederRegistry {
+	r.seedersOnce.Do(func() {
+		r.seeders = newSeederRegistry()
+	})
+
+	return r.seeders
+}
+
+// clientResolver resolves the client of a connection for the commands
+func clientResolver(app foundation.Application) func(connection string) (contracts.Client, error) {
+	return func(connection string) (contracts.Client, error) {
+		instance, err := app.MakeWith(Binding, map[string]any{
+			"connection": connection,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		return instance.(contracts.Client), nil
+	}
 }
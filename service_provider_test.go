@@ -0,0 +1,62 @@
+package mongodb
+
+import (
+	"testing"
+
+	"github.com/goravel/framework/contracts/foundation"
+	mocksconfig "github.com/goravel/framework/mocks/config"
+	mocksfoundation "github.com/goravel/framework/mocks/foundation"
+	mockslog "github.com/goravel/framework/mocks/log"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/portofolio-mager/goravel-mongodb/contracts"
+)
+
+type ServiceProviderTestSuite struct {
+	suite.Suite
+	mockApp  *mocksfoundation.Application
+	callback func(foundation.Application, map[string]any) (any, error)
+}
+
+func TestServiceProviderTestSuite(t *testing.T) {
+	suite.Run(t, new(ServiceProviderTestSuite))
+}
+
+func (s *ServiceProviderTestSuite) SetupTest() {
+	s.mockApp = mocksfoundation.NewApplication(s.T())
+	s.mockApp.EXPECT().BindWith(Binding, mock.Anything).Run(func(key any, callback func(foundation.Application, map[string]any) (any, error)) {
+		s.callback = callback
+	}).Once()
+
+	(&ServiceProvider{}).Register(s.mockApp)
+}
+
+func (s *ServiceProviderTestSuite) TestRegister_SharesTheClientOfAConnection() {
+	s.mockApp.EXPECT().MakeConfig().Return(mocksconfig.NewConfig(s.T()))
+	s.mockApp.EXPECT().MakeLog().Return(mockslog.NewLog(s.T()))
+
+	first, err := s.callback(s.mockApp, map[string]any{"connection": "mongodb"})
+	s.NoError(err)
+	second, err := s.callback(s.mockApp, map[string]any{"connection": "mongodb"})
+	s.NoError(err)
+	other, err := s.callback(s.mockApp, map[string]any{"connection": "analytics"})
+	s.NoError(err)
+
+	s.Same(first, second)
+	s.NotSame(first, other)
+	s.Equal("analytics", other.(*MongoDB).config.Connection())
+
+	// The seeders registered on a connection seed the other ones too
+	seeder := &testSeeder{signature: "UserSeeder"}
+	first.(*MongoDB).RegisterSeeders(seeder)
+	s.Equal([]contracts.Seeder{seeder}, other.(*MongoDB).Seeders())
+}
+
+func (s *ServiceProviderTestSuite) TestRegister_WithoutConfig() {
+	s.mockApp.EXPECT().MakeConfig().Return(nil).Once()
+
+	client, err := s.callback(s.mockApp, map[string]any{"connection": "mongodb"})
+	s.Error(err)
+	s.Nil(client)
+}
@@ -0,0 +1,133 @@
+package mongodb
+
+import (
+	"fmt"
+
+	"github.com/goravel/framework/contracts/console"
+	"github.com/goravel/framework/contracts/console/command"
+
+	"github.com/portofolio-mager/goravel-mongodb/contracts"
+)
+
+type ShowCommand struct {
+	client func(connection string) (contracts.Client, error)
+}
+
+func NewShowCommand(client func(connection string) (contracts.Client, error)) *ShowCommand {
+	return &ShowCommand{
+		client: client,
+	}
+}
+
+// Signature The name and signature of the console command.
+func (r *ShowCommand) Signature() string {
+	return "mongodb:show"
+}
+
+// Description The console command description.
+func (r *ShowCommand) Description() string {
+	return "Display information about the MongoDB databases and collections"
+}
+
+// Extend The console command extend.
+func (r *ShowCommand) Extend() command.Extend {
+	return command.Extend{
+		Category: "mongodb",
+		Flags: []command.Flag{
+			&command.StringFlag{
+				Name:  "connection",
+				Value: Name,
+				Usage: "the MongoDB connection",
+			},
+			&command.StringFlag{
+				Name:    "database",
+				Aliases: []string{"d"},
+				Usage:   "the database whose collections are shown, the database of the connection by default",
+			},
+		},
+	}
+}
+
+// Handle Execute the console command.
+func (r *ShowCommand) Handle(ctx console.Context) error {
+	if got := ctx.Argument(0); len(got) > 0 {
+		ctx.Error(fmt.Sprintf("No arguments expected for '%s' command, got '%s'.", r.Signature(), got))
+		return nil
+	}
+
+	connection := ctx.Option("connection")
+	client, err := r.client(connection)
+	if err != nil {
+		ctx.Error(err.Error())
+		return nil
+	}
+
+	databases, err := client.ListDatabases()
+	if err != nil {
+		ctx.Error(err.Error())
+		return nil
+	}
+
+	database := client.Database(ctx.Option("database"))
+	if database == nil {
+		ctx.Error(ConnectionFailed.Error())
+		return nil
+	}
+
+	collections, err := database.ListCollectionSpecs(nil)
+	if err != nil {
+		ctx.Error(err.Error())
+		return nil
+	}
+
+	// Views have no storage of their own
+	stats := make([]*collectionStats, len(collections))
+	for i, collection := range collections {
+		if collection.Type == "view" {
+			continue
+		}
+		if stats[i], err = statsOf(database.Collection(collection.Name)); err != nil {
+			ctx.Error(err.Error())
+			return nil
+		}
+	}
+
+	ctx.NewLine()
+	ctx.TwoColumnDetail("<fg=green;op=bold>MongoDB</>", "")
+	ctx.TwoColumnDetail("Connection", connection)
+	ctx.TwoColumnDetail("Database", database.Name())
+	ctx.TwoColumnDetail("Databases", fmt.Sprintf("%d", len(databases)))
+	var size int64
+	for _, database := range databases {
+		size += database.SizeOnDisk
+	}
+	ctx.TwoColumnDetail("Total Size", fmt.Sprintf("%s MB", megabytes(size)))
+	ctx.NewLine()
+
+	if len(databases) > 0 {
+		ctx.TwoColumnDetail("<fg=green;op=bold>Databases</>", "<fg=yellow;op=bold>Size (MB)</>")
+		for _, database := range databases {
+			ctx.TwoColumnDetail(database.Name, megabytes(database.SizeOnDisk))
+		}
+		ctx.NewLine()
+	}
+
+	if len(collections) > 0 {
+		ctx.TwoColumnDetail("<fg=green;op=bold>Collections</>", "<fg=yellow;op=bold>Documents / Size (MB)</>")
+		for i, collection := range collections {
+			if stats[i] == nil {
+				ctx.TwoColumnDetail(collection.Name, collection.Type)
+				continue
+			}
+			ctx.TwoColumnDetail(collection.Name, fmt.Sprintf("%d / %s", stats[i].Count, megabytes(stats[i].Size)))
+		}
+		ctx.NewLine()
+	}
+
+	return nil
+}
+
+// megabytes formats a size in bytes as megabytes
+func megabytes(size int64) string {
+	return fmt.Sprintf("%.3f", float64(size)/1024/1024)
+}
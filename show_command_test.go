@@ -0,0 +1,170 @@
+package mongodb
+
+import (
+	"errors"
+	"testing"
+
+	mocksconsole "github.com/goravel/framework/mocks/console"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/bson"
+
+	"github.com/portofolio-mager/goravel-mongodb/contracts"
+	mockscontracts "github.com/portofolio-mager/goravel-mongodb/mocks"
+)
+
+type ShowCommandTestSuite struct {
+	suite.Suite
+	mockContext  *mocksconsole.Context
+	mockClient   *mockscontracts.Client
+	mockDatabase *mockscontracts.Database
+	clientErr    error
+	showCommand  *ShowCommand
+}
+
+func TestShowCommandTestSuite(t *testing.T) {
+	suite.Run(t, new(ShowCommandTestSuite))
+}
+
+func (s *ShowCommandTestSuite) SetupTest() {
+	s.mockContext = mocksconsole.NewContext(s.T())
+	s.mockClient = mockscontracts.NewClient(s.T())
+	s.mockDatabase = mockscontracts.NewDatabase(s.T())
+	s.clientErr = nil
+	s.showCommand = NewShowCommand(func(connection string) (contracts.Client, error) {
+		s.Equal("mongodb", connection)
+		return s.mockClient, s.clientErr
+	})
+}
+
+func (s *ShowCommandTestSuite) TestHandle() {
+	tests := []struct {
+		name  string
+		setup func()
+	}{
+		{
+			name: "Success",
+			setup: func() {
+				users := mockscontracts.NewCollection(s.T())
+				s.mockContext.EXPECT().Argument(0).Return("").Once()
+				s.mockContext.EXPECT().Option("connection").Return("mongodb").Once()
+				s.mockClient.EXPECT().ListDatabases().Return([]contracts.DatabaseInfo{
+					{Name: "admin", SizeOnDisk: 1024 * 1024},
+					{Name: "goravel", SizeOnDisk: 2 * 1024 * 1024},
+				}, nil).Once()
+				s.mockContext.EXPECT().Option("database").Return("").Once()
+				s.mockClient.EXPECT().Database("").Return(s.mockDatabase).Once()
+				s.mockDatabase.EXPECT().ListCollectionSpecs(nil).Return([]contracts.CollectionInfo{
+					{Name: "active_users", Type: "view", ReadOnly: true},
+					{Name: "users", Type: "collection"},
+				}, nil).Once()
+				s.mockDatabase.EXPECT().Collection("users").Return(users).Once()
+				s.mockDatabase.EXPECT().Name().Return("goravel").Once()
+				expectStats(s.T(), users, bson.M{"storageStats": bson.M{"count": 3, "size": 512 * 1024}})
+
+				s.mockContext.EXPECT().NewLine().Times(4)
+				s.mockContext.EXPECT().TwoColumnDetail("<fg=green;op=bold>MongoDB</>", "").Once()
+				s.mockContext.EXPECT().TwoColumnDetail("Connection", "mongodb").Once()
+				s.mockContext.EXPECT().TwoColumnDetail("Database", "goravel").Once()
+				s.mockContext.EXPECT().TwoColumnDetail("Databases", "2").Once()
+				s.mockContext.EXPECT().TwoColumnDetail("Total Size", "3.000 MB").Once()
+				s.mockContext.EXPECT().TwoColumnDetail("<fg=green;op=bold>Databases</>", "<fg=yellow;op=bold>Size (MB)</>").Once()
+				s.mockContext.EXPECT().TwoColumnDetail("admin", "1.000").Once()
+				s.mockContext.EXPECT().TwoColumnDetail("goravel", "2.000").Once()
+				s.mockContext.EXPECT().TwoColumnDetail("<fg=green;op=bold>Collections</>", "<fg=yellow;op=bold>Documents / Size (MB)</>").Once()
+				s.mockContext.EXPECT().TwoColumnDetail("active_users", "view").Once()
+				s.mockContext.EXPECT().TwoColumnDetail("users", "3 / 0.500").Once()
+			},
+		},
+		{
+			name: "Unexpected argument",
+			setup: func() {
+				s.mockContext.EXPECT().Argument(0).Return("users").Once()
+				s.mockContext.EXPECT().Error("No arguments expected for 'mongodb:show' command, got 'users'.").Once()
+			},
+		},
+		{
+			name: "Failed to resolve the client",
+			setup: func() {
+				s.clientErr = errors.New("please register mongodb service provider")
+				s.mockContext.EXPECT().Argument(0).Return("").Once()
+				s.mockContext.EXPECT().Option("connection").Return("mongodb").Once()
+				s.mockContext.EXPECT().Error("please register mongodb service provider").Once()
+			},
+		},
+		{
+			name: "Failed to list the databases",
+			setup: func() {
+				s.mockContext.EXPECT().Argument(0).Return("").Once()
+				s.mockContext.EXPECT().Option("connection").Return("mongodb").Once()
+				s.mockClient.EXPECT().ListDatabases().Return(nil, errors.New("unauthorized")).Once()
+				s.mockContext.EXPECT().Error("unauthorized").Once()
+			},
+		},
+		{
+			name: "Failed to connect",
+			setup: func() {
+				s.mockContext.EXPECT().Argument(0).Return("").Once()
+				s.mockContext.EXPECT().Option("connection").Return("mongodb").Once()
+				s.mockClient.EXPECT().ListDatabases().Return(nil, nil).Once()
+				s.mockContext.EXPECT().Option("database").Return("").Once()
+				s.mockClient.EXPECT().Database("").Return(nil).Once()
+				s.mockContext.EXPECT().Error(ConnectionFailed.Error()).Once()
+			},
+		},
+		{
+			name: "Failed to get the statistics",
+			setup: func() {
+				users := mockscontracts.NewCollection(s.T())
+				aggregation := mockscontracts.NewAggregation(s.T())
+				s.mockContext.EXPECT().Argument(0).Return("").Once()
+				s.mockContext.EXPECT().Option("connection").Return("mongodb").Once()
+				s.mockClient.EXPECT().ListDatabases().Return(nil, nil).Once()
+				s.mockContext.EXPECT().Option("database").Return("").Once()
+				s.mockClient.EXPECT().Database("").Return(s.mockDatabase).Once()
+				s.mockDatabase.EXPECT().ListCollectionSpecs(nil).Return([]contracts.CollectionInfo{{Name: "users", Type: "collection"}}, nil).Once()
+				s.mockDatabase.EXPECT().Collection("users").Return(users).Once()
+				users.EXPECT().Aggregate(mock.Anything).Return(aggregation).Once()
+				users.EXPECT().Name().Return("users").Once()
+				aggregation.EXPECT().All(mock.Anything).Return(errors.New("unauthorized")).Once()
+				s.mockContext.EXPECT().Error("failed to get the statistics of users: unauthorized").Once()
+			},
+		},
+	}
+
+	for _, test := range tests {
+		s.Run(test.name, func() {
+			s.SetupTest()
+			test.setup()
+			s.NoError(s.showCommand.Handle(s.mockContext))
+		})
+	}
+}
+
+func (s *ShowCommandTestSuite) TestMegabytes() {
+	s.Equal("0.000", megabytes(0))
+	s.Equal("1.500", megabytes(1536*1024))
+}
+
+// expectStats makes the $collStats aggregation of the collection return the
+// documents
+func expectStats(t *testing.T, collection *mockscontracts.Collection, documents ...bson.M) {
+	aggregation := mockscontracts.NewAggregation(t)
+	collection.EXPECT().Aggregate(bson.A{
+		bson.D{{Key: "$collStats", Value: bson.D{{Key: "storageStats", Value: bson.D{}}}}},
+	}).Return(aggregation).Once()
+	aggregation.EXPECT().All(mock.Anything).RunAndReturn(aggregationResults(documents...)).Once()
+}
+
+// aggregationResults decodes the documents into the results like
+// Aggregation.All
+func aggregationResults(documents ...bson.M) func(results interface{}) error {
+	return func(results interface{}) error {
+		raw, err := bson.Marshal(bson.M{"results": documents})
+		if err != nil {
+			return err
+		}
+
+		return bson.Raw(raw).Lookup("results").Unmarshal(results)
+	}
+}
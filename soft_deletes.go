@@ -0,0 +1,41 @@
+package mongodb
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// DeletedAtField is the document field used to flag soft deleted documents
+const DeletedAtField = "deleted_at"
+
+// SoftDeletes can be embedded in a model to add the soft delete timestamp,
+// similar to Goravel's orm.SoftDeletes
+type SoftDeletes struct {
+	DeletedAt *time.Time `bson:"deleted_at,omitempty" json:"deleted_at,omitempty"`
+}
+
+// Trashed reports whether the model has been soft deleted
+func (r SoftDeletes) Trashed() bool {
+	return r.DeletedAt != nil
+}
+
+type trashedScope int
+
+const (
+	withoutTrashed trashedScope = iota
+	withTrashed
+	onlyTrashed
+)
+
+// filter returns the condition that restricts a query to the requested trashed state
+func (r trashedScope) filter() bson.M {
+	switch r {
+	case withoutTrashed:
+		return bson.M{DeletedAtField: nil}
+	case onlyTrashed:
+		return bson.M{DeletedAtField: bson.M{"$ne": nil}}
+	default:
+		return nil
+	}
+}
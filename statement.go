@@ -0,0 +1,994 @@
+package mongodb
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"gorm.io/gorm"
+	"gorm.io/gorm/callbacks"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+)
+
+// SequencesCollection holds the counters of the integer primary keys the
+// dialector generates, one document per table
+const SequencesCollection = "sequences"
+
+var (
+	// comparisonPattern matches the conditions of Where("age > ?", 18) and
+	// WhereIn, the column, operator and placeholder
+	comparisonPattern = regexp.MustCompile(`(?i)^([\w.` + "`" + `"]+)\s*(=|<>|!=|>=|<=|>|<|not\s+in|in|not\s+like|like)\s*\(?\?\)?$`)
+	nullPattern       = regexp.MustCompile(`(?i)^([\w.` + "`" + `"]+)\s+is\s+(not\s+)?null$`)
+	andPattern        = regexp.MustCompile(`(?i)\s+and\s+`)
+	orPattern         = regexp.MustCompile(`(?i)\s+or\s+`)
+)
+
+// registerCallbacks replaces the callbacks executing SQL, the statements are
+// translated to MongoDB operations on the collection named by the table
+func (m *mongoConnPool) registerCallbacks(db *gorm.DB) error {
+	if err := db.Callback().Create().Replace("gorm:create", m.create); err != nil {
+		return err
+	}
+	if err := db.Callback().Query().Replace("gorm:query", m.query); err != nil {
+		return err
+	}
+	if err := db.Callback().Update().Replace("gorm:update", m.update); err != nil {
+		return err
+	}
+
+	return db.Callback().Delete().Replace("gorm:delete", m.delete)
+}
+
+func (m *mongoConnPool) create(db *gorm.DB) {
+	if db.Error != nil {
+		return
+	}
+
+	stmt := db.Statement
+	if stmt.Schema != nil && !stmt.Unscoped {
+		for _, c := range stmt.Schema.CreateClauses {
+			stmt.AddClause(c)
+		}
+	}
+
+	values := callbacks.ConvertToCreateValues(stmt)
+	if db.Error != nil || db.DryRun {
+		return
+	}
+
+	database, err := m.mongoDatabase()
+	if err != nil {
+		_ = db.AddError(err)
+		return
+	}
+	collection := database.Collection(stmt.Table)
+
+	documents := make([]interface{}, len(values.Values))
+	for i, row := range values.Values {
+		document := bson.D{}
+		for j, column := range values.Columns {
+			value, err := toMongoValue(row[j])
+			if err != nil {
+				_ = db.AddError(err)
+				return
+			}
+			key := m.key(stmt, column.Name)
+			// A nil value, like a NULL sql.NullString, is a missing id too
+			if key == "_id" && (value == nil || reflect.ValueOf(value).IsZero()) {
+				continue
+			}
+			document = append(document, bson.E{Key: key, Value: value})
+		}
+
+		if _, ok := lookupElement(document, "_id"); !ok {
+			id, err := m.generateID(stmt, database)
+			if err != nil {
+				_ = db.AddError(err)
+				return
+			}
+			document = append(bson.D{{Key: "_id", Value: id}}, document...)
+			m.setPrimaryKey(stmt, i, id)
+		}
+
+		documents[i] = document
+	}
+
+	if len(documents) == 0 {
+		return
+	}
+
+	if c, ok := stmt.Clauses["ON CONFLICT"]; ok {
+		onConflict, _ := c.Expression.(clause.OnConflict)
+		db.RowsAffected, err = m.upsert(stmt, collection, documents, onConflict)
+		_ = db.AddError(err)
+		return
+	}
+
+	result, err := collection.InsertMany(stmt.Context, documents)
+	if err != nil {
+		_ = db.AddError(fmt.Errorf("failed to insert into %s: %w", stmt.Table, err))
+		return
+	}
+	db.RowsAffected = int64(len(result.InsertedIDs))
+}
+
+// upsert resolves the conflicts on _id, the only unique key of a collection
+// the dialector knows about. DoNothing keeps the existing documents, the
+// others overwrite them with the inserted fields
+func (m *mongoConnPool) upsert(stmt *gorm.Statement, collection *mongo.Collection, documents []interface{}, onConflict clause.OnConflict) (int64, error) {
+	var affected int64
+	for _, document := range documents {
+		var id interface{}
+		fields := bson.D{}
+		for _, element := range document.(bson.D) {
+			if element.Key == "_id" {
+				id = element.Value
+				continue
+			}
+			fields = append(fields, element)
+		}
+
+		update := bson.D{{Key: "$set", Value: fields}}
+		if onConflict.DoNothing {
+			update = bson.D{{Key: "$setOnInsert", Value: fields}}
+		}
+
+		result, err := collection.UpdateOne(stmt.Context, bson.D{{Key: "_id", Value: id}}, update, options.Update().SetUpsert(true))
+		if err != nil {
+			return affected, fmt.Errorf("failed to upsert into %s: %w", stmt.Table, err)
+		}
+		if !onConflict.DoNothing || result.UpsertedCount > 0 {
+			affected++
+		}
+	}
+
+	return affected, nil
+}
+
+func (m *mongoConnPool) query(db *gorm.DB) {
+	if db.Error != nil {
+		return
+	}
+
+	stmt := db.Statement
+	if stmt.Schema != nil {
+		for _, c := range stmt.Schema.QueryClauses {
+			stmt.AddClause(c)
+		}
+
+		// First(&user) with a primary key set queries by the primary key
+		if stmt.ReflectValue.Kind() == reflect.Struct && stmt.ReflectValue.Type() == stmt.Schema.ModelType {
+			var conditions []clause.Expression
+			for _, field := range stmt.Schema.PrimaryFields {
+				if value, isZero := field.ValueOf(stmt.Context, stmt.ReflectValue); !isZero {
+					conditions = append(conditions, clause.Eq{Column: clause.Column{Table: stmt.Table, Name: field.DBName}, Value: value})
+				}
+			}
+			if len(conditions) > 0 {
+				stmt.AddClause(clause.Where{Exprs: conditions})
+			}
+		}
+	}
+
+	if err := unsupportedQuery(stmt); err != nil {
+		_ = db.AddError(err)
+		return
+	}
+
+	filter, err := m.filter(stmt)
+	if err != nil {
+		_ = db.AddError(err)
+		return
+	}
+	sort, err := m.sort(stmt)
+	if err != nil {
+		_ = db.AddError(err)
+		return
+	}
+	if db.DryRun {
+		return
+	}
+
+	database, err := m.mongoDatabase()
+	if err != nil {
+		_ = db.AddError(err)
+		return
+	}
+	collection := database.Collection(stmt.Table)
+
+	var limit clause.Limit
+	if c, ok := stmt.Clauses["LIMIT"]; ok {
+		limit, _ = c.Expression.(clause.Limit)
+	}
+
+	if count, ok := stmt.Dest.(*int64); ok && isCount(stmt) {
+		// COUNT(column) leaves out the documents without the column
+		if expression, _ := stmt.Clauses["SELECT"].Expression.(clause.Expr); len(expression.Vars) == 1 {
+			column, err := m.columnKey(stmt, expression.Vars[0])
+			if err != nil {
+				_ = db.AddError(err)
+				return
+			}
+			filter = and(bson.A{filter, bson.D{{Key: column, Value: bson.D{{Key: "$ne", Value: nil}}}}})
+		}
+
+		opts := options.Count()
+		if limit.Offset > 0 {
+			opts.SetSkip(int64(limit.Offset))
+		}
+		if limit.Limit != nil && *limit.Limit >= 0 {
+			opts.SetLimit(int64(*limit.Limit))
+		}
+
+		if *count, err = collection.CountDocuments(stmt.Context, filter, opts); err != nil {
+			_ = db.AddError(fmt.Errorf("failed to count %s: %w", stmt.Table, err))
+			return
+		}
+		db.RowsAffected = 1
+		return
+	}
+
+	opts := options.Find()
+	if len(sort) > 0 {
+		opts.SetSort(sort)
+	}
+	if limit.Offset > 0 {
+		opts.SetSkip(int64(limit.Offset))
+	}
+	if limit.Limit != nil && *limit.Limit >= 0 {
+		opts.SetLimit(int64(*limit.Limit))
+	}
+	if columns := selectedColumns(stmt); len(columns) > 0 {
+		projection := bson.D{}
+		for _, column := range columns {
+			projection = append(projection, bson.E{Key: m.key(stmt, column), Value: 1})
+		}
+		opts.SetProjection(projection)
+	}
+
+	cursor, err := collection.Find(stmt.Context, filter, opts)
+	if err != nil {
+		_ = db.AddError(fmt.Errorf("failed to query %s: %w", stmt.Table, err))
+		return
+	}
+	var documents []bson.M
+	if err := cursor.All(stmt.Context, &documents); err != nil {
+		_ = db.AddError(fmt.Errorf("failed to query %s: %w", stmt.Table, err))
+		return
+	}
+
+	db.RowsAffected = int64(len(documents))
+	m.scan(db, documents)
+
+	if db.RowsAffected == 0 && stmt.RaiseErrorOnNotFound && db.Error == nil {
+		_ = db.AddError(gorm.ErrRecordNotFound)
+	}
+}
+
+func (m *mongoConnPool) update(db *gorm.DB) {
+	if db.Error != nil {
+		return
+	}
+
+	stmt := db.Statement
+	if stmt.Schema != nil {
+		for _, c := range stmt.Schema.UpdateClauses {
+			stmt.AddClause(c)
+		}
+	}
+
+	if _, ok := stmt.Clauses["SET"]; !ok {
+		set := callbacks.ConvertToAssignments(stmt)
+		if len(set) == 0 {
+			return
+		}
+		defer delete(stmt.Clauses, "SET")
+		stmt.AddClause(set)
+	}
+
+	m.updateMany(db)
+}
+
+func (m *mongoConnPool) delete(db *gorm.DB) {
+	if db.Error != nil {
+		return
+	}
+
+	stmt := db.Statement
+	if stmt.Schema != nil {
+		// The soft delete clause turns the statement into an update of deleted_at
+		for _, c := range stmt.Schema.DeleteClauses {
+			stmt.AddClause(c)
+		}
+	}
+	if _, ok := stmt.Clauses["SET"]; ok {
+		m.updateMany(db)
+		return
+	}
+
+	if stmt.Schema != nil {
+		_, queryValues := schema.GetIdentityFieldValuesMap(stmt.Context, stmt.ReflectValue, stmt.Schema.PrimaryFields)
+		column, values := schema.ToQueryValues(stmt.Table, stmt.Schema.PrimaryFieldDBNames, queryValues)
+		if len(values) > 0 {
+			stmt.AddClause(clause.Where{Exprs: []clause.Expression{clause.IN{Column: column, Values: values}}})
+		}
+
+		if stmt.ReflectValue.CanAddr() && stmt.Dest != stmt.Model && stmt.Model != nil {
+			_, queryValues = schema.GetIdentityFieldValuesMap(stmt.Context, reflect.ValueOf(stmt.Model), stmt.Schema.PrimaryFields)
+			column, values = schema.ToQueryValues(stmt.Table, stmt.Schema.PrimaryFieldDBNames, queryValues)
+			if len(values) > 0 {
+				stmt.AddClause(clause.Where{Exprs: []clause.Expression{clause.IN{Column: column, Values: values}}})
+			}
+		}
+	}
+
+	filter, err := m.filter(stmt)
+	if err != nil {
+		_ = db.AddError(err)
+		return
+	}
+	if !checkWhereConditions(db) || db.DryRun {
+		return
+	}
+
+	database, err := m.mongoDatabase()
+	if err != nil {
+		_ = db.AddError(err)
+		return
+	}
+
+	result, err := database.Collection(stmt.Table).DeleteMany(stmt.Context, filter)
+	if err != nil {
+		_ = db.AddError(fmt.Errorf("failed to delete from %s: %w", stmt.Table, err))
+		return
+	}
+	db.RowsAffected = result.DeletedCount
+}
+
+// updateMany sets the assignments of the SET clause on the matching documents
+func (m *mongoConnPool) updateMany(db *gorm.DB) {
+	stmt := db.Statement
+	set, _ := stmt.Clauses["SET"].Expression.(clause.Set)
+
+	fields := bson.D{}
+	for _, assignment := range set {
+		value, err := toMongoValue(assignment.Value)
+		if err != nil {
+			_ = db.AddError(err)
+			return
+		}
+		key := m.key(stmt, assignment.Column.Name)
+		if key == "_id" {
+			continue
+		}
+		fields = append(fields, bson.E{Key: key, Value: value})
+	}
+
+	filter, err := m.filter(stmt)
+	if err != nil {
+		_ = db.AddError(err)
+		return
+	}
+	if !checkWhereConditions(db) || db.DryRun || len(fields) == 0 {
+		return
+	}
+
+	database, err := m.mongoDatabase()
+	if err != nil {
+		_ = db.AddError(err)
+		return
+	}
+
+	result, err := database.Collection(stmt.Table).UpdateMany(stmt.Context, filter, bson.D{{Key: "$set", Value: fields}})
+	if err != nil {
+		_ = db.AddError(fmt.Errorf("failed to update %s: %w", stmt.Table, err))
+		return
+	}
+	db.RowsAffected = result.MatchedCount
+}
+
+// filter translates the WHERE clause to a MongoDB filter
+func (m *mongoConnPool) filter(stmt *gorm.Statement) (bson.D, error) {
+	c, ok := stmt.Clauses["WHERE"]
+	if !ok {
+		return bson.D{}, nil
+	}
+	where, _ := c.Expression.(clause.Where)
+
+	return m.conditions(stmt, where.Exprs)
+}
+
+// conditions joins the expressions like clause.Where does: with AND, or with
+// OR for a single OrConditions added by db.Or
+func (m *mongoConnPool) conditions(stmt *gorm.Statement, expressions []clause.Expression) (bson.D, error) {
+	var groups bson.A
+	var current bson.A
+	for i, expression := range expressions {
+		if or, ok := expression.(clause.OrConditions); ok && len(or.Exprs) == 1 && i > 0 {
+			groups = append(groups, and(current))
+			current = nil
+			expression = or.Exprs[0]
+		}
+
+		condition, err := m.condition(stmt, expression)
+		if err != nil {
+			return nil, err
+		}
+		current = append(current, condition)
+	}
+
+	if len(groups) == 0 {
+		return and(current), nil
+	}
+
+	return bson.D{{Key: "$or", Value: append(groups, and(current))}}, nil
+}
+
+func (m *mongoConnPool) condition(stmt *gorm.Statement, expression clause.Expression) (bson.D, error) {
+	var (
+		column   interface{}
+		operator string
+		value    interface{}
+	)
+
+	switch e := expression.(type) {
+	case clause.Eq:
+		column, operator, value = e.Column, "$eq", e.Value
+		if isSlice(e.Value) {
+			operator = "$in"
+		}
+	case clause.Neq:
+		column, operator, value = e.Column, "$ne", e.Value
+		if isSlice(e.Value) {
+			operator = "$nin"
+		}
+	case clause.Gt:
+		column, operator, value = e.Column, "$gt", e.Value
+	case clause.Gte:
+		column, operator, value = e.Column, "$gte", e.Value
+	case clause.Lt:
+		column, operator, value = e.Column, "$lt", e.Value
+	case clause.Lte:
+		column, operator, value = e.Column, "$lte", e.Value
+	case clause.IN:
+		column, operator, value = e.Column, "$in", e.Values
+		if len(e.Values) == 1 && !isSlice(e.Values[0]) {
+			operator, value = "$eq", e.Values[0]
+		}
+	case clause.Like:
+		column, operator, value = e.Column, "$regex", e.Value
+	case clause.Where:
+		return m.conditions(stmt, e.Exprs)
+	case clause.AndConditions:
+		return m.conditions(stmt, e.Exprs)
+	case clause.OrConditions:
+		var conditions bson.A
+		for _, expression := range e.Exprs {
+			condition, err := m.condition(stmt, expression)
+			if err != nil {
+				return nil, err
+			}
+			conditions = append(conditions, condition)
+		}
+		return bson.D{{Key: "$or", Value: conditions}}, nil
+	case clause.NotConditions:
+		conditions, err := m.conditions(stmt, e.Exprs)
+		if err != nil {
+			return nil, err
+		}
+		return bson.D{{Key: "$nor", Value: bson.A{conditions}}}, nil
+	case clause.Expr:
+		return m.expression(stmt, e)
+	default:
+		return nil, fmt.Errorf("%w: condition %T", UnsupportedStatement, expression)
+	}
+
+	key, err := m.columnKey(stmt, column)
+	if err != nil {
+		return nil, err
+	}
+
+	return comparison(key, operator, value)
+}
+
+// expression translates the conditions written as SQL, like "age > ?",
+// "name IN ?" or "deleted_at IS NULL", joined with AND
+func (m *mongoConnPool) expression(stmt *gorm.Statement, expression clause.Expr) (bson.D, error) {
+	sql := strings.TrimSpace(expression.SQL)
+	if orPattern.MatchString(sql) || strings.Contains(strings.ReplaceAll(sql, "(?)", "?"), "(") {
+		return nil, fmt.Errorf("%w: condition %q", UnsupportedStatement, expression.SQL)
+	}
+
+	vars := expression.Vars
+	var conditions bson.A
+	for _, part := range andPattern.Split(sql, -1) {
+		if matches := nullPattern.FindStringSubmatch(part); matches != nil {
+			operator := "$eq"
+			if matches[2] != "" {
+				operator = "$ne"
+			}
+			condition, err := comparison(m.key(stmt, matches[1]), operator, nil)
+			if err != nil {
+				return nil, err
+			}
+			conditions = append(conditions, condition)
+			continue
+		}
+
+		matches := comparisonPattern.FindStringSubmatch(part)
+		if matches == nil || len(vars) == 0 {
+			return nil, fmt.Errorf("%w: condition %q", UnsupportedStatement, expression.SQL)
+		}
+
+		var operator string
+		switch strings.Join(strings.Fields(strings.ToUpper(matches[2])), " ") {
+		case "=":
+			operator = "$eq"
+		case "<>", "!=":
+			operator = "$ne"
+		case ">":
+			operator = "$gt"
+		case ">=":
+			operator = "$gte"
+		case "<":
+			operator = "$lt"
+		case "<=":
+			operator = "$lte"
+		case "IN":
+			operator = "$in"
+		case "NOT IN":
+			operator = "$nin"
+		case "LIKE":
+			operator = "$regex"
+		case "NOT LIKE":
+			operator = "$not"
+		}
+
+		condition, err := comparison(m.key(stmt, matches[1]), operator, vars[0])
+		if err != nil {
+			return nil, err
+		}
+		conditions = append(conditions, condition)
+		vars = vars[1:]
+	}
+
+	return and(conditions), nil
+}
+
+// sort translates the ORDER BY clause, Order("age desc, name") included
+func (m *mongoConnPool) sort(stmt *gorm.Statement) (bson.D, error) {
+	c, ok := stmt.Clauses["ORDER BY"]
+	if !ok {
+		return nil, nil
+	}
+	orderBy, _ := c.Expression.(clause.OrderBy)
+	if orderBy.Expression != nil {
+		return nil, fmt.Errorf("%w: ORDER BY expression", UnsupportedStatement)
+	}
+
+	sort := bson.D{}
+	for _, column := range orderBy.Columns {
+		if !column.Column.Raw {
+			direction := 1
+			if column.Desc {
+				direction = -1
+			}
+			sort = append(sort, bson.E{Key: m.key(stmt, column.Column.Name), Value: direction})
+			continue
+		}
+
+		for _, order := range strings.Split(column.Column.Name, ",") {
+			fields := strings.Fields(order)
+			if len(fields) == 0 || len(fields) > 2 {
+				return nil, fmt.Errorf("%w: ORDER BY %q", UnsupportedStatement, column.Column.Name)
+			}
+
+			direction := 1
+			if len(fields) == 2 {
+				switch strings.ToUpper(fields[1]) {
+				case "ASC":
+				case "DESC":
+					direction = -1
+				default:
+					return nil, fmt.Errorf("%w: ORDER BY %q", UnsupportedStatement, column.Column.Name)
+				}
+			}
+			sort = append(sort, bson.E{Key: m.key(stmt, fields[0]), Value: direction})
+		}
+	}
+
+	return sort, nil
+}
+
+// scan sets the documents on the destination, a struct, a map or a slice of them
+func (m *mongoConnPool) scan(db *gorm.DB, documents []bson.M) {
+	value := db.Statement.ReflectValue
+
+	switch value.Kind() {
+	case reflect.Slice, reflect.Array:
+		elemType := value.Type().Elem()
+		isPtr := elemType.Kind() == reflect.Ptr
+		if isPtr {
+			elemType = elemType.Elem()
+		}
+
+		value.Set(reflect.MakeSlice(value.Type(), 0, len(documents)))
+		for _, document := range documents {
+			elem := reflect.New(elemType).Elem()
+			if err := m.assign(db.Statement, document, elem); err != nil {
+				_ = db.AddError(err)
+				return
+			}
+			if isPtr {
+				elem = elem.Addr()
+			}
+			value.Set(reflect.Append(value, elem))
+		}
+	default:
+		if len(documents) > 0 {
+			_ = db.AddError(m.assign(db.Statement, documents[0], value))
+		}
+	}
+}
+
+// assign sets a document on a struct or a map, or the selected column on
+// another value like Pluck does
+func (m *mongoConnPool) assign(stmt *gorm.Statement, document bson.M, value reflect.Value) error {
+	switch value.Kind() {
+	case reflect.Struct:
+		modelSchema := stmt.Schema
+		if modelSchema == nil || modelSchema.ModelType != value.Type() {
+			var err error
+			if modelSchema, err = schema.Parse(value.Addr().Interface(), &m.schemas, stmt.DB.NamingStrategy); err != nil {
+				return err
+			}
+		}
+
+		for _, field := range modelSchema.Fields {
+			if field.DBName == "" {
+				continue
+			}
+			key := field.DBName
+			if modelSchema.PrioritizedPrimaryField == field {
+				key = "_id"
+			}
+			fieldValue, ok := document[key]
+			if !ok {
+				continue
+			}
+			if err := field.Set(stmt.Context, value, fromMongoValue(fieldValue, field.IndirectFieldType)); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	case reflect.Map:
+		if value.Type().Key().Kind() != reflect.String {
+			break
+		}
+		if value.IsNil() {
+			value.Set(reflect.MakeMap(value.Type()))
+		}
+		for key, fieldValue := range document {
+			if key == "_id" && stmt.Schema != nil && stmt.Schema.PrioritizedPrimaryField != nil {
+				key = stmt.Schema.PrioritizedPrimaryField.DBName
+			}
+			element := reflect.ValueOf(fromMongoValue(fieldValue, value.Type().Elem()))
+			if !element.IsValid() {
+				element = reflect.Zero(value.Type().Elem())
+			}
+			if !element.Type().AssignableTo(value.Type().Elem()) {
+				return fmt.Errorf("%w: scanning %s into %s", UnsupportedStatement, key, value.Type())
+			}
+			value.SetMapIndex(reflect.ValueOf(key).Convert(value.Type().Key()), element)
+		}
+
+		return nil
+	default:
+		if columns := selectedColumns(stmt); len(columns) == 1 {
+			fieldValue := reflect.ValueOf(fromMongoValue(document[m.key(stmt, columns[0])], value.Type()))
+			if !fieldValue.IsValid() {
+				return nil
+			}
+			if fieldValue.Type().ConvertibleTo(value.Type()) {
+				value.Set(fieldValue.Convert(value.Type()))
+				return nil
+			}
+		}
+	}
+
+	return fmt.Errorf("%w: scanning into %s", UnsupportedStatement, value.Type())
+}
+
+// generateID returns the next value of the integer primary keys, or an
+// ObjectID, as a hex string for string primary keys
+func (m *mongoConnPool) generateID(stmt *gorm.Statement, database *mongo.Database) (interface{}, error) {
+	var fieldType reflect.Type
+	if stmt.Schema != nil && stmt.Schema.PrioritizedPrimaryField != nil {
+		fieldType = stmt.Schema.PrioritizedPrimaryField.IndirectFieldType
+	}
+	if fieldType == nil {
+		return primitive.NewObjectID(), nil
+	}
+
+	kind := fieldType.Kind()
+	// The nullable keys, like sql.NullString, are generated like the type they scan
+	if scanner, ok := reflect.New(fieldType).Interface().(sql.Scanner); ok {
+		if scanner.Scan("") == nil {
+			kind = reflect.String
+		} else if scanner.Scan(int64(0)) == nil {
+			kind = reflect.Int64
+		}
+	}
+
+	switch kind {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		var sequence struct {
+			Value int64 `bson:"value"`
+		}
+		err := database.Collection(SequencesCollection).FindOneAndUpdate(
+			stmt.Context,
+			bson.D{{Key: "_id", Value: stmt.Table}},
+			bson.D{{Key: "$inc", Value: bson.D{{Key: "value", Value: int64(1)}}}},
+			options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After),
+		).Decode(&sequence)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate the id of %s: %w", stmt.Table, err)
+		}
+
+		return sequence.Value, nil
+	case reflect.String:
+		return primitive.NewObjectID().Hex(), nil
+	default:
+		return primitive.NewObjectID(), nil
+	}
+}
+
+// setPrimaryKey sets the generated id on the created model of the row
+func (m *mongoConnPool) setPrimaryKey(stmt *gorm.Statement, row int, id interface{}) {
+	if stmt.Schema == nil || stmt.Schema.PrioritizedPrimaryField == nil {
+		return
+	}
+
+	value := stmt.ReflectValue
+	switch value.Kind() {
+	case reflect.Slice, reflect.Array:
+		value = reflect.Indirect(value.Index(row))
+	case reflect.Struct:
+	default:
+		return
+	}
+
+	if value.Kind() == reflect.Struct {
+		_ = stmt.AddError(stmt.Schema.PrioritizedPrimaryField.Set(stmt.Context, value, id))
+	}
+}
+
+// columnKey returns the document key of a clause column
+func (m *mongoConnPool) columnKey(stmt *gorm.Statement, column interface{}) (string, error) {
+	switch column := column.(type) {
+	case string:
+		return m.key(stmt, column), nil
+	case clause.Column:
+		return m.key(stmt, column.Name), nil
+	}
+
+	return "", fmt.Errorf("%w: column %T", UnsupportedStatement, column)
+}
+
+// key returns the document key of a column, the primary key is stored as _id
+func (m *mongoConnPool) key(stmt *gorm.Statement, column string) string {
+	column = strings.NewReplacer("`", "", `"`, "").Replace(column)
+	column = strings.TrimPrefix(column, clause.CurrentTable+".")
+	if stmt.Table != "" {
+		column = strings.TrimPrefix(column, stmt.Table+".")
+	}
+
+	if column == clause.PrimaryKey {
+		return "_id"
+	}
+	if stmt.Schema != nil && stmt.Schema.PrioritizedPrimaryField != nil && stmt.Schema.PrioritizedPrimaryField.DBName == column {
+		return "_id"
+	}
+
+	return column
+}
+
+// unsupportedQuery reports the query features the dialector cannot translate
+func unsupportedQuery(stmt *gorm.Statement) error {
+	switch {
+	case len(stmt.Joins) > 0:
+		return fmt.Errorf("%w: joins", UnsupportedStatement)
+	case stmt.Distinct:
+		return fmt.Errorf("%w: distinct", UnsupportedStatement)
+	}
+	for _, name := range []string{"GROUP BY", "HAVING"} {
+		if _, ok := stmt.Clauses[name]; ok {
+			return fmt.Errorf("%w: %s", UnsupportedStatement, name)
+		}
+	}
+
+	return nil
+}
+
+// isCount reports whether the statement is built by db.Count, which sets the
+// count(*) or COUNT(?) expression as the SELECT clause
+func isCount(stmt *gorm.Statement) bool {
+	expression, ok := stmt.Clauses["SELECT"].Expression.(clause.Expr)
+
+	return ok && strings.HasPrefix(strings.ToLower(expression.SQL), "count(")
+}
+
+// selectedColumns returns the columns of Select, or of Pluck
+func selectedColumns(stmt *gorm.Statement) []string {
+	if len(stmt.Selects) > 0 {
+		return stmt.Selects
+	}
+
+	c, ok := stmt.Clauses["SELECT"]
+	if !ok {
+		return nil
+	}
+	selectClause, _ := c.Expression.(clause.Select)
+	columns := make([]string, len(selectClause.Columns))
+	for i, column := range selectClause.Columns {
+		columns[i] = column.Name
+	}
+
+	return columns
+}
+
+// checkWhereConditions fails the updates and deletes without conditions like
+// GORM does, unless AllowGlobalUpdate is set
+func checkWhereConditions(db *gorm.DB) bool {
+	if db.AllowGlobalUpdate {
+		return true
+	}
+
+	where, ok := db.Statement.Clauses["WHERE"]
+	if ok {
+		if _, withSoftDelete := db.Statement.Clauses["soft_delete_enabled"]; withSoftDelete {
+			whereClause, _ := where.Expression.(clause.Where)
+			ok = len(whereClause.Exprs) > 1
+		}
+	}
+	if !ok {
+		_ = db.AddError(gorm.ErrMissingWhereClause)
+	}
+
+	return ok
+}
+
+func comparison(key, operator string, value interface{}) (bson.D, error) {
+	value, err := toMongoValue(value)
+	if err != nil {
+		return nil, err
+	}
+
+	switch operator {
+	case "$eq":
+		return bson.D{{Key: key, Value: value}}, nil
+	case "$in", "$nin":
+		if _, ok := value.(bson.A); !ok {
+			value = bson.A{value}
+		}
+	case "$regex", "$not":
+		pattern, ok := value.(string)
+		if !ok {
+			return nil, fmt.Errorf("%w: LIKE with %T", UnsupportedStatement, value)
+		}
+		value = primitive.Regex{Pattern: likeToRegex(pattern)}
+	}
+
+	return bson.D{{Key: key, Value: bson.D{{Key: operator, Value: value}}}}, nil
+}
+
+func and(conditions bson.A) bson.D {
+	switch len(conditions) {
+	case 0:
+		return bson.D{}
+	case 1:
+		return conditions[0].(bson.D)
+	}
+
+	return bson.D{{Key: "$and", Value: conditions}}
+}
+
+// likeToRegex converts a LIKE pattern to an anchored regular expression
+func likeToRegex(pattern string) string {
+	var builder strings.Builder
+	builder.WriteString("^")
+	for _, r := range pattern {
+		switch r {
+		case '%':
+			builder.WriteString(".*")
+		case '_':
+			builder.WriteString(".")
+		default:
+			builder.WriteString(regexp.QuoteMeta(string(r)))
+		}
+	}
+	builder.WriteString("$")
+
+	return builder.String()
+}
+
+// toMongoValue converts the values GORM binds to the values stored in
+// MongoDB, SQL expressions cannot be translated
+func toMongoValue(value interface{}) (interface{}, error) {
+	switch v := value.(type) {
+	case nil:
+		return nil, nil
+	case clause.Expression:
+		return nil, fmt.Errorf("%w: SQL expression %T", UnsupportedStatement, value)
+	case driver.Valuer:
+		reflected := reflect.ValueOf(v)
+		if reflected.Kind() == reflect.Ptr && reflected.IsNil() {
+			return nil, nil
+		}
+		converted, err := v.Value()
+		if err != nil {
+			return nil, err
+		}
+		return converted, nil
+	case []byte:
+		return v, nil
+	}
+
+	if isSlice(value) {
+		reflected := reflect.ValueOf(value)
+		array := make(bson.A, reflected.Len())
+		for i := range array {
+			element, err := toMongoValue(reflected.Index(i).Interface())
+			if err != nil {
+				return nil, err
+			}
+			array[i] = element
+		}
+		return array, nil
+	}
+
+	return value, nil
+}
+
+// fromMongoValue converts a stored value for a field of the type
+func fromMongoValue(value interface{}, fieldType reflect.Type) interface{} {
+	switch v := value.(type) {
+	case primitive.DateTime:
+		return v.Time()
+	case primitive.ObjectID:
+		if fieldType != nil && fieldType.Kind() == reflect.String {
+			return v.Hex()
+		}
+	}
+
+	return value
+}
+
+func isSlice(value interface{}) bool {
+	if _, ok := value.([]byte); ok {
+		return false
+	}
+	kind := reflect.ValueOf(value).Kind()
+
+	return kind == reflect.Slice || kind == reflect.Array
+}
+
+func lookupElement(document bson.D, key string) (interface{}, bool) {
+	for _, element := range document {
+		if element.Key == key {
+			return element.Value, true
+		}
+	}
+
+	return nil, false
+}
@@ -0,0 +1,151 @@
+package mongodb
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type statementUser struct {
+	ID        uint
+	Name      string
+	Age       int
+	DeletedAt gorm.DeletedAt
+}
+
+type StatementSuite struct {
+	suite.Suite
+	db   *gorm.DB
+	pool *mongoConnPool
+}
+
+func TestStatementSuite(t *testing.T) {
+	suite.Run(t, &StatementSuite{})
+}
+
+func (s *StatementSuite) SetupTest() {
+	db, err := gorm.Open(Open("mongodb://localhost:27017/goravel"), &gorm.Config{DryRun: true})
+	s.Require().NoError(err)
+	s.db = db
+	s.pool = &mongoConnPool{}
+}
+
+func (s *StatementSuite) TestFilter() {
+	tests := []struct {
+		name     string
+		query    func(db *gorm.DB) *gorm.DB
+		expected bson.D
+	}{
+		{
+			name: "primary key",
+			query: func(db *gorm.DB) *gorm.DB {
+				return db.First(&statementUser{}, 1)
+			},
+			expected: bson.D{{Key: "$and", Value: bson.A{
+				bson.D{{Key: "_id", Value: 1}},
+				bson.D{{Key: "deleted_at", Value: nil}},
+			}}},
+		},
+		{
+			name: "struct primary key",
+			query: func(db *gorm.DB) *gorm.DB {
+				return db.Unscoped().First(&statementUser{ID: 2})
+			},
+			expected: bson.D{{Key: "_id", Value: uint(2)}},
+		},
+		{
+			name: "comparisons",
+			query: func(db *gorm.DB) *gorm.DB {
+				return db.Unscoped().Where("age >= ? AND name <> ?", 18, "Goravel").Find(&[]statementUser{})
+			},
+			expected: bson.D{{Key: "$and", Value: bson.A{
+				bson.D{{Key: "age", Value: bson.D{{Key: "$gte", Value: 18}}}},
+				bson.D{{Key: "name", Value: bson.D{{Key: "$ne", Value: "Goravel"}}}},
+			}}},
+		},
+		{
+			name: "in and like",
+			query: func(db *gorm.DB) *gorm.DB {
+				return db.Unscoped().Where("`statement_users`.`name` IN ?", []string{"Goravel", "Laravel"}).Where("name NOT LIKE ?", "G_r%").Find(&[]statementUser{})
+			},
+			expected: bson.D{{Key: "$and", Value: bson.A{
+				bson.D{{Key: "name", Value: bson.D{{Key: "$in", Value: bson.A{"Goravel", "Laravel"}}}}},
+				bson.D{{Key: "name", Value: bson.D{{Key: "$not", Value: primitive.Regex{Pattern: "^G.r.*$"}}}}},
+			}}},
+		},
+		{
+			name: "or",
+			query: func(db *gorm.DB) *gorm.DB {
+				return db.Unscoped().Where("age", 18).Where("name = ?", "Goravel").Or("age > ?", 30).Find(&[]statementUser{})
+			},
+			expected: bson.D{{Key: "$or", Value: bson.A{
+				bson.D{{Key: "$and", Value: bson.A{
+					bson.D{{Key: "age", Value: 18}},
+					bson.D{{Key: "name", Value: "Goravel"}},
+				}}},
+				bson.D{{Key: "age", Value: bson.D{{Key: "$gt", Value: 30}}}},
+			}}},
+		},
+		{
+			name: "not and struct conditions",
+			query: func(db *gorm.DB) *gorm.DB {
+				return db.Unscoped().Not(map[string]interface{}{"age": []int{18, 20}}).Where(&statementUser{Name: "Goravel"}).Find(&[]statementUser{})
+			},
+			expected: bson.D{{Key: "$and", Value: bson.A{
+				bson.D{{Key: "$nor", Value: bson.A{bson.D{{Key: "age", Value: bson.D{{Key: "$in", Value: bson.A{18, 20}}}}}}}},
+				bson.D{{Key: "name", Value: "Goravel"}},
+			}}},
+		},
+		{
+			name: "null",
+			query: func(db *gorm.DB) *gorm.DB {
+				return db.Unscoped().Where("deleted_at IS NOT NULL").Find(&[]statementUser{})
+			},
+			expected: bson.D{{Key: "deleted_at", Value: bson.D{{Key: "$ne", Value: nil}}}},
+		},
+	}
+
+	for _, test := range tests {
+		s.Run(test.name, func() {
+			tx := test.query(s.db)
+			s.Require().NoError(tx.Error)
+
+			filter, err := s.pool.filter(tx.Statement)
+			s.NoError(err)
+			s.Equal(test.expected, filter)
+		})
+	}
+}
+
+func (s *StatementSuite) TestFilterUnsupported() {
+	for _, query := range []string{"age > ? OR age < ?", "(age > ?)", "lower(name) = ?", "age BETWEEN ? AND ?"} {
+		s.Run(query, func() {
+			tx := s.db.Where(query, 1, 2).Find(&[]statementUser{})
+			s.ErrorIs(tx.Error, UnsupportedStatement)
+		})
+	}
+
+	s.ErrorIs(s.db.Joins("Company").Find(&[]statementUser{}).Error, UnsupportedStatement)
+	s.ErrorIs(s.db.Group("age").Find(&[]statementUser{}).Error, UnsupportedStatement)
+}
+
+func (s *StatementSuite) TestSort() {
+	tx := s.db.Order("age desc, name").Order(clause.OrderByColumn{Column: clause.Column{Name: "id"}, Desc: true}).Find(&[]statementUser{})
+	s.Require().NoError(tx.Error)
+
+	sort, err := s.pool.sort(tx.Statement)
+	s.NoError(err)
+	s.Equal(bson.D{{Key: "age", Value: -1}, {Key: "name", Value: 1}, {Key: "_id", Value: -1}}, sort)
+
+	tx = s.db.Order("age sideways").Find(&[]statementUser{})
+	s.ErrorIs(tx.Error, UnsupportedStatement)
+}
+
+func (s *StatementSuite) TestLikeToRegex() {
+	s.Equal("^go.*vel$", likeToRegex("go%vel"))
+	s.Equal(`^a\.b.$`, likeToRegex("a.b_"))
+}
@@ -0,0 +1,58 @@
+package mongodb
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// The kinds of deployment topologyOf reports
+const (
+	TopologyStandalone = "standalone"
+	TopologyReplicaSet = "replica set"
+	TopologySharded    = "sharded"
+	TopologyUnknown    = "unknown"
+)
+
+// topology describes the deployment as the hello command reports it
+type topology struct {
+	Kind    string
+	SetName string
+	Primary string
+	Hosts   []string
+}
+
+// topologyOf runs hello to find out the kind of the deployment, a nil client
+// has an unknown topology
+func topologyOf(client *mongo.Client) (*topology, error) {
+	if client == nil {
+		return &topology{Kind: TopologyUnknown}, nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var hello struct {
+		Msg     string   `bson:"msg"`
+		SetName string   `bson:"setName"`
+		Primary string   `bson:"primary"`
+		Me      string   `bson:"me"`
+		Hosts   []string `bson:"hosts"`
+	}
+	if err := client.Database("admin").RunCommand(ctx, bson.D{{Key: "hello", Value: 1}}).Decode(&hello); err != nil {
+		return nil, fmt.Errorf("failed to get the topology: %w", err)
+	}
+
+	result := &topology{Kind: TopologyStandalone, SetName: hello.SetName, Primary: hello.Primary, Hosts: hello.Hosts}
+	switch {
+	case hello.Msg == "isdbgrid":
+		result.Kind = TopologySharded
+	case hello.SetName != "":
+		result.Kind = TopologyReplicaSet
+	}
+
+	return result, nil
+}
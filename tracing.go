@@ -0,0 +1,102 @@
+package mongodb
+
+import (
+	"context"
+	"sync"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/event"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// The name of the tracer of the commands
+const tracerName = "github.com/portofolio-mager/goravel-mongodb"
+
+// commandTracer starts a span for each command, as a child of the span of the
+// context the command runs with
+type commandTracer struct {
+	tracer trace.Tracer
+	// spans are the spans of the running commands, by request
+	spans sync.Map
+}
+
+// newCommandTracer traces the commands with the provider, the global one when
+// it is nil
+func newCommandTracer(provider trace.TracerProvider) *commandTracer {
+	if provider == nil {
+		provider = otel.GetTracerProvider()
+	}
+
+	return &commandTracer{
+		tracer: provider.Tracer(tracerName, trace.WithSchemaURL(semconv.SchemaURL)),
+	}
+}
+
+// monitor returns the driver monitor tracing the commands
+func (c *commandTracer) monitor() *event.CommandMonitor {
+	return &event.CommandMonitor{
+		Started: func(ctx context.Context, started *event.CommandStartedEvent) {
+			name := started.CommandName + " " + started.DatabaseName
+			attributes := []attribute.KeyValue{
+				semconv.DBSystemMongoDB,
+				semconv.DBName(started.DatabaseName),
+				semconv.DBOperation(started.CommandName),
+			}
+			if collection := commandCollection(started.Command); collection != "" {
+				name += "." + collection
+				attributes = append(attributes, semconv.DBMongoDBCollection(collection))
+			}
+			if filter := commandFilter(started.Command); filter != nil {
+				attributes = append(attributes, semconv.DBStatement(extJSON(sanitize(filter))))
+			}
+
+			_, span := c.tracer.Start(ctx, name, trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(attributes...))
+			c.spans.Store(commandKey(started.ConnectionID, started.RequestID), span)
+		},
+		Succeeded: func(_ context.Context, succeeded *event.CommandSucceededEvent) {
+			if span, ok := c.span(succeeded.CommandFinishedEvent); ok {
+				span.End()
+			}
+		},
+		Failed: func(_ context.Context, failed *event.CommandFailedEvent) {
+			if span, ok := c.span(failed.CommandFinishedEvent); ok {
+				span.SetStatus(codes.Error, failed.Failure)
+				span.End()
+			}
+		},
+	}
+}
+
+func (c *commandTracer) span(finished event.CommandFinishedEvent) (trace.Span, bool) {
+	span, ok := c.spans.LoadAndDelete(commandKey(finished.ConnectionID, finished.RequestID))
+	if !ok {
+		return nil, false
+	}
+
+	return span.(trace.Span), true
+}
+
+// sanitize replaces the values of a filter with ?, keeping its fields and
+// operators
+func sanitize(value interface{}) interface{} {
+	switch value := value.(type) {
+	case bson.D:
+		document := make(bson.D, len(value))
+		for i, element := range value {
+			document[i] = bson.E{Key: element.Key, Value: sanitize(element.Value)}
+		}
+		return document
+	case bson.A:
+		array := make(bson.A, len(value))
+		for i, element := range value {
+			array[i] = sanitize(element)
+		}
+		return array
+	}
+
+	return "?"
+}
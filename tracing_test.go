@@ -0,0 +1,122 @@
+package mongodb
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/event"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type TracingTestSuite struct {
+	suite.Suite
+	exporter *tracetest.InMemoryExporter
+	provider *sdktrace.TracerProvider
+}
+
+func TestTracingTestSuite(t *testing.T) {
+	suite.Run(t, new(TracingTestSuite))
+}
+
+func (s *TracingTestSuite) SetupTest() {
+	s.exporter = tracetest.NewInMemoryExporter()
+	s.provider = sdktrace.NewTracerProvider(sdktrace.WithSyncer(s.exporter))
+}
+
+func (s *TracingTestSuite) TearDownTest() {
+	s.NoError(s.provider.Shutdown(context.Background()))
+}
+
+func (s *TracingTestSuite) TestCommandTracer() {
+	monitor := newCommandTracer(s.provider).monitor()
+	ctx, parent := s.provider.Tracer("test").Start(context.Background(), "request")
+
+	monitor.Started(ctx, &event.CommandStartedEvent{
+		Command: mustMarshal(bson.D{
+			{Key: "find", Value: "users"},
+			{Key: "filter", Value: bson.D{{Key: "email", Value: "goravel@example.com"}, {Key: "age", Value: bson.D{{Key: "$in", Value: bson.A{18, 30}}}}}},
+		}),
+		DatabaseName: "goravel",
+		CommandName:  "find",
+		RequestID:    1,
+		ConnectionID: "localhost:27017[-1]",
+	})
+	monitor.Started(ctx, &event.CommandStartedEvent{
+		Command:      mustMarshal(bson.D{{Key: "ping", Value: 1}}),
+		DatabaseName: "admin",
+		CommandName:  "ping",
+		RequestID:    1,
+		ConnectionID: "localhost:27017[-2]",
+	})
+	monitor.Succeeded(ctx, &event.CommandSucceededEvent{CommandFinishedEvent: event.CommandFinishedEvent{
+		RequestID:    1,
+		ConnectionID: "localhost:27017[-1]",
+		Duration:     time.Millisecond,
+	}})
+	monitor.Failed(ctx, &event.CommandFailedEvent{CommandFinishedEvent: event.CommandFinishedEvent{
+		RequestID:    1,
+		ConnectionID: "localhost:27017[-2]",
+	}, Failure: "(Unauthorized) not authorized"})
+	parent.End()
+
+	// A command finishing without starting has no span
+	monitor.Succeeded(ctx, &event.CommandSucceededEvent{CommandFinishedEvent: event.CommandFinishedEvent{RequestID: 2}})
+
+	spans := s.exporter.GetSpans()
+	s.Require().Len(spans, 3)
+
+	find := spans[0]
+	s.Equal("find goravel.users", find.Name)
+	s.Equal(trace.SpanKindClient, find.SpanKind)
+	s.Equal(parent.SpanContext().SpanID(), find.Parent.SpanID())
+	s.Equal(tracerName, find.InstrumentationScope.Name)
+	s.Equal([]attribute.KeyValue{
+		attribute.String("db.system", "mongodb"),
+		attribute.String("db.name", "goravel"),
+		attribute.String("db.operation", "find"),
+		attribute.String("db.mongodb.collection", "users"),
+		attribute.String("db.statement", `{"email":"?","age":{"$in":["?","?"]}}`),
+	}, find.Attributes)
+	s.Equal(codes.Unset, find.Status.Code)
+
+	ping := spans[1]
+	s.Equal("ping admin", ping.Name)
+	s.Equal([]attribute.KeyValue{
+		attribute.String("db.system", "mongodb"),
+		attribute.String("db.name", "admin"),
+		attribute.String("db.operation", "ping"),
+	}, ping.Attributes)
+	s.Equal(codes.Error, ping.Status.Code)
+	s.Equal("(Unauthorized) not authorized", ping.Status.Description)
+}
+
+func (s *TracingTestSuite) TestGlobalTracerProvider() {
+	global := otel.GetTracerProvider()
+	defer otel.SetTracerProvider(global)
+	otel.SetTracerProvider(s.provider)
+
+	monitor := newCommandTracer(nil).monitor()
+	monitor.Started(context.Background(), &event.CommandStartedEvent{DatabaseName: "admin", CommandName: "ping", RequestID: 1})
+	monitor.Succeeded(context.Background(), &event.CommandSucceededEvent{CommandFinishedEvent: event.CommandFinishedEvent{RequestID: 1}})
+
+	s.Len(s.exporter.GetSpans(), 1)
+}
+
+func (s *TracingTestSuite) TestSanitize() {
+	s.Equal("?", sanitize("Goravel"))
+	s.Equal(bson.A{
+		bson.D{{Key: "$match", Value: bson.D{{Key: "name", Value: "?"}}}},
+		bson.D{{Key: "$limit", Value: "?"}},
+	}, sanitize(bson.A{
+		bson.D{{Key: "$match", Value: bson.D{{Key: "name", Value: "Goravel"}}}},
+		bson.D{{Key: "$limit", Value: 10}},
+	}))
+}
@@ -0,0 +1,349 @@
+package mongodb
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsoncodec"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// The validation levels and actions of Collection.SetValidator
+const (
+	ValidationLevelOff      = "off"
+	ValidationLevelStrict   = "strict"
+	ValidationLevelModerate = "moderate"
+	ValidationActionError   = "error"
+	ValidationActionWarn    = "warn"
+)
+
+// The types with a BSON type of their own, checked before their kind
+var bsonTypes = map[reflect.Type]string{
+	reflect.TypeOf(time.Time{}):            "date",
+	reflect.TypeOf(primitive.DateTime(0)):  "date",
+	reflect.TypeOf(primitive.ObjectID{}):   "objectId",
+	reflect.TypeOf(primitive.Decimal128{}): "decimal",
+	reflect.TypeOf(primitive.Binary{}):     "binData",
+	reflect.TypeOf([]byte(nil)):            "binData",
+	reflect.TypeOf(primitive.Timestamp{}):  "timestamp",
+	reflect.TypeOf(primitive.Regex{}):      "regex",
+	reflect.TypeOf(bson.D{}):               "object",
+	reflect.TypeOf(bson.M{}):               "object",
+	reflect.TypeOf(bson.Raw{}):             "object",
+	reflect.TypeOf(bson.A{}):               "array",
+}
+
+// The interfaces of the types that marshal themselves, their BSON type is
+// unknown so any value is accepted
+var marshalerTypes = []reflect.Type{
+	reflect.TypeOf((*bson.Marshaler)(nil)).Elem(),
+	reflect.TypeOf((*bson.ValueMarshaler)(nil)).Elem(),
+}
+
+// Validator generates the $jsonSchema validator of a struct, so MongoDB
+// enforces the shape of the model. The fields are named by their bson tags and
+// typed by their Go types, pointers, slices and maps may also be null. The
+// validate tag adds the required, oneof, min, max and len rules:
+//
+//	type User struct {
+//		Name string `bson:"name" validate:"required,min=2,max=50"`
+//		Role string `bson:"role" validate:"oneof=admin user"`
+//	}
+func Validator(model interface{}) (bson.D, error) {
+	modelType, ok := structType(model)
+	if !ok {
+		return nil, fmt.Errorf("%T is not a struct", model)
+	}
+
+	schema, err := objectSchema(modelType, make(map[reflect.Type]bool))
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate the validator of %s: %w", modelType, err)
+	}
+
+	return bson.D{{Key: "$jsonSchema", Value: schema}}, nil
+}
+
+// structType returns the type of a struct or a pointer to one, the BSON
+// documents are not models
+func structType(model interface{}) (reflect.Type, bool) {
+	modelType := reflect.TypeOf(model)
+	for modelType != nil && modelType.Kind() == reflect.Ptr {
+		modelType = modelType.Elem()
+	}
+	if modelType == nil || modelType.Kind() != reflect.Struct {
+		return nil, false
+	}
+	if _, ok := bsonTypes[modelType]; ok {
+		return nil, false
+	}
+
+	return modelType, true
+}
+
+// objectSchema returns the schema of a struct, seen stops at the types
+// containing themselves
+func objectSchema(structType reflect.Type, seen map[reflect.Type]bool) (bson.D, error) {
+	schema := bson.D{{Key: "bsonType", Value: "object"}}
+	if seen[structType] {
+		return schema, nil
+	}
+	seen[structType] = true
+	defer delete(seen, structType)
+
+	var properties bson.D
+	var required bson.A
+	if err := fieldSchemas(structType, seen, &properties, &required); err != nil {
+		return nil, err
+	}
+
+	if len(properties) > 0 {
+		schema = append(schema, bson.E{Key: "properties", Value: properties})
+	}
+	if len(required) > 0 {
+		schema = append(schema, bson.E{Key: "required", Value: required})
+	}
+
+	return schema, nil
+}
+
+// fieldSchemas adds the fields of a struct to the properties, the inlined
+// structs add theirs
+func fieldSchemas(structType reflect.Type, seen map[reflect.Type]bool, properties *bson.D, required *bson.A) error {
+	for i := 0; i < structType.NumField(); i++ {
+		field := structType.Field(i)
+		if field.PkgPath != "" && !field.Anonymous {
+			continue
+		}
+
+		tags, err := bsoncodec.DefaultStructTagParser.ParseStructTags(field)
+		if err != nil {
+			return err
+		}
+		if tags.Skip {
+			continue
+		}
+
+		if tags.Inline {
+			fieldType := field.Type
+			if fieldType.Kind() == reflect.Ptr {
+				fieldType = fieldType.Elem()
+			}
+			// An inlined map holds the fields the struct does not declare
+			if fieldType.Kind() == reflect.Struct {
+				if err := fieldSchemas(fieldType, seen, properties, required); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+		if field.PkgPath != "" {
+			continue
+		}
+
+		schema, err := valueSchema(field.Type, seen)
+		if err != nil {
+			return fmt.Errorf("field %s: %w", field.Name, err)
+		}
+
+		isRequired, err := applyRules(&schema, field.Type, field.Tag.Get("validate"))
+		if err != nil {
+			return fmt.Errorf("field %s: %w", field.Name, err)
+		}
+		if isRequired {
+			*required = append(*required, tags.Name)
+		}
+
+		*properties = append(*properties, bson.E{Key: tags.Name, Value: schema})
+	}
+
+	return nil
+}
+
+// valueSchema returns the schema of a field type, an empty schema accepts any
+// value
+func valueSchema(valueType reflect.Type, seen map[reflect.Type]bool) (bson.D, error) {
+	nullable := false
+	for valueType.Kind() == reflect.Ptr {
+		valueType = valueType.Elem()
+		nullable = true
+	}
+
+	var schema bson.D
+	if bsonType, ok := bsonTypes[valueType]; ok {
+		schema = bson.D{{Key: "bsonType", Value: bsonType}}
+		nullable = nullable || valueType.Kind() == reflect.Slice || valueType.Kind() == reflect.Map
+	} else if marshals(valueType) {
+		return bson.D{}, nil
+	} else {
+		switch valueType.Kind() {
+		case reflect.Bool:
+			schema = bson.D{{Key: "bsonType", Value: "bool"}}
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+			reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			// The driver writes the integers that fit 32 bits as int
+			schema = bson.D{{Key: "bsonType", Value: bson.A{"int", "long"}}}
+		case reflect.Float32, reflect.Float64:
+			schema = bson.D{{Key: "bsonType", Value: "double"}}
+		case reflect.String:
+			schema = bson.D{{Key: "bsonType", Value: "string"}}
+		case reflect.Interface:
+			return bson.D{}, nil
+		case reflect.Struct:
+			var err error
+			if schema, err = objectSchema(valueType, seen); err != nil {
+				return nil, err
+			}
+		case reflect.Map:
+			if valueType.Key().Kind() != reflect.String {
+				return nil, fmt.Errorf("map keys of type %s cannot be stored in MongoDB", valueType.Key())
+			}
+			schema = bson.D{{Key: "bsonType", Value: "object"}}
+			nullable = true
+		case reflect.Array:
+			// The driver writes the byte arrays as binary data
+			if valueType.Elem().Kind() == reflect.Uint8 {
+				schema = bson.D{{Key: "bsonType", Value: "binData"}}
+				break
+			}
+			fallthrough
+		case reflect.Slice:
+			items, err := valueSchema(valueType.Elem(), seen)
+			if err != nil {
+				return nil, err
+			}
+			schema = bson.D{{Key: "bsonType", Value: "array"}}
+			if len(items) > 0 {
+				schema = append(schema, bson.E{Key: "items", Value: items})
+			}
+			// The driver writes nil slices as null
+			nullable = valueType.Kind() == reflect.Slice
+		default:
+			return nil, fmt.Errorf("type %s cannot be stored in MongoDB", valueType)
+		}
+	}
+
+	if nullable {
+		schema[0].Value = withNull(schema[0].Value)
+	}
+
+	return schema, nil
+}
+
+// marshals reports whether the type, or a pointer to it, marshals itself
+func marshals(valueType reflect.Type) bool {
+	for _, marshalerType := range marshalerTypes {
+		if valueType.Implements(marshalerType) || reflect.PointerTo(valueType).Implements(marshalerType) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// withNull adds null to the BSON types
+func withNull(bsonType interface{}) bson.A {
+	if types, ok := bsonType.(bson.A); ok {
+		return append(append(bson.A{}, types...), "null")
+	}
+
+	return bson.A{bsonType, "null"}
+}
+
+// applyRules adds the rules of a validate tag to the schema and reports
+// whether the field is required, the rules after dive apply to the elements
+// and are skipped like the unknown ones
+func applyRules(schema *bson.D, fieldType reflect.Type, tag string) (bool, error) {
+	for fieldType.Kind() == reflect.Ptr {
+		fieldType = fieldType.Elem()
+	}
+
+	required := false
+	for _, rule := range strings.Split(tag, ",") {
+		name, parameter, _ := strings.Cut(strings.TrimSpace(rule), "=")
+		switch name {
+		case "dive":
+			return required, nil
+		case "required":
+			required = true
+		case "oneof":
+			// The values of the marshalers are stored in their own form
+			if marshals(fieldType) {
+				continue
+			}
+
+			var enum bson.A
+			for _, value := range strings.Fields(parameter) {
+				converted, err := ruleValue(fieldType, strings.Trim(value, "'"))
+				if err != nil {
+					return false, fmt.Errorf("invalid oneof value %q: %w", value, err)
+				}
+				enum = append(enum, converted)
+			}
+			*schema = append(*schema, bson.E{Key: "enum", Value: enum})
+		case "min", "gte", "max", "lte", "len":
+			keys := boundKeys(fieldType)
+			if keys == nil {
+				continue
+			}
+
+			bound, err := ruleValue(fieldType, parameter)
+			if keys[0] != "minimum" {
+				bound, err = strconv.ParseInt(parameter, 10, 64)
+			}
+			if err != nil {
+				return false, fmt.Errorf("invalid %s value %q: %w", name, parameter, err)
+			}
+
+			if name != "max" && name != "lte" {
+				*schema = append(*schema, bson.E{Key: keys[0], Value: bound})
+			}
+			if name != "min" && name != "gte" {
+				*schema = append(*schema, bson.E{Key: keys[1], Value: bound})
+			}
+		}
+	}
+
+	return required, nil
+}
+
+// boundKeys returns the schema keywords bounding the values of a type
+func boundKeys(fieldType reflect.Type) []string {
+	if _, ok := bsonTypes[fieldType]; ok || marshals(fieldType) {
+		return nil
+	}
+	if fieldType.Kind() == reflect.Array && fieldType.Elem().Kind() == reflect.Uint8 {
+		return nil
+	}
+
+	switch fieldType.Kind() {
+	case reflect.String:
+		return []string{"minLength", "maxLength"}
+	case reflect.Slice, reflect.Array:
+		return []string{"minItems", "maxItems"}
+	case reflect.Map:
+		return []string{"minProperties", "maxProperties"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return []string{"minimum", "maximum"}
+	}
+
+	return nil
+}
+
+// ruleValue converts a value of a validate rule to the type of the field
+func ruleValue(fieldType reflect.Type, value string) (interface{}, error) {
+	switch fieldType.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.ParseInt(value, 10, 64)
+	case reflect.Float32, reflect.Float64:
+		return strconv.ParseFloat(value, 64)
+	}
+
+	return value, nil
+}
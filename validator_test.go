@@ -0,0 +1,148 @@
+package mongodb
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type validatorAddress struct {
+	City string `bson:"city" validate:"required"`
+}
+
+type validatorTimestamps struct {
+	CreatedAt time.Time `bson:"created_at"`
+}
+
+type validatorUser struct {
+	ID                  primitive.ObjectID `bson:"_id,omitempty"`
+	Name                string             `bson:"name" validate:"required,min=2,max=50"`
+	Role                string             `bson:"role" validate:"oneof=admin user"`
+	Age                 int                `bson:"age" validate:"gte=18"`
+	Score               *float64           `bson:"score,omitempty" validate:"omitempty,max=9.5"`
+	Tags                []string           `bson:"tags" validate:"max=3,dive,min=1"`
+	Address             validatorAddress   `bson:"address"`
+	Metadata            map[string]any     `bson:"metadata"`
+	Extra               interface{}        `bson:"extra"`
+	Active              bool
+	Ignored             string `bson:"-"`
+	validatorTimestamps `bson:",inline"`
+	password            string
+}
+
+type validatorNode struct {
+	Name     string          `bson:"name"`
+	Children []validatorNode `bson:"children"`
+}
+
+type validatorStatus int
+
+func (s validatorStatus) MarshalBSONValue() (bsontype.Type, []byte, error) {
+	return bson.MarshalValue(strconv.Itoa(int(s)))
+}
+
+type validatorSettings map[string]string
+
+func (s *validatorSettings) MarshalBSON() ([]byte, error) {
+	return bson.Marshal(map[string]string(*s))
+}
+
+type validatorFile struct {
+	Checksum [16]byte          `bson:"checksum" validate:"len=16"`
+	Sizes    [2]int            `bson:"sizes" validate:"len=2"`
+	Status   validatorStatus   `bson:"status" validate:"required,oneof=1 2,min=1"`
+	Settings validatorSettings `bson:"settings"`
+}
+
+type ValidatorSuite struct {
+	suite.Suite
+}
+
+func TestValidatorSuite(t *testing.T) {
+	suite.Run(t, &ValidatorSuite{})
+}
+
+func (s *ValidatorSuite) TestValidator() {
+	validator, err := Validator(&validatorUser{})
+	s.NoError(err)
+	s.Equal(bson.D{{Key: "$jsonSchema", Value: bson.D{
+		{Key: "bsonType", Value: "object"},
+		{Key: "properties", Value: bson.D{
+			{Key: "_id", Value: bson.D{{Key: "bsonType", Value: "objectId"}}},
+			{Key: "name", Value: bson.D{{Key: "bsonType", Value: "string"}, {Key: "minLength", Value: int64(2)}, {Key: "maxLength", Value: int64(50)}}},
+			{Key: "role", Value: bson.D{{Key: "bsonType", Value: "string"}, {Key: "enum", Value: bson.A{"admin", "user"}}}},
+			{Key: "age", Value: bson.D{{Key: "bsonType", Value: bson.A{"int", "long"}}, {Key: "minimum", Value: int64(18)}}},
+			{Key: "score", Value: bson.D{{Key: "bsonType", Value: bson.A{"double", "null"}}, {Key: "maximum", Value: 9.5}}},
+			{Key: "tags", Value: bson.D{
+				{Key: "bsonType", Value: bson.A{"array", "null"}},
+				{Key: "items", Value: bson.D{{Key: "bsonType", Value: "string"}}},
+				{Key: "maxItems", Value: int64(3)},
+			}},
+			{Key: "address", Value: bson.D{
+				{Key: "bsonType", Value: "object"},
+				{Key: "properties", Value: bson.D{{Key: "city", Value: bson.D{{Key: "bsonType", Value: "string"}}}}},
+				{Key: "required", Value: bson.A{"city"}},
+			}},
+			{Key: "metadata", Value: bson.D{{Key: "bsonType", Value: bson.A{"object", "null"}}}},
+			{Key: "extra", Value: bson.D{}},
+			{Key: "active", Value: bson.D{{Key: "bsonType", Value: "bool"}}},
+			{Key: "created_at", Value: bson.D{{Key: "bsonType", Value: "date"}}},
+		}},
+		{Key: "required", Value: bson.A{"name"}},
+	}}}, validator)
+}
+
+func (s *ValidatorSuite) TestValidatorRecursive() {
+	validator, err := Validator(validatorNode{})
+	s.NoError(err)
+	s.Equal(bson.D{{Key: "$jsonSchema", Value: bson.D{
+		{Key: "bsonType", Value: "object"},
+		{Key: "properties", Value: bson.D{
+			{Key: "name", Value: bson.D{{Key: "bsonType", Value: "string"}}},
+			{Key: "children", Value: bson.D{
+				{Key: "bsonType", Value: bson.A{"array", "null"}},
+				{Key: "items", Value: bson.D{{Key: "bsonType", Value: "object"}}},
+			}},
+		}},
+	}}}, validator)
+}
+
+func (s *ValidatorSuite) TestValidatorArraysAndMarshalers() {
+	validator, err := Validator(validatorFile{})
+	s.NoError(err)
+	s.Equal(bson.D{{Key: "$jsonSchema", Value: bson.D{
+		{Key: "bsonType", Value: "object"},
+		{Key: "properties", Value: bson.D{
+			{Key: "checksum", Value: bson.D{{Key: "bsonType", Value: "binData"}}},
+			{Key: "sizes", Value: bson.D{
+				{Key: "bsonType", Value: "array"},
+				{Key: "items", Value: bson.D{{Key: "bsonType", Value: bson.A{"int", "long"}}}},
+				{Key: "minItems", Value: int64(2)},
+				{Key: "maxItems", Value: int64(2)},
+			}},
+			{Key: "status", Value: bson.D{}},
+			{Key: "settings", Value: bson.D{}},
+		}},
+		{Key: "required", Value: bson.A{"status"}},
+	}}}, validator)
+}
+
+func (s *ValidatorSuite) TestValidatorErrors() {
+	_, err := Validator(bson.M{"name": "Goravel"})
+	s.EqualError(err, "primitive.M is not a struct")
+
+	_, err = Validator(struct {
+		Callback func()
+	}{})
+	s.ErrorContains(err, "field Callback: type func() cannot be stored in MongoDB")
+
+	_, err = Validator(struct {
+		Age int `validate:"min=young"`
+	}{})
+	s.ErrorContains(err, `field Age: invalid min value "young"`)
+}